@@ -0,0 +1,289 @@
+package addressbook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/decred/dcrd/chaincfg"
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/raedahgroup/dcrlibwallet/txhelper"
+	"github.com/raedahgroup/godcr/app/config"
+	"github.com/raedahgroup/godcr/app/walletcore"
+)
+
+// ContactPrefix marks a destination as the name of a contact rather than an address, e.g. @alice
+const ContactPrefix = "@"
+
+// Contact is a named address that funds can be sent to
+type Contact struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+// AddressBook holds the contacts saved for a network. Changes are saved to a json file immediately
+type AddressBook struct {
+	mu        sync.RWMutex
+	path      string
+	netParams *chaincfg.Params
+	contacts  []*Contact
+}
+
+var (
+	openBooksMu sync.Mutex
+	openBooks   = make(map[string]*AddressBook)
+)
+
+// Open returns the address book for `netType`, loading it from the godcr app data directory the first time it is opened.
+// The same instance is returned to every caller, so changes made by one frontend are seen by the others
+func Open(netType string) (*AddressBook, error) {
+	openBooksMu.Lock()
+	defer openBooksMu.Unlock()
+
+	if book, ok := openBooks[netType]; ok {
+		return book, nil
+	}
+
+	book, err := Load(config.AddressBookFile(netType), netType)
+	if err != nil {
+		return nil, err
+	}
+	openBooks[netType] = book
+	return book, nil
+}
+
+// Load reads the address book saved in the file at `path`. Addresses added to the book must be valid for `netType`
+func Load(path, netType string) (*AddressBook, error) {
+	book := &AddressBook{
+		path:      path,
+		netParams: &chaincfg.MainNetParams,
+	}
+	if netType != "mainnet" {
+		book.netParams = &chaincfg.TestNet3Params
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return book, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading address book: %s", err.Error())
+	}
+
+	if err = json.Unmarshal(data, &book.contacts); err != nil {
+		return nil, fmt.Errorf("error decoding address book: %s", err.Error())
+	}
+	return book, nil
+}
+
+// Contacts returns a copy of all contacts, sorted by name
+func (book *AddressBook) Contacts() []*Contact {
+	book.mu.RLock()
+	defer book.mu.RUnlock()
+
+	contacts := make([]*Contact, len(book.contacts))
+	for i, contact := range book.contacts {
+		contacts[i] = &Contact{Name: contact.Name, Address: contact.Address}
+	}
+	sort.Slice(contacts, func(i, j int) bool {
+		return strings.ToLower(contacts[i].Name) < strings.ToLower(contacts[j].Name)
+	})
+	return contacts
+}
+
+// Contact returns the contact named `name`, ignoring case
+func (book *AddressBook) Contact(name string) (*Contact, bool) {
+	book.mu.RLock()
+	defer book.mu.RUnlock()
+
+	if index := book.indexOf(name); index >= 0 {
+		contact := book.contacts[index]
+		return &Contact{Name: contact.Name, Address: contact.Address}, true
+	}
+	return nil, false
+}
+
+// NameForAddress returns the name of the contact with `address`, or an empty string if no contact has the address
+func (book *AddressBook) NameForAddress(address string) string {
+	book.mu.RLock()
+	defer book.mu.RUnlock()
+
+	for _, contact := range book.contacts {
+		if contact.Address == address {
+			return contact.Name
+		}
+	}
+	return ""
+}
+
+// HasContacts returns true if the address book has at least one contact
+func (book *AddressBook) HasContacts() bool {
+	book.mu.RLock()
+	defer book.mu.RUnlock()
+	return len(book.contacts) > 0
+}
+
+// OutputContacts returns the names of the contacts whose addresses are paid by `outputs`
+func (book *AddressBook) OutputContacts(outputs []*txhelper.DecodedOutput) []string {
+	var names []string
+	for _, output := range outputs {
+		for _, address := range output.Addresses {
+			if name := book.NameForAddress(address.Address); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// TransactionContacts returns the names of the contacts paid by each of `transactions`, mapped by transaction hash.
+// Callers pass only the transactions being displayed, e.g. a page of history, since the details of each transaction
+// are read from `wallet` to find its outputs. Only regular transactions sent from the wallet can pay contacts, so
+// other transactions are skipped, and nothing is read if the address book is empty
+func (book *AddressBook) TransactionContacts(wallet walletcore.Wallet, transactions []*walletcore.Transaction) (map[string][]string, error) {
+	contacts := make(map[string][]string)
+	if !book.HasContacts() {
+		return contacts, nil
+	}
+
+	for _, tx := range transactions {
+		if tx.Direction != txhelper.TransactionDirectionSent || tx.Type != walletcore.TransactionTypeRegular {
+			continue
+		}
+
+		txDetails, err := wallet.GetTransaction(tx.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("error reading transaction %s: %s", tx.Hash, err.Error())
+		}
+		if names := book.OutputContacts(txDetails.Outputs); len(names) > 0 {
+			contacts[tx.Hash] = names
+		}
+	}
+	return contacts, nil
+}
+
+// ResolveAddress returns the address of the contact if `destination` is a contact name prefixed with ContactPrefix.
+// Other destinations are returned as is
+func (book *AddressBook) ResolveAddress(destination string) (string, error) {
+	if !strings.HasPrefix(destination, ContactPrefix) {
+		return destination, nil
+	}
+
+	name := strings.TrimPrefix(destination, ContactPrefix)
+	contact, ok := book.Contact(name)
+	if !ok {
+		return "", fmt.Errorf("no contact named %s in address book", name)
+	}
+	return contact.Address, nil
+}
+
+// Add saves a new contact
+func (book *AddressBook) Add(name, address string) error {
+	name, address = strings.TrimSpace(name), strings.TrimSpace(address)
+	if err := book.validate(name, address); err != nil {
+		return err
+	}
+
+	book.mu.Lock()
+	defer book.mu.Unlock()
+
+	if book.indexOf(name) >= 0 {
+		return fmt.Errorf("a contact named %s already exists", name)
+	}
+
+	book.contacts = append(book.contacts, &Contact{Name: name, Address: address})
+	return book.save()
+}
+
+// Edit changes the name and address of the contact named `name`. An empty `newName` or `newAddress` leaves that value unchanged
+func (book *AddressBook) Edit(name, newName, newAddress string) error {
+	book.mu.Lock()
+	defer book.mu.Unlock()
+
+	index := book.indexOf(name)
+	if index < 0 {
+		return fmt.Errorf("no contact named %s in address book", name)
+	}
+	contact := book.contacts[index]
+
+	newName, newAddress = strings.TrimSpace(newName), strings.TrimSpace(newAddress)
+	if newName == "" {
+		newName = contact.Name
+	}
+	if newAddress == "" {
+		newAddress = contact.Address
+	}
+	if err := book.validate(newName, newAddress); err != nil {
+		return err
+	}
+	if existing := book.indexOf(newName); existing >= 0 && existing != index {
+		return fmt.Errorf("a contact named %s already exists", newName)
+	}
+
+	book.contacts[index] = &Contact{Name: newName, Address: newAddress}
+	return book.save()
+}
+
+// Remove deletes the contact named `name`
+func (book *AddressBook) Remove(name string) error {
+	book.mu.Lock()
+	defer book.mu.Unlock()
+
+	index := book.indexOf(name)
+	if index < 0 {
+		return fmt.Errorf("no contact named %s in address book", name)
+	}
+
+	book.contacts = append(book.contacts[:index], book.contacts[index+1:]...)
+	return book.save()
+}
+
+// validate checks that `name` can be used as a send destination and that `address` is valid for the book's network
+func (book *AddressBook) validate(name, address string) error {
+	if name == "" {
+		return fmt.Errorf("contact name is required")
+	}
+	if strings.HasPrefix(name, ContactPrefix) || strings.Contains(name, ":") {
+		return fmt.Errorf("contact name cannot start with %s or contain ':'", ContactPrefix)
+	}
+
+	decodedAddress, err := dcrutil.DecodeAddress(address)
+	if err != nil {
+		return fmt.Errorf("invalid address %s: %s", address, err.Error())
+	}
+	if !decodedAddress.IsForNet(book.netParams) {
+		return fmt.Errorf("address %s is not for %s", address, book.netParams.Name)
+	}
+	return nil
+}
+
+// indexOf returns the index of the contact named `name` ignoring case, or -1. Must be called with book.mu held
+func (book *AddressBook) indexOf(name string) int {
+	for i, contact := range book.contacts {
+		if strings.EqualFold(contact.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// save writes the contacts to the address book file. Must be called with book.mu held
+func (book *AddressBook) save() error {
+	data, err := json.MarshalIndent(book.contacts, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding address book: %s", err.Error())
+	}
+
+	if err = os.MkdirAll(filepath.Dir(book.path), os.ModePerm); err != nil {
+		return fmt.Errorf("error creating address book directory: %s", err.Error())
+	}
+	if err = ioutil.WriteFile(book.path, data, 0600); err != nil {
+		return fmt.Errorf("error saving address book: %s", err.Error())
+	}
+	return nil
+}
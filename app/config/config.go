@@ -30,32 +30,45 @@ type Config struct {
 
 // ConfFileOptions holds the top-level options/flags that are best set in config file rather than in command-line
 type ConfFileOptions struct {
+	WalletOptions
+	WalletProfiles []string `long:"walletprofile" description:"Named wallet profile in the format name=<name>;<option>=<value>;... Options not set in a profile are taken from the top-level wallet options"`
+	HTTPHost       string   `long:"httphost" description:"HTTP server host address or IP"`
+	HTTPPort       string   `long:"httpport" description:"HTTP server port"`
+}
+
+// WalletOptions holds the options that determine which wallet godcr opens and how godcr connects to the wallet
+// Each wallet profile can set its own values for these options
+type WalletOptions struct {
 	AppDataDir      string `short:"A" long:"appdata" description:"Path to application data directory"`
 	UseTestNet      bool   `short:"t" long:"testnet" description:"Connects to testnet wallet instead of mainnet"`
 	UseWalletRPC    bool   `short:"w" long:"usewalletrpc" description:"Connect to a running drcwallet daemon over rpc to perform wallet operations"`
+	UseMockWallet   bool   `long:"usemockwallet" description:"Use an in-memory wallet populated with fake data instead of a real decred wallet. Meant for testing and demos"`
+	EmptyMockWallet bool   `long:"emptymockwallet" description:"Start the mock wallet without a wallet, so that a wallet must be created or restored first. Only used with usemockwallet"`
 	WalletRPCServer string `long:"walletrpcserver" description:"Wallet RPC server address to connect to"`
 	WalletRPCCert   string `long:"walletrpccert" description:"Path to dcrwallet certificate file"`
 	NoWalletRPCTLS  bool   `long:"nowalletrpctls" description:"Disable TLS when connecting to dcrwallet daemon via RPC"`
-	HTTPHost        string `long:"httphost" description:"HTTP server host address or IP"`
-	HTTPPort        string `long:"httpport" description:"HTTP server port"`
 }
 
 // CommandLineOptions holds the top-level options/flags that are displayed on the command-line menu
 type CommandLineOptions struct {
 	InterfaceMode string `long:"mode" description:"Interface mode to run" choice:"cli" choice:"http" choice:"nuklear" choice:"qt" default:"cli"`
+	WalletName    string `long:"wallet" description:"Name of the wallet profile to open. The wallet set by the top-level wallet options in the config file is opened by default"`
 	CliOptions
 }
 
 type CliOptions struct {
-	SyncBlockchain bool `long:"sync" description:"Syncs blockchain when running in cli mode. If used with a command, command is executed after blockchain syncs"`
+	SyncBlockchain bool   `long:"sync" description:"Syncs blockchain when running in cli mode. If used with a command, command is executed after blockchain syncs"`
+	OutputFormat   string `long:"output" description:"Format for printing command results when running in cli mode. Use json or csv for output that will be parsed by other programs" choice:"table" choice:"json" choice:"csv" default:"table"`
 }
 
 func defaultFileOptions() ConfFileOptions {
 	return ConfFileOptions{
-		AppDataDir:    defaultAppDataDir,
-		WalletRPCCert: defaultRPCCertFile,
-		HTTPHost:      defaultHTTPHost,
-		HTTPPort:      defaultHTTPPort,
+		WalletOptions: WalletOptions{
+			AppDataDir:    defaultAppDataDir,
+			WalletRPCCert: defaultRPCCertFile,
+		},
+		HTTPHost: defaultHTTPHost,
+		HTTPPort: defaultHTTPPort,
 	}
 }
 
@@ -135,10 +148,21 @@ func hasConfigFileOption(unknownArgs []string) bool {
 }
 
 // configFileOptions returns a slice of the short names and long names of all config file options
-func configFileOptions() (options []string) {
-	tConfFileOptions := reflect.TypeOf(ConfFileOptions{})
-	for i := 0; i < tConfFileOptions.NumField(); i++ {
-		fieldTag := tConfFileOptions.Field(i).Tag
+func configFileOptions() []string {
+	return optionNames(reflect.TypeOf(ConfFileOptions{}))
+}
+
+// optionNames returns the short names and long names of the options defined by the fields of `structType`
+// including options defined in embedded structs
+func optionNames(structType reflect.Type) (options []string) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			options = append(options, optionNames(field.Type)...)
+			continue
+		}
+
+		fieldTag := field.Tag
 
 		if shortName, ok := fieldTag.Lookup("short"); ok {
 			options = append(options, "-"+shortName)
@@ -25,9 +25,27 @@ appdata={{.AppDataDir}}
 ; nowalletrpctls=0
 walletrpccert={{.WalletRPCCert}}
 
+; Use an in-memory wallet populated with fake accounts, transactions and tickets instead of a real decred wallet.
+; Nothing is saved to disk and no network connection is made. Meant for testing and demos only.
+; The spending passphrase of the fake wallet is "mockwallet".
+; usemockwallet=false
+
+; Start the mock wallet without a wallet, so that wallet creation and restoration can be tried out. Only used if usemockwallet=true
+; emptymockwallet=false
+
 ; Connects to testnet wallet instead of mainnet
 ; testnet=false
 
+; ------------------------------------------------------------------------------
+; Wallet Profiles
+; ------------------------------------------------------------------------------
+
+; The wallet options above define the default wallet. Additional wallets can be added as named profiles
+; and opened with --wallet=<name>. Profile options not set are taken from the wallet options above.
+; Use 'godcr wallets add' and 'godcr wallets remove' to manage profiles or add them here, one per line.
+; walletprofile=name=treasury;appdata=/path/to/treasury/appdata
+; walletprofile=name=operations;testnet=true;usewalletrpc=true;walletrpcserver=localhost:19111
+
 ; ------------------------------------------------------------------------------
 ; Godcr Interface Modes
 ; ------------------------------------------------------------------------------
@@ -0,0 +1,308 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultWalletProfileName is the name of the wallet defined by the top-level wallet options in the config file
+	DefaultWalletProfileName = "default"
+
+	walletProfileOption = "walletprofile"
+	profileNameKey      = "name"
+	profileSeparator    = ";"
+)
+
+// WalletProfile is a named set of wallet options, allowing godcr to work with several wallets
+type WalletProfile struct {
+	Name string
+	WalletOptions
+}
+
+// NetType returns the network of the profile's wallet, mainnet or testnet
+func (profile *WalletProfile) NetType() string {
+	if profile.UseTestNet {
+		return "testnet"
+	}
+	return "mainnet"
+}
+
+// Medium returns the name of the medium used to access the profile's wallet
+func (profile *WalletProfile) Medium() string {
+	switch {
+	case profile.UseMockWallet:
+		return "mockwallet"
+	case profile.UseWalletRPC:
+		return "dcrwalletrpc"
+	default:
+		return "dcrlibwallet"
+	}
+}
+
+// Location returns the dcrwallet rpc server address or the app data directory of the profile's wallet
+func (profile *WalletProfile) Location() string {
+	switch {
+	case profile.UseMockWallet:
+		return "in-memory"
+	case profile.UseWalletRPC:
+		return profile.WalletRPCServer
+	default:
+		return profile.AppDataDir
+	}
+}
+
+// LockedOutputsFile returns the path of the file in the godcr app data directory
+// where the unspent outputs locked by the user in the profile's wallet are saved
+func (profile *WalletProfile) LockedOutputsFile() string {
+	return filepath.Join(defaultAppDataDir, "lockedoutputs", fmt.Sprintf("%s-%s.json", profile.Name, profile.NetType()))
+}
+
+// HiddenAccountsFile returns the path of the file in the godcr app data directory
+// where the accounts hidden by the user in the profile's wallet are saved
+func (profile *WalletProfile) HiddenAccountsFile() string {
+	return filepath.Join(defaultAppDataDir, "hiddenaccounts", fmt.Sprintf("%s-%s.json", profile.Name, profile.NetType()))
+}
+
+// LabelsFile returns the path of the file in the godcr app data directory
+// where the labels set by the user for records in the profile's wallet are saved
+func (profile *WalletProfile) LabelsFile() string {
+	return filepath.Join(defaultAppDataDir, "labels", fmt.Sprintf("%s-%s.jsonl", profile.Name, profile.NetType()))
+}
+
+// SeedBackupFile returns the path of the file in the godcr app data directory where the seed of the profile's wallet
+// is kept, encrypted, until the user confirms they backed it up
+func (profile *WalletProfile) SeedBackupFile() string {
+	return filepath.Join(defaultAppDataDir, "seedbackups", fmt.Sprintf("%s-%s.json", profile.Name, profile.NetType()))
+}
+
+// dataFiles returns the paths of the files in the godcr app data directory that hold data for the profile's wallet
+func (profile *WalletProfile) dataFiles() []string {
+	return []string{
+		profile.LockedOutputsFile(),
+		profile.HiddenAccountsFile(),
+		profile.LabelsFile(),
+		profile.SeedBackupFile(),
+	}
+}
+
+// WalletProfiles returns the default wallet profile, made up of the top-level wallet options,
+// followed by the wallet profiles set in the config file
+func (config Config) WalletProfiles() ([]*WalletProfile, error) {
+	profiles := []*WalletProfile{{
+		Name:          DefaultWalletProfileName,
+		WalletOptions: config.WalletOptions,
+	}}
+
+	for _, profileValue := range config.ConfFileOptions.WalletProfiles {
+		profile, err := ParseWalletProfile(profileValue, config.WalletOptions)
+		if err != nil {
+			return nil, err
+		}
+		for _, existingProfile := range profiles {
+			if existingProfile.Name == profile.Name {
+				return nil, fmt.Errorf("duplicate wallet profile name: %s", profile.Name)
+			}
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, nil
+}
+
+// ParseWalletProfile parses a wallet profile in the format `name=<name>;<option>=<value>;...`
+// where <option> is the long name of any wallet option such as appdata or testnet
+// Options not set in the profile are copied from `defaults`
+func ParseWalletProfile(profileValue string, defaults WalletOptions) (*WalletProfile, error) {
+	profile := &WalletProfile{
+		WalletOptions: defaults,
+	}
+	options := reflect.ValueOf(&profile.WalletOptions).Elem()
+
+	for _, keyValue := range strings.Split(profileValue, profileSeparator) {
+		keyValue = strings.TrimSpace(keyValue)
+		if keyValue == "" {
+			continue
+		}
+
+		keyValueParts := strings.SplitN(keyValue, "=", 2)
+		if len(keyValueParts) != 2 {
+			return nil, fmt.Errorf("invalid wallet profile option %q, use the format <option>=<value>", keyValue)
+		}
+		key, value := strings.TrimSpace(keyValueParts[0]), strings.TrimSpace(keyValueParts[1])
+
+		if key == profileNameKey {
+			profile.Name = value
+			continue
+		}
+
+		field, ok := walletOptionField(options, key)
+		if !ok {
+			return nil, fmt.Errorf("unknown wallet profile option: %s", key)
+		}
+		if field.Kind() == reflect.Bool {
+			boolValue, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for wallet profile option %s: %s", key, value)
+			}
+			field.SetBool(boolValue)
+		} else {
+			field.SetString(value)
+		}
+	}
+
+	if err := ValidateWalletProfileName(profile.Name); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// ValidateWalletProfileName checks that `name` can be used to name a new wallet profile
+func ValidateWalletProfileName(name string) error {
+	if name == "" {
+		return fmt.Errorf("wallet profile name is required")
+	}
+	if name == DefaultWalletProfileName {
+		return fmt.Errorf("%s is reserved for the wallet set by the top-level wallet options", DefaultWalletProfileName)
+	}
+	if strings.ContainsAny(name, profileSeparator+"= \t") {
+		return fmt.Errorf("wallet profile name cannot contain spaces, = or %s", profileSeparator)
+	}
+	return nil
+}
+
+// Encode encodes the profile in the format parsed by ParseWalletProfile
+// Only options that differ from `defaults` are included
+func (profile *WalletProfile) Encode(defaults WalletOptions) string {
+	keyValues := []string{profileNameKey + "=" + profile.Name}
+
+	options := reflect.ValueOf(profile.WalletOptions)
+	defaultOptions := reflect.ValueOf(defaults)
+	optionsType := options.Type()
+	for i := 0; i < optionsType.NumField(); i++ {
+		if options.Field(i).Interface() == defaultOptions.Field(i).Interface() {
+			continue
+		}
+		key := optionsType.Field(i).Tag.Get("long")
+		keyValues = append(keyValues, fmt.Sprintf("%s=%v", key, options.Field(i).Interface()))
+	}
+
+	return strings.Join(keyValues, profileSeparator)
+}
+
+// DefaultProfileAppDataDir returns the directory used for the data of a new dcrlibwallet wallet profile
+// if no app data directory is set for the profile
+func DefaultProfileAppDataDir(profileName string) string {
+	return filepath.Join(defaultAppDataDir, "wallets", profileName)
+}
+
+// AddWalletProfile saves `profile` to the config file, creating the config file if it does not exist.
+// Options that match `defaults` are not saved, so `defaults` must be the options the profile is parsed with when loaded,
+// i.e. the top-level wallet options
+func AddWalletProfile(profile *WalletProfile, defaults WalletOptions) error {
+	if err := os.MkdirAll(filepath.Dir(AppConfigFilePath), os.ModePerm); err != nil {
+		return fmt.Errorf("error creating config file directory: %s", err.Error())
+	}
+
+	configFile, err := os.OpenFile(AppConfigFilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening config file: %s", err.Error())
+	}
+	defer configFile.Close()
+
+	_, err = fmt.Fprintf(configFile, "\n%s=%s\n", walletProfileOption, profile.Encode(defaults))
+	if err != nil {
+		return fmt.Errorf("error saving wallet profile to config file: %s", err.Error())
+	}
+	return nil
+}
+
+// RemoveWalletProfile removes the wallet profile named `name` from the config file, leaving other config file content unchanged.
+// The files godcr keeps for the profile, such as labels and locked outputs, are renamed so that they are not picked up
+// by a new profile with the same name. The files are renamed rather than deleted since they may hold the unconfirmed seed of the wallet
+func RemoveWalletProfile(name string) error {
+	content, err := ioutil.ReadFile(AppConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("error reading config file: %s", err.Error())
+	}
+
+	var updatedLines []string
+	var removed bool
+	scanner := bufio.NewScanner(strings.NewReader(string(content)))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if profileName, isProfile := walletProfileLineName(line); isProfile && profileName == name {
+			removed = true
+			continue
+		}
+		updatedLines = append(updatedLines, line)
+	}
+	if err = scanner.Err(); err != nil {
+		return fmt.Errorf("error reading config file: %s", err.Error())
+	}
+	if !removed {
+		return fmt.Errorf("no wallet profile named %s in config file", name)
+	}
+
+	err = ioutil.WriteFile(AppConfigFilePath, []byte(strings.Join(updatedLines, "\n")+"\n"), 0644)
+	if err != nil {
+		return fmt.Errorf("error saving config file: %s", err.Error())
+	}
+
+	return renameWalletProfileFiles(name)
+}
+
+// renameWalletProfileFiles adds a `.removed-<timestamp>` suffix to the mainnet and testnet data files of the wallet profile named `name`
+func renameWalletProfileFiles(name string) error {
+	suffix := fmt.Sprintf(".removed-%d", time.Now().Unix())
+	for _, useTestNet := range []bool{false, true} {
+		profile := &WalletProfile{Name: name, WalletOptions: WalletOptions{UseTestNet: useTestNet}}
+		for _, path := range profile.dataFiles() {
+			err := os.Rename(path, path+suffix)
+			if err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("error renaming wallet profile file %s: %s", path, err.Error())
+			}
+		}
+	}
+	return nil
+}
+
+// walletProfileLineName returns the name of the wallet profile set on a config file line, if the line sets a wallet profile
+func walletProfileLineName(line string) (string, bool) {
+	keyValue := strings.SplitN(strings.TrimSpace(line), "=", 2)
+	if len(keyValue) != 2 || strings.TrimSpace(keyValue[0]) != walletProfileOption {
+		return "", false
+	}
+
+	for _, profileKeyValue := range strings.Split(keyValue[1], profileSeparator) {
+		profileKeyValueParts := strings.SplitN(strings.TrimSpace(profileKeyValue), "=", 2)
+		if len(profileKeyValueParts) == 2 && strings.TrimSpace(profileKeyValueParts[0]) == profileNameKey {
+			return strings.TrimSpace(profileKeyValueParts[1]), true
+		}
+	}
+	return "", true
+}
+
+// walletOptionField returns the field of `options` whose long option name is `longName`
+func walletOptionField(options reflect.Value, longName string) (reflect.Value, bool) {
+	optionsType := options.Type()
+	for i := 0; i < optionsType.NumField(); i++ {
+		if optionsType.Field(i).Tag.Get("long") == longName {
+			return options.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// AddressBookFile returns the path of the file in the godcr app data directory where the address book for `netType` is saved.
+// Addresses are only valid on one network, so mainnet and testnet wallets have separate address books
+func AddressBookFile(netType string) string {
+	return filepath.Join(defaultAppDataDir, "addressbook", netType+".json")
+}
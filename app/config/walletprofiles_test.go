@@ -0,0 +1,158 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseWalletProfile(t *testing.T) {
+	defaults := WalletOptions{
+		AppDataDir:      "/default/appdata",
+		WalletRPCServer: "127.0.0.1:9111",
+	}
+
+	profile, err := ParseWalletProfile("name=work; testnet=true ;usewalletrpc=1;walletrpcserver=10.0.0.2:19111;", defaults)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	expected := &WalletProfile{
+		Name: "work",
+		WalletOptions: WalletOptions{
+			AppDataDir:      "/default/appdata",
+			UseTestNet:      true,
+			UseWalletRPC:    true,
+			WalletRPCServer: "10.0.0.2:19111",
+		},
+	}
+	if !reflect.DeepEqual(profile, expected) {
+		t.Errorf("got profile %+v, expected %+v", profile, expected)
+	}
+
+	invalidProfiles := map[string]string{
+		"no name":             "testnet=true",
+		"reserved name":       "name=default",
+		"name with spaces":    "name=my wallet",
+		"option without =":    "name=work;testnet",
+		"unknown option":      "name=work;color=blue",
+		"invalid bool option": "name=work;testnet=maybe",
+	}
+	for description, profileValue := range invalidProfiles {
+		if _, err := ParseWalletProfile(profileValue, defaults); err == nil {
+			t.Errorf("%s: expected an error parsing %q", description, profileValue)
+		}
+	}
+}
+
+func TestWalletProfileEncode(t *testing.T) {
+	defaults := WalletOptions{AppDataDir: "/default/appdata"}
+	profile := &WalletProfile{
+		Name:          "work",
+		WalletOptions: WalletOptions{AppDataDir: "/default/appdata", UseTestNet: true, WalletRPCServer: "10.0.0.2:19111"},
+	}
+
+	// options that match the defaults are left out
+	encoded := profile.Encode(defaults)
+	if expected := "name=work;testnet=true;walletrpcserver=10.0.0.2:19111"; encoded != expected {
+		t.Errorf("got %q, expected %q", encoded, expected)
+	}
+
+	decoded, err := ParseWalletProfile(encoded, defaults)
+	if err != nil {
+		t.Fatalf("unexpected error decoding %q: %s", encoded, err.Error())
+	}
+	if !reflect.DeepEqual(decoded, profile) {
+		t.Errorf("decoded %+v, expected %+v", decoded, profile)
+	}
+}
+
+func TestWalletProfiles(t *testing.T) {
+	config := Config{ConfFileOptions: ConfFileOptions{
+		WalletOptions:  WalletOptions{UseTestNet: true},
+		WalletProfiles: []string{"name=work", "name=savings;testnet=false"},
+	}}
+
+	profiles, err := config.WalletProfiles()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	var names []string
+	for _, profile := range profiles {
+		names = append(names, profile.Name+"-"+profile.NetType())
+	}
+	if expected := []string{"default-testnet", "work-testnet", "savings-mainnet"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("got profiles %v, expected %v", names, expected)
+	}
+
+	config.ConfFileOptions.WalletProfiles = append(config.ConfFileOptions.WalletProfiles, "name=work;testnet=false")
+	if _, err := config.WalletProfiles(); err == nil {
+		t.Error("expected an error for duplicate profile names")
+	}
+}
+
+// useTestAppDataDir points the godcr app data directory and config file to a temporary directory until the returned function is called
+func useTestAppDataDir(t *testing.T) (cleanup func()) {
+	dir, err := ioutil.TempDir("", "godcr-config-test")
+	if err != nil {
+		t.Fatalf("error creating temporary directory: %s", err.Error())
+	}
+
+	appDataDir, configFilePath := defaultAppDataDir, AppConfigFilePath
+	defaultAppDataDir = dir
+	AppConfigFilePath = filepath.Join(dir, defaultConfigFilename)
+	return func() {
+		defaultAppDataDir, AppConfigFilePath = appDataDir, configFilePath
+		os.RemoveAll(dir)
+	}
+}
+
+func TestAddAndRemoveWalletProfile(t *testing.T) {
+	defer useTestAppDataDir(t)()
+
+	initialConfig := "; godcr config\nappdata=/default/appdata\nwalletprofile = name=work;testnet=true\n"
+	if err := ioutil.WriteFile(AppConfigFilePath, []byte(initialConfig), 0644); err != nil {
+		t.Fatalf("error writing config file: %s", err.Error())
+	}
+
+	defaults := WalletOptions{AppDataDir: "/default/appdata"}
+	profile := &WalletProfile{Name: "savings", WalletOptions: WalletOptions{AppDataDir: "/savings/appdata"}}
+	if err := AddWalletProfile(profile, defaults); err != nil {
+		t.Fatalf("unexpected error adding profile: %s", err.Error())
+	}
+
+	// the data files of the removed profile are renamed, not deleted
+	labelsFile := (&WalletProfile{Name: "work", WalletOptions: WalletOptions{UseTestNet: true}}).LabelsFile()
+	if err := os.MkdirAll(filepath.Dir(labelsFile), os.ModePerm); err != nil {
+		t.Fatalf("error creating labels directory: %s", err.Error())
+	}
+	if err := ioutil.WriteFile(labelsFile, []byte("{}\n"), 0644); err != nil {
+		t.Fatalf("error writing labels file: %s", err.Error())
+	}
+
+	if err := RemoveWalletProfile("work"); err != nil {
+		t.Fatalf("unexpected error removing profile: %s", err.Error())
+	}
+
+	content, err := ioutil.ReadFile(AppConfigFilePath)
+	if err != nil {
+		t.Fatalf("error reading config file: %s", err.Error())
+	}
+	expectedConfig := "; godcr config\nappdata=/default/appdata\n\nwalletprofile=name=savings;appdata=/savings/appdata\n"
+	if string(content) != expectedConfig {
+		t.Errorf("got config file %q, expected %q", string(content), expectedConfig)
+	}
+
+	if _, err := os.Stat(labelsFile); !os.IsNotExist(err) {
+		t.Errorf("labels file of the removed profile was not renamed")
+	}
+	renamedFiles, _ := filepath.Glob(labelsFile + ".removed-*")
+	if len(renamedFiles) != 1 {
+		t.Errorf("found %d renamed labels files, expected 1", len(renamedFiles))
+	}
+
+	if err := RemoveWalletProfile("work"); err == nil {
+		t.Error("expected an error removing a profile that is not in the config file")
+	}
+}
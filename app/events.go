@@ -0,0 +1,113 @@
+package app
+
+import "github.com/raedahgroup/godcr/app/walletcore"
+
+// names of the different wallet events, as returned by WalletEvent.EventType
+const (
+	EventTypeSyncProgress = "sync_progress"
+	EventTypeNewBlock     = "new_block"
+	EventTypeTransaction  = "transaction"
+	EventTypeConfirmation = "confirmation"
+	EventTypeTicketStatus = "ticket_status"
+	EventTypeAccount      = "account"
+	EventTypeDropped      = "dropped"
+)
+
+// ConfirmationsTracked is the number of confirmations up to which a ConfirmationEvent is sent for each new block
+// that confirms a wallet transaction further. No confirmation events are sent for a transaction after this
+const ConfirmationsTracked = 6
+
+// stages of the blockchain sync process reported in SyncProgressEvent
+const (
+	SyncStageStarted            = "started"
+	SyncStageFetchingHeaders    = "fetching_headers"
+	SyncStageDiscoveringAddress = "discovering_addresses"
+	SyncStageRescanningBlocks   = "rescanning_blocks"
+	SyncStageFinished           = "finished"
+)
+
+// WalletEvent is implemented by all events sent to subscribers of wallet events, see WalletMiddleware.Subscribe
+type WalletEvent interface {
+	// EventType returns the name of the event, for use by subscribers that serialize events
+	EventType() string
+}
+
+// SyncProgressEvent is sent as the blockchain sync process progresses
+type SyncProgressEvent struct {
+	Stage string `json:"stage"`
+
+	// Progress is the percentage completion of the current stage, if the stage reports progress
+	Progress int64 `json:"progress"`
+
+	// Error is set if the sync process ended with an error
+	Error string `json:"error,omitempty"`
+}
+
+// NewBlockEvent is sent when a new block is attached to the main chain
+type NewBlockEvent struct {
+	Height    int32  `json:"height"`
+	Hash      string `json:"hash,omitempty"`
+	Timestamp int64  `json:"timestamp,omitempty"`
+}
+
+// TransactionEvent is sent when a transaction that involves the wallet is first seen in the mempool or is mined
+type TransactionEvent struct {
+	Transaction *walletcore.Transaction `json:"transaction"`
+	Mined       bool                    `json:"mined"`
+	BlockHeight int32                   `json:"block_height,omitempty"`
+}
+
+// ConfirmationEvent is sent when a wallet transaction is mined and for every new block
+// that confirms the transaction further, until the transaction has `ConfirmationsTracked` confirmations
+type ConfirmationEvent struct {
+	TransactionHash string `json:"transaction_hash"`
+	BlockHeight     int32  `json:"block_height"`
+	Confirmations   int32  `json:"confirmations"`
+}
+
+// TicketStatusEvent is sent when a wallet ticket is purchased, mined, becomes live, is missed, expires, votes or is revoked
+type TicketStatusEvent struct {
+	TicketHash string `json:"ticket_hash"`
+	Status     string `json:"status"`
+
+	// TransactionHash is the hash of the ticket purchase, vote or revocation transaction that changed the ticket status.
+	// It is empty for tickets that became live, were missed or expired
+	TransactionHash string `json:"transaction_hash,omitempty"`
+}
+
+// AccountEvent is sent when a wallet account is created or its properties change
+type AccountEvent struct {
+	AccountNumber    uint32 `json:"account_number"`
+	AccountName      string `json:"account_name"`
+	ExternalKeyCount uint32 `json:"external_key_count,omitempty"`
+	InternalKeyCount uint32 `json:"internal_key_count,omitempty"`
+	ImportedKeyCount uint32 `json:"imported_key_count,omitempty"`
+}
+
+// DroppedEvent is sent to a subscriber that did not receive events fast enough, before the next event it has room for.
+// Count is the number of events that were dropped for the subscriber
+type DroppedEvent struct {
+	Count int `json:"count"`
+}
+
+// EventMessage wraps a wallet event with its type, for serializing events in a format that lets clients tell events apart
+type EventMessage struct {
+	Type string      `json:"type"`
+	Data WalletEvent `json:"data"`
+}
+
+// NewEventMessage returns an EventMessage for `event`
+func NewEventMessage(event WalletEvent) *EventMessage {
+	return &EventMessage{
+		Type: event.EventType(),
+		Data: event,
+	}
+}
+
+func (SyncProgressEvent) EventType() string { return EventTypeSyncProgress }
+func (NewBlockEvent) EventType() string     { return EventTypeNewBlock }
+func (TransactionEvent) EventType() string  { return EventTypeTransaction }
+func (ConfirmationEvent) EventType() string { return EventTypeConfirmation }
+func (TicketStatusEvent) EventType() string { return EventTypeTicketStatus }
+func (AccountEvent) EventType() string      { return EventTypeAccount }
+func (DroppedEvent) EventType() string      { return EventTypeDropped }
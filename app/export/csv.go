@@ -0,0 +1,79 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/decred/dcrd/dcrutil"
+)
+
+// csvDateFormat is a date format that spreadsheet applications recognize
+const csvDateFormat = "2006-01-02 15:04:05"
+
+var csvColumns = []string{
+	"Date",
+	"Hash",
+	"Type",
+	"Direction",
+	"Amount (DCR)",
+	"Fee (DCR)",
+	"Fee Rate (DCR/kB)",
+	"Confirmations",
+	"Block Height",
+	"Account",
+	"Account Change (DCR)",
+	"Account Balance (DCR)",
+	"Outputs",
+	"Label",
+}
+
+// writeCSV writes one row for each wallet account involved in each transaction in `records`
+func writeCSV(records []*record, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvColumns); err != nil {
+		return fmt.Errorf("error writing csv: %s", err.Error())
+	}
+
+	for _, txRecord := range records {
+		tx := txRecord.tx
+		txColumns := []string{
+			time.Unix(tx.Timestamp, 0).Format(csvDateFormat),
+			tx.Hash,
+			tx.Type,
+			tx.Direction.String(),
+			csvAmount(tx.Amount),
+			csvAmount(tx.Fee),
+			csvAmount(tx.FeeRate),
+			strconv.Itoa(int(tx.Confirmations)),
+			strconv.Itoa(int(tx.BlockHeight)),
+		}
+		outputs := outputsSummary(tx)
+
+		if len(txRecord.entries) == 0 {
+			row := append(txColumns, "", "", "", outputs, tx.Label)
+			if err := writer.Write(row); err != nil {
+				return fmt.Errorf("error writing csv: %s", err.Error())
+			}
+			continue
+		}
+
+		for _, entry := range txRecord.entries {
+			row := make([]string, 0, len(csvColumns))
+			row = append(row, txColumns...)
+			row = append(row, entry.accountName, csvAmount(entry.change), csvAmount(entry.balance), outputs, tx.Label)
+			if err := writer.Write(row); err != nil {
+				return fmt.Errorf("error writing csv: %s", err.Error())
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func csvAmount(amount dcrutil.Amount) string {
+	return strconv.FormatFloat(amount.ToCoin(), 'f', -1, 64)
+}
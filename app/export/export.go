@@ -0,0 +1,165 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/raedahgroup/godcr/app/walletcore"
+)
+
+// supported export formats
+const (
+	FormatCSV = "csv"
+	FormatOFX = "ofx"
+)
+
+// Formats lists the supported export formats
+var Formats = []string{FormatCSV, FormatOFX}
+
+// record holds the details of an exported transaction and its effect on each wallet account involved in the transaction
+type record struct {
+	tx      *walletcore.TransactionDetails
+	entries []*accountEntry
+}
+
+// accountEntry holds the amount by which a transaction changed the balance of a wallet account
+// and the account's balance after the transaction
+type accountEntry struct {
+	accountNumber uint32
+	accountName   string
+	change        dcrutil.Amount
+	balance       dcrutil.Amount
+}
+
+// TransactionHistory writes the wallet transactions that match `query` to `w` in the specified format.
+// Running account balances are calculated from the wallet's first transaction,
+// so the balances are correct even if `query` only selects transactions from a particular period.
+func TransactionHistory(wallet walletcore.Wallet, query *walletcore.TransactionHistoryQuery, format string, w io.Writer) error {
+	records, err := transactionRecords(wallet, query)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case FormatCSV:
+		return writeCSV(records, w)
+	case FormatOFX:
+		return writeOFX(records, w)
+	default:
+		return fmt.Errorf("unsupported export format %s, use one of %s", format, strings.Join(Formats, ", "))
+	}
+}
+
+// transactionRecords returns records for the transactions that match `query`, in the order specified by `query`
+func transactionRecords(wallet walletcore.Wallet, query *walletcore.TransactionHistoryQuery) ([]*record, error) {
+	selectedTxs, err := wallet.TransactionHistory(query)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching history: %s", err.Error())
+	}
+
+	// fetch all wallet transactions, oldest first, to calculate running balances
+	// from the account changes returned with each transaction
+	allTxs, err := wallet.TransactionHistory(&walletcore.TransactionHistoryQuery{
+		SortOrder: walletcore.SortOldestFirst,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching history: %s", err.Error())
+	}
+
+	accountNames := make(map[uint32]string)
+	accountName := func(accountNumber uint32) (string, error) {
+		if name, ok := accountNames[accountNumber]; ok {
+			return name, nil
+		}
+		name, err := wallet.AccountName(accountNumber)
+		if err != nil {
+			return "", fmt.Errorf("error fetching name of account %d: %s", accountNumber, err.Error())
+		}
+		accountNames[accountNumber] = name
+		return name, nil
+	}
+
+	balances := make(map[uint32]dcrutil.Amount)
+	txEntries := make(map[string][]*accountEntry, len(allTxs))
+	for _, tx := range allTxs {
+		entries := make([]*accountEntry, 0, len(tx.AccountChanges))
+		for _, change := range tx.AccountChanges {
+			name, err := accountName(change.Account)
+			if err != nil {
+				return nil, err
+			}
+
+			balances[change.Account] += change.Amount
+			entries = append(entries, &accountEntry{
+				accountNumber: change.Account,
+				accountName:   name,
+				change:        change.Amount,
+				balance:       balances[change.Account],
+			})
+		}
+		txEntries[tx.Hash] = entries
+	}
+
+	// only the selected transactions need their inputs and outputs fetched
+	selectedRecords := make([]*record, 0, len(selectedTxs))
+	for _, tx := range selectedTxs {
+		entries, ok := txEntries[tx.Hash]
+		if !ok {
+			continue
+		}
+
+		txDetails, err := wallet.GetTransaction(tx.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching transaction %s: %s", tx.Hash, err.Error())
+		}
+
+		txRecord := &record{tx: txDetails, entries: entries}
+		if query != nil && len(query.Accounts) > 0 {
+			txRecord = txRecord.forAccounts(query.Accounts)
+		}
+		selectedRecords = append(selectedRecords, txRecord)
+	}
+
+	return selectedRecords, nil
+}
+
+// forAccounts returns a copy of the record containing only entries for the specified accounts
+func (txRecord *record) forAccounts(accounts []uint32) *record {
+	filteredRecord := &record{tx: txRecord.tx}
+	for _, entry := range txRecord.entries {
+		for _, account := range accounts {
+			if entry.accountNumber == account {
+				filteredRecord.entries = append(filteredRecord.entries, entry)
+				break
+			}
+		}
+	}
+	return filteredRecord
+}
+
+// outputsSummary describes each output of `tx` as address (amount, account), followed by the output's label if it has one
+func outputsSummary(tx *walletcore.TransactionDetails) string {
+	outputs := make([]string, 0, len(tx.Outputs))
+	for index, output := range tx.Outputs {
+		amount := dcrutil.Amount(output.Value).String()
+		if len(output.Addresses) == 0 {
+			outputs = append(outputs, fmt.Sprintf("no address (%s)", amount))
+			continue
+		}
+
+		for _, address := range output.Addresses {
+			accountName := "external"
+			if address.IsMine {
+				accountName = address.AccountName
+			}
+			summary := fmt.Sprintf("%s (%s, %s)", address.Address, amount, accountName)
+			if label := tx.OutputLabels[index]; label != "" {
+				summary += fmt.Sprintf(" \"%s\"", label)
+			}
+			outputs = append(outputs, summary)
+		}
+	}
+	return strings.Join(outputs, "; ")
+}
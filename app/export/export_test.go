@@ -0,0 +1,178 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/raedahgroup/dcrlibwallet/txhelper"
+	"github.com/raedahgroup/godcr/app/walletcore"
+)
+
+// historyWallet is a walletcore.Wallet that only implements the methods used for exporting history
+type historyWallet struct {
+	walletcore.Wallet
+	transactions []*walletcore.Transaction
+}
+
+func (wallet *historyWallet) TransactionHistory(query *walletcore.TransactionHistoryQuery) ([]*walletcore.Transaction, error) {
+	return query.Apply(wallet.transactions), nil
+}
+
+func (wallet *historyWallet) AccountName(accountNumber uint32) (string, error) {
+	return fmt.Sprintf("account%d", accountNumber), nil
+}
+
+func (wallet *historyWallet) GetTransaction(transactionHash string) (*walletcore.TransactionDetails, error) {
+	for _, tx := range wallet.transactions {
+		if tx.Hash == transactionHash {
+			return &walletcore.TransactionDetails{
+				BlockHeight: 10,
+				Outputs: []*txhelper.DecodedOutput{
+					{Value: int64(tx.Amount), Addresses: []*txhelper.AddressInfo{{Address: "Dsaddress", IsMine: true, AccountName: "default"}}},
+				},
+				Transaction: tx,
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("transaction not found")
+}
+
+func exportTestWallet() *historyWallet {
+	transactions := []*walletcore.Transaction{
+		{Hash: "a", Amount: 10e8, Direction: txhelper.TransactionDirectionReceived, Type: walletcore.TransactionTypeRegular, Timestamp: 100},
+		{Hash: "b", Amount: 3e8, Direction: txhelper.TransactionDirectionTransferred, Type: walletcore.TransactionTypeRegular, Timestamp: 200},
+		{Hash: "c", Amount: 2e8, Direction: txhelper.TransactionDirectionSent, Type: walletcore.TransactionTypeRegular, Timestamp: 300, Label: "rent"},
+	}
+	transactions[0].AddAccountChange(0, 10e8)
+	// b moves 3 DCR from account 0 to account 1
+	transactions[1].AddAccountChange(0, -3e8)
+	transactions[1].AddAccountChange(1, 3e8)
+	transactions[2].AddAccountChange(1, -2e8)
+	return &historyWallet{transactions: transactions}
+}
+
+func TestTransactionRecordsRunningBalances(t *testing.T) {
+	wallet := exportTestWallet()
+
+	// balances are calculated from the first transaction even though it is not exported
+	records, err := transactionRecords(wallet, &walletcore.TransactionHistoryQuery{StartTime: 200})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	type entry struct {
+		hash            string
+		account         uint32
+		change, balance dcrutil.Amount
+	}
+	var entries []entry
+	for _, txRecord := range records {
+		for _, accountEntry := range txRecord.entries {
+			entries = append(entries, entry{txRecord.tx.Hash, accountEntry.accountNumber, accountEntry.change, accountEntry.balance})
+		}
+	}
+
+	expected := []entry{
+		{"c", 1, -2e8, 1e8},
+		{"b", 0, -3e8, 7e8},
+		{"b", 1, 3e8, 3e8},
+	}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Errorf("got entries %v, expected %v", entries, expected)
+	}
+
+	records, err = transactionRecords(wallet, &walletcore.TransactionHistoryQuery{Accounts: []uint32{1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	for _, txRecord := range records {
+		for _, accountEntry := range txRecord.entries {
+			if accountEntry.accountNumber != 1 {
+				t.Errorf("transaction %s has an entry for account %d, only account 1 was exported", txRecord.tx.Hash, accountEntry.accountNumber)
+			}
+		}
+	}
+}
+
+func TestTransactionHistoryCSV(t *testing.T) {
+	var output bytes.Buffer
+	query := &walletcore.TransactionHistoryQuery{SortOrder: walletcore.SortOldestFirst}
+	if err := TransactionHistory(exportTestWallet(), query, FormatCSV, &output); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	rows, err := csv.NewReader(&output).ReadAll()
+	if err != nil {
+		t.Fatalf("error reading exported csv: %s", err.Error())
+	}
+	if !reflect.DeepEqual(rows[0], csvColumns) {
+		t.Errorf("got header %v, expected %v", rows[0], csvColumns)
+	}
+
+	// one row per account changed by each transaction
+	if len(rows) != 5 {
+		t.Fatalf("got %d rows, expected a header and 4 account rows", len(rows))
+	}
+
+	// account, account change, account balance and label columns of each row
+	var accountColumns [][]string
+	for _, row := range rows[1:] {
+		accountColumns = append(accountColumns, []string{row[9], row[10], row[11], row[13]})
+	}
+	expected := [][]string{
+		{"account0", "10", "10", ""},
+		{"account0", "-3", "7", ""},
+		{"account1", "3", "3", ""},
+		{"account1", "-2", "1", "rent"},
+	}
+	if !reflect.DeepEqual(accountColumns, expected) {
+		t.Errorf("got account columns %v, expected %v", accountColumns, expected)
+	}
+}
+
+func TestTransactionHistoryOFX(t *testing.T) {
+	var output bytes.Buffer
+	if err := TransactionHistory(exportTestWallet(), nil, FormatOFX, &output); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	var document ofxDocument
+	if err := xml.Unmarshal(output.Bytes(), &document); err != nil {
+		t.Fatalf("error reading exported ofx: %s", err.Error())
+	}
+
+	// one statement per account, in the order the accounts first appear in the export, newest transactions first
+	if len(document.Bank) != 2 {
+		t.Fatalf("got %d statements, expected 2", len(document.Bank))
+	}
+
+	account1 := document.Bank[0].Statement
+	if account1.Account.AccountID != "1-account1" {
+		t.Errorf("first statement is for account %s, expected 1-account1", account1.Account.AccountID)
+	}
+	if account1.LedgerBalance.Amount != "1.00000000" {
+		t.Errorf("got account1 ledger balance %s, expected 1.00000000", account1.LedgerBalance.Amount)
+	}
+
+	account0 := document.Bank[1].Statement
+	var transactions []string
+	for _, tx := range account0.TransactionList.Transactions {
+		transactions = append(transactions, fmt.Sprintf("%s %s %s", tx.FITID, tx.Type, tx.Amount))
+	}
+	expected := []string{"b-0 DEBIT -3.00000000", "a-0 CREDIT 10.00000000"}
+	if !reflect.DeepEqual(transactions, expected) {
+		t.Errorf("got account0 transactions %v, expected %v", transactions, expected)
+	}
+	if account0.LedgerBalance.Amount != "7.00000000" {
+		t.Errorf("got account0 ledger balance %s, expected 7.00000000", account0.LedgerBalance.Amount)
+	}
+
+	if err := TransactionHistory(exportTestWallet(), nil, "qif", &output); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+}
@@ -0,0 +1,171 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/decred/dcrd/dcrutil"
+)
+
+const (
+	ofxHeader     = `<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>`
+	ofxDateFormat = "20060102150405"
+	ofxCurrency   = "DCR"
+)
+
+// the following types describe the subset of the OFX 2.2 banking statement format used to export transaction history
+// each wallet account is exported as a separate bank statement
+
+type ofxDocument struct {
+	XMLName xml.Name     `xml:"OFX"`
+	SignOn  ofxSignOn    `xml:"SIGNONMSGSRSV1>SONRS"`
+	Bank    []ofxStmtTrn `xml:"BANKMSGSRSV1>STMTTRNRS"`
+}
+
+type ofxStatus struct {
+	Code     int    `xml:"CODE"`
+	Severity string `xml:"SEVERITY"`
+}
+
+type ofxSignOn struct {
+	Status   ofxStatus `xml:"STATUS"`
+	DTServer string    `xml:"DTSERVER"`
+	Language string    `xml:"LANGUAGE"`
+}
+
+type ofxStmtTrn struct {
+	TrnUID    string       `xml:"TRNUID"`
+	Status    ofxStatus    `xml:"STATUS"`
+	Statement ofxStatement `xml:"STMTRS"`
+}
+
+type ofxStatement struct {
+	Currency        string             `xml:"CURDEF"`
+	Account         ofxAccount         `xml:"BANKACCTFROM"`
+	TransactionList ofxTransactionList `xml:"BANKTRANLIST"`
+	LedgerBalance   ofxBalance         `xml:"LEDGERBAL"`
+}
+
+type ofxAccount struct {
+	BankID      string `xml:"BANKID"`
+	AccountID   string `xml:"ACCTID"`
+	AccountType string `xml:"ACCTTYPE"`
+}
+
+type ofxTransactionList struct {
+	DTStart      string           `xml:"DTSTART"`
+	DTEnd        string           `xml:"DTEND"`
+	Transactions []ofxTransaction `xml:"STMTTRN"`
+}
+
+type ofxTransaction struct {
+	Type     string `xml:"TRNTYPE"`
+	DTPosted string `xml:"DTPOSTED"`
+	Amount   string `xml:"TRNAMT"`
+	FITID    string `xml:"FITID"`
+	Name     string `xml:"NAME"`
+	Memo     string `xml:"MEMO"`
+}
+
+type ofxBalance struct {
+	Amount string `xml:"BALAMT"`
+	DTAsOf string `xml:"DTASOF"`
+}
+
+// writeOFX writes `records` as an OFX document containing a bank statement for each wallet account in `records`
+func writeOFX(records []*record, w io.Writer) error {
+	now := time.Now().Format(ofxDateFormat)
+	document := ofxDocument{
+		SignOn: ofxSignOn{
+			Status:   ofxStatus{Code: 0, Severity: "INFO"},
+			DTServer: now,
+			Language: "ENG",
+		},
+	}
+
+	statements := make(map[uint32]*ofxStatement)
+	var accountOrder []uint32
+	latestTimestamps := make(map[uint32]int64)
+
+	for _, txRecord := range records {
+		tx := txRecord.tx
+		for _, entry := range txRecord.entries {
+			statement, ok := statements[entry.accountNumber]
+			if !ok {
+				statement = &ofxStatement{
+					Currency: ofxCurrency,
+					Account: ofxAccount{
+						BankID:      "godcr",
+						AccountID:   fmt.Sprintf("%d-%s", entry.accountNumber, entry.accountName),
+						AccountType: "CHECKING",
+					},
+				}
+				statements[entry.accountNumber] = statement
+				accountOrder = append(accountOrder, entry.accountNumber)
+			}
+
+			trnType := "CREDIT"
+			if entry.change < 0 {
+				trnType = "DEBIT"
+			}
+			postedDate := time.Unix(tx.Timestamp, 0).Format(ofxDateFormat)
+
+			memo := fmt.Sprintf("%s, fee %s. Outputs: %s", tx.Direction.String(), tx.Fee.String(), outputsSummary(tx))
+			if tx.Label != "" {
+				memo = fmt.Sprintf("%s. %s", tx.Label, memo)
+			}
+
+			list := &statement.TransactionList
+			list.Transactions = append(list.Transactions, ofxTransaction{
+				Type:     trnType,
+				DTPosted: postedDate,
+				Amount:   ofxAmount(entry.change),
+				FITID:    fmt.Sprintf("%s-%d", tx.Hash, entry.accountNumber),
+				Name:     tx.Type,
+				Memo:     memo,
+			})
+			if list.DTStart == "" || postedDate < list.DTStart {
+				list.DTStart = postedDate
+			}
+			if postedDate > list.DTEnd {
+				list.DTEnd = postedDate
+			}
+
+			// the ledger balance is the account balance after the latest exported transaction
+			if tx.Timestamp >= latestTimestamps[entry.accountNumber] {
+				latestTimestamps[entry.accountNumber] = tx.Timestamp
+				statement.LedgerBalance = ofxBalance{
+					Amount: ofxAmount(entry.balance),
+					DTAsOf: postedDate,
+				}
+			}
+		}
+	}
+
+	for i, accountNumber := range accountOrder {
+		document.Bank = append(document.Bank, ofxStmtTrn{
+			TrnUID:    strconv.Itoa(i + 1),
+			Status:    ofxStatus{Code: 0, Severity: "INFO"},
+			Statement: *statements[accountNumber],
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header+ofxHeader+"\n"); err != nil {
+		return fmt.Errorf("error writing ofx: %s", err.Error())
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return fmt.Errorf("error writing ofx: %s", err.Error())
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func ofxAmount(amount dcrutil.Amount) string {
+	return strconv.FormatFloat(amount.ToCoin(), 'f', 8, 64)
+}
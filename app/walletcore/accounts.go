@@ -0,0 +1,124 @@
+package walletcore
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// ImportedAccountNumber is the number of the account that holds keys imported into the wallet rather than derived from the seed
+const ImportedAccountNumber = 2147483647
+
+// HiddenAccounts holds the numbers of the accounts that the user chose to hide from account lists such as balance and send pages.
+// Hidden accounts are kept by godcr rather than the wallet, so they are saved to a json file to persist across restarts
+type HiddenAccounts struct {
+	mu       sync.RWMutex
+	path     string
+	accounts map[uint32]bool
+}
+
+// LoadHiddenAccounts reads the hidden accounts saved in the file at `path`.
+// If `path` is empty, hidden accounts are only kept in memory
+func LoadHiddenAccounts(path string) (*HiddenAccounts, error) {
+	hidden := &HiddenAccounts{
+		path:     path,
+		accounts: make(map[uint32]bool),
+	}
+	if path == "" {
+		return hidden, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return hidden, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading hidden accounts file: %s", err.Error())
+	}
+
+	var accountNumbers []uint32
+	if err = json.Unmarshal(data, &accountNumbers); err != nil {
+		return nil, fmt.Errorf("error decoding hidden accounts file: %s", err.Error())
+	}
+	for _, accountNumber := range accountNumbers {
+		hidden.accounts[accountNumber] = true
+	}
+	return hidden, nil
+}
+
+// SetHidden hides or shows the account numbered `accountNumber` and saves the hidden accounts
+func (hidden *HiddenAccounts) SetHidden(accountNumber uint32, hide bool) error {
+	hidden.mu.Lock()
+	defer hidden.mu.Unlock()
+
+	if hide {
+		hidden.accounts[accountNumber] = true
+	} else {
+		delete(hidden.accounts, accountNumber)
+	}
+	return hidden.save()
+}
+
+// IsHidden returns true if `account` should be left out of account lists.
+// Accounts are hidden if the user hid them, the imported account is also hidden while its balance is zero
+func (hidden *HiddenAccounts) IsHidden(account *Account) bool {
+	if account.Number == ImportedAccountNumber && account.Balance != nil && account.Balance.Total == 0 {
+		return true
+	}
+
+	hidden.mu.RLock()
+	defer hidden.mu.RUnlock()
+	return hidden.accounts[account.Number]
+}
+
+// VisibleAccounts returns the accounts in `accounts` that are not hidden
+func (hidden *HiddenAccounts) VisibleAccounts(accounts []*Account) []*Account {
+	visible := make([]*Account, 0, len(accounts))
+	for _, account := range accounts {
+		if !hidden.IsHidden(account) {
+			visible = append(visible, account)
+		}
+	}
+	return visible
+}
+
+// must be called with hidden.mu held
+func (hidden *HiddenAccounts) save() error {
+	if hidden.path == "" {
+		return nil
+	}
+
+	accountNumbers := make([]uint32, 0, len(hidden.accounts))
+	for accountNumber := range hidden.accounts {
+		accountNumbers = append(accountNumbers, accountNumber)
+	}
+	sort.Slice(accountNumbers, func(i, j int) bool {
+		return accountNumbers[i] < accountNumbers[j]
+	})
+
+	data, err := json.MarshalIndent(accountNumbers, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding hidden accounts: %s", err.Error())
+	}
+
+	if err = os.MkdirAll(filepath.Dir(hidden.path), os.ModePerm); err != nil {
+		return fmt.Errorf("error creating hidden accounts directory: %s", err.Error())
+	}
+	if err = ioutil.WriteFile(hidden.path, data, 0600); err != nil {
+		return fmt.Errorf("error saving hidden accounts: %s", err.Error())
+	}
+	return nil
+}
+
+// AccountDerivationPath returns the BIP-44 path of the extended key of account `accountNumber` in a wallet using `coinType`.
+// The imported account is not derived from the wallet seed, so an empty string is returned for it
+func AccountDerivationPath(coinType uint32, accountNumber uint32) string {
+	if accountNumber == ImportedAccountNumber {
+		return ""
+	}
+	return fmt.Sprintf("m/44'/%d'/%d'", coinType, accountNumber)
+}
@@ -0,0 +1,251 @@
+package walletcore
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/decred/dcrd/dcrutil"
+)
+
+// coin selection strategies used to automatically select the inputs of a transaction
+const (
+	LargestFirstSelection  = "largest-first"
+	SmallestFirstSelection = "smallest-first"
+	ExactMatchSelection    = "exact-match"
+	PrivacySelection       = "privacy"
+	OldestFirstSelection   = "oldest-first"
+)
+
+// DefaultCoinSelection is the coin selection strategy used when none is specified
+const DefaultCoinSelection = LargestFirstSelection
+
+// CoinSelectionStrategies lists the names of the supported coin selection strategies
+var CoinSelectionStrategies = []string{
+	LargestFirstSelection,
+	SmallestFirstSelection,
+	ExactMatchSelection,
+	PrivacySelection,
+	OldestFirstSelection,
+}
+
+// exactMatchMaxTries limits the number of subsets the exact match strategy checks before giving up
+const exactMatchMaxTries = 100000
+
+// SelectionTarget is the amount a selection of inputs must cover: the amount being sent and the fee for the transaction.
+// The fee depends on the number of inputs selected, so it is computed for each candidate selection
+type SelectionTarget struct {
+	SendAmount dcrutil.Amount
+	FeeRate    dcrutil.Amount
+	NumOutputs int
+}
+
+// Required returns the total input amount needed to pay the send amount and the fee of a transaction with `numInputs` inputs
+func (target SelectionTarget) Required(numInputs int) dcrutil.Amount {
+	return target.SendAmount + FeeForSerializeSize(target.FeeRate, EstimateSerializeSize(numInputs, target.NumOutputs))
+}
+
+// changeCost is the fee for adding a change output to a transaction and for spending that output later.
+// Selections that exceed the required amount by less than this are better off paying the excess as fee
+func (target SelectionTarget) changeCost() dcrutil.Amount {
+	return FeeForSerializeSize(target.FeeRate, p2pkhOutputSize) + FeeForSerializeSize(target.FeeRate, p2pkhInputSize)
+}
+
+// ValidateCoinSelectionStrategy returns an error if `strategy` is not one of CoinSelectionStrategies.
+// An empty strategy is valid and selects DefaultCoinSelection
+func ValidateCoinSelectionStrategy(strategy string) error {
+	if strategy == "" {
+		return nil
+	}
+	for _, name := range CoinSelectionStrategies {
+		if strategy == name {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown coin selection strategy %s, use one of %s", strategy, strings.Join(CoinSelectionStrategies, ", "))
+}
+
+// SelectCoins selects inputs from `utxos` that cover `target` using the named strategy. `utxos` is not modified.
+// Selections are deterministic: outputs that compare equal under a strategy are ordered by their output keys
+func SelectCoins(strategy string, utxos []*UnspentOutput, target SelectionTarget) ([]*UnspentOutput, error) {
+	if err := ValidateCoinSelectionStrategy(strategy); err != nil {
+		return nil, err
+	}
+
+	var selection []*UnspentOutput
+	switch strategy {
+	case SmallestFirstSelection:
+		selection = accumulate(sortedUtxos(utxos, func(a, b *UnspentOutput) bool {
+			return a.Amount < b.Amount
+		}), target)
+	case OldestFirstSelection:
+		selection = accumulate(sortedUtxos(utxos, func(a, b *UnspentOutput) bool {
+			return a.ReceiveTime < b.ReceiveTime
+		}), target)
+	case ExactMatchSelection:
+		selection = selectExactMatch(utxos, target)
+	case PrivacySelection:
+		selection = selectByAddress(utxos, target)
+	default:
+		selection = selectLargestFirst(utxos, target)
+	}
+
+	if selection == nil {
+		var total dcrutil.Amount
+		for _, utxo := range utxos {
+			total += utxo.Amount
+		}
+		return nil, fmt.Errorf("insufficient funds: %s is required to send %s at a fee rate of %s/kB, account has %s in %d unspent outputs",
+			target.Required(len(utxos)), target.SendAmount, target.FeeRate, total, len(utxos))
+	}
+	return selection, nil
+}
+
+func selectLargestFirst(utxos []*UnspentOutput, target SelectionTarget) []*UnspentOutput {
+	return accumulate(sortedUtxos(utxos, func(a, b *UnspentOutput) bool {
+		return a.Amount > b.Amount
+	}), target)
+}
+
+// sortedUtxos returns a copy of `utxos` sorted by `less`, with ties ordered by output key
+func sortedUtxos(utxos []*UnspentOutput, less func(a, b *UnspentOutput) bool) []*UnspentOutput {
+	sorted := make([]*UnspentOutput, len(utxos))
+	copy(sorted, utxos)
+	sort.Slice(sorted, func(i, j int) bool {
+		if less(sorted[i], sorted[j]) {
+			return true
+		}
+		if less(sorted[j], sorted[i]) {
+			return false
+		}
+		return sorted[i].OutputKey < sorted[j].OutputKey
+	})
+	return sorted
+}
+
+// accumulate selects outputs in the order they appear in `utxos` until they cover `target`
+// nil is returned if all outputs together do not cover the target
+func accumulate(utxos []*UnspentOutput, target SelectionTarget) []*UnspentOutput {
+	var selection []*UnspentOutput
+	var total dcrutil.Amount
+	for _, utxo := range utxos {
+		selection = append(selection, utxo)
+		total += utxo.Amount
+		if total >= target.Required(len(selection)) {
+			return selection
+		}
+	}
+	return nil
+}
+
+// selectExactMatch uses a branch and bound search for a set of outputs that covers `target` without leaving change,
+// i.e. the amount left after paying the target is less than the cost of creating and spending a change output.
+// If no such set is found within exactMatchMaxTries, outputs are selected largest first
+func selectExactMatch(utxos []*UnspentOutput, target SelectionTarget) []*UnspentOutput {
+	sorted := sortedUtxos(utxos, func(a, b *UnspentOutput) bool {
+		return a.Amount > b.Amount
+	})
+
+	// remaining[i] is the total of the outputs from index i, used to prune branches that cannot reach the target
+	remaining := make([]dcrutil.Amount, len(sorted)+1)
+	for i := len(sorted) - 1; i >= 0; i-- {
+		remaining[i] = remaining[i+1] + sorted[i].Amount
+	}
+
+	changeCost := target.changeCost()
+	var selected []int
+	var best []int
+	tries := 0
+
+	var search func(index int, total dcrutil.Amount) bool
+	search = func(index int, total dcrutil.Amount) bool {
+		tries++
+		if tries > exactMatchMaxTries {
+			return false
+		}
+
+		if len(selected) > 0 {
+			required := target.Required(len(selected))
+			if total >= required {
+				if total-required <= changeCost {
+					best = append([]int(nil), selected...)
+					return true
+				}
+				// adding more outputs only increases the excess
+				return false
+			}
+		}
+
+		if index == len(sorted) || total+remaining[index] < target.Required(len(selected)+1) {
+			return false
+		}
+
+		// include the output at index, then try without it
+		selected = append(selected, index)
+		if search(index+1, total+sorted[index].Amount) {
+			return true
+		}
+		selected = selected[:len(selected)-1]
+		return search(index+1, total)
+	}
+
+	if !search(0, 0) {
+		return selectLargestFirst(utxos, target)
+	}
+
+	selection := make([]*UnspentOutput, len(best))
+	for i, index := range best {
+		selection[i] = sorted[index]
+	}
+	return selection
+}
+
+// selectByAddress avoids linking addresses in the same transaction. All outputs of the address whose outputs
+// cover `target` with the smallest total are spent together. If no single address can cover the target,
+// addresses are added largest total first so that as few addresses as possible are linked
+func selectByAddress(utxos []*UnspentOutput, target SelectionTarget) []*UnspentOutput {
+	type addressGroup struct {
+		address string
+		utxos   []*UnspentOutput
+		total   dcrutil.Amount
+	}
+
+	groupsByAddress := make(map[string]*addressGroup)
+	var groups []*addressGroup
+
+	// group the outputs by address, in output key order
+	for _, utxo := range sortedUtxos(utxos, func(a, b *UnspentOutput) bool { return false }) {
+		group, ok := groupsByAddress[utxo.Address]
+		if !ok {
+			group = &addressGroup{address: utxo.Address}
+			groupsByAddress[utxo.Address] = group
+			groups = append(groups, group)
+		}
+		group.utxos = append(group.utxos, utxo)
+		group.total += utxo.Amount
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].total != groups[j].total {
+			return groups[i].total < groups[j].total
+		}
+		return groups[i].address < groups[j].address
+	})
+
+	for _, group := range groups {
+		if group.total >= target.Required(len(group.utxos)) {
+			return group.utxos
+		}
+	}
+
+	var selection []*UnspentOutput
+	var total dcrutil.Amount
+	for i := len(groups) - 1; i >= 0; i-- {
+		selection = append(selection, groups[i].utxos...)
+		total += groups[i].total
+		if total >= target.Required(len(selection)) {
+			return selection
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package walletcore
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/decred/dcrd/dcrutil"
+)
+
+func testUtxo(key string, amount dcrutil.Amount, address string, receiveTime int64) *UnspentOutput {
+	return &UnspentOutput{
+		OutputKey:   key,
+		Amount:      amount,
+		Address:     address,
+		ReceiveTime: receiveTime,
+	}
+}
+
+// equalAmountUtxos returns `count` outputs of `amount` with keys prefixed by `prefix`, ordered by key
+func equalAmountUtxos(prefix string, count int, amount dcrutil.Amount) []*UnspentOutput {
+	utxos := make([]*UnspentOutput, count)
+	for i := range utxos {
+		utxos[i] = testUtxo(fmt.Sprintf("%s%04d", prefix, i), amount, fmt.Sprintf("address%d", i), int64(i))
+	}
+	return utxos
+}
+
+func outputKeys(utxos []*UnspentOutput) []string {
+	keys := make([]string, len(utxos))
+	for i, utxo := range utxos {
+		keys[i] = utxo.OutputKey
+	}
+	return keys
+}
+
+func TestSelectCoins(t *testing.T) {
+	// a zero fee rate makes the required amount equal to the send amount, so the expected selections are easy to work out
+	utxos := []*UnspentOutput{
+		testUtxo("a", 1, "address1", 3),
+		testUtxo("b", 3, "address2", 1),
+		testUtxo("c", 5, "address1", 2),
+		testUtxo("d", 2, "address3", 4),
+	}
+
+	// the only exact match for 6 is the two 3s, which are searched last. With 500 larger outputs before them,
+	// the search runs out of tries before reaching them and the two largest outputs are selected instead
+	tooManyToSearch := append(equalAmountUtxos("big", 500, 4), testUtxo("small1", 3, "small", 0), testUtxo("small2", 3, "small", 0))
+	fewEnoughToSearch := append(equalAmountUtxos("big", 10, 4), testUtxo("small1", 3, "small", 0), testUtxo("small2", 3, "small", 0))
+
+	tests := []struct {
+		name       string
+		strategy   string
+		utxos      []*UnspentOutput
+		sendAmount dcrutil.Amount
+		expected   []string
+	}{
+		{"largest first", LargestFirstSelection, utxos, 6, []string{"c", "b"}},
+		{"default strategy is largest first", "", utxos, 6, []string{"c", "b"}},
+		{"smallest first", SmallestFirstSelection, utxos, 4, []string{"a", "d", "b"}},
+		{"oldest first", OldestFirstSelection, utxos, 4, []string{"b", "c"}},
+		{"exact match", ExactMatchSelection, utxos, 6, []string{"c", "a"}},
+		{"exact match of a single output", ExactMatchSelection, utxos, 3, []string{"b"}},
+		{"exact match falls back to largest first", ExactMatchSelection, []*UnspentOutput{
+			testUtxo("a", 10, "address1", 0),
+			testUtxo("b", 20, "address2", 0),
+		}, 15, []string{"b"}},
+		{"exact match within max tries", ExactMatchSelection, fewEnoughToSearch, 6, []string{"small1", "small2"}},
+		{"exact match runs out of tries", ExactMatchSelection, tooManyToSearch, 6, []string{"big0000", "big0001"}},
+		{"privacy spends the smallest address that covers the amount", PrivacySelection, utxos, 3, []string{"b"}},
+		{"privacy spends all outputs of an address", PrivacySelection, utxos, 4, []string{"a", "c"}},
+		{"privacy adds the largest addresses first", PrivacySelection, utxos, 8, []string{"a", "c", "b"}},
+		{"ties are ordered by output key", LargestFirstSelection, []*UnspentOutput{
+			testUtxo("b", 5, "address1", 0),
+			testUtxo("a", 5, "address2", 0),
+		}, 5, []string{"a"}},
+	}
+
+	for _, test := range tests {
+		selection, err := SelectCoins(test.strategy, test.utxos, SelectionTarget{SendAmount: test.sendAmount, NumOutputs: 1})
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err.Error())
+			continue
+		}
+		if keys := outputKeys(selection); !reflect.DeepEqual(keys, test.expected) {
+			t.Errorf("%s: selected %v, expected %v", test.name, keys, test.expected)
+		}
+	}
+}
+
+func TestSelectCoinsFees(t *testing.T) {
+	utxos := equalAmountUtxos("utxo", 3, 1e8)
+	target := SelectionTarget{SendAmount: 1e8, FeeRate: DefaultFeeRate, NumOutputs: 1}
+
+	// a single output cannot pay the send amount and the fee
+	selection, err := SelectCoins(LargestFirstSelection, utxos, target)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if len(selection) != 2 {
+		t.Errorf("selected %d outputs, expected 2", len(selection))
+	}
+}
+
+func TestSelectCoinsInsufficientFunds(t *testing.T) {
+	utxos := []*UnspentOutput{
+		testUtxo("a", 1, "address1", 0),
+		testUtxo("b", 2, "address2", 0),
+	}
+
+	for _, strategy := range CoinSelectionStrategies {
+		selection, err := SelectCoins(strategy, utxos, SelectionTarget{SendAmount: 4, NumOutputs: 1})
+		if err == nil {
+			t.Errorf("%s: expected an insufficient funds error, selected %v", strategy, outputKeys(selection))
+			continue
+		}
+		if !strings.HasPrefix(err.Error(), "insufficient funds") {
+			t.Errorf("%s: unexpected error: %s", strategy, err.Error())
+		}
+	}
+
+	if _, err := SelectCoins(LargestFirstSelection, nil, SelectionTarget{SendAmount: 1, NumOutputs: 1}); err == nil {
+		t.Error("expected an error selecting from no outputs")
+	}
+}
+
+func TestSelectCoinsUnknownStrategy(t *testing.T) {
+	if _, err := SelectCoins("random", nil, SelectionTarget{SendAmount: 1, NumOutputs: 1}); err == nil {
+		t.Error("expected an error for an unknown strategy")
+	}
+}
+
+func TestSelectCoinsDoesNotModifyUtxos(t *testing.T) {
+	utxos := []*UnspentOutput{
+		testUtxo("a", 1, "address1", 0),
+		testUtxo("b", 3, "address2", 0),
+		testUtxo("c", 2, "address3", 0),
+	}
+
+	for _, strategy := range CoinSelectionStrategies {
+		if _, err := SelectCoins(strategy, utxos, SelectionTarget{SendAmount: 5, NumOutputs: 1}); err != nil {
+			t.Fatalf("%s: unexpected error: %s", strategy, err.Error())
+		}
+		if keys := outputKeys(utxos); !reflect.DeepEqual(keys, []string{"a", "b", "c"}) {
+			t.Fatalf("%s: utxos reordered to %v", strategy, keys)
+		}
+	}
+}
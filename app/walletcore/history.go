@@ -0,0 +1,304 @@
+package walletcore
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/raedahgroup/dcrlibwallet/txhelper"
+)
+
+// transaction types as returned by the different wallet mediums
+const (
+	TransactionTypeRegular        = "REGULAR"
+	TransactionTypeCoinbase       = "COINBASE"
+	TransactionTypeTicketPurchase = "TICKET_PURCHASE"
+	TransactionTypeVote           = "VOTE"
+	TransactionTypeRevocation     = "REVOCATION"
+)
+
+// orders for sorting transaction history
+const (
+	SortNewestFirst   = "newest"
+	SortOldestFirst   = "oldest"
+	SortLargestFirst  = "largest"
+	SortSmallestFirst = "smallest"
+)
+
+// HistoryDateFormat is the format of dates used to filter transaction history by date
+const HistoryDateFormat = "2006-01-02"
+
+// TransactionHistoryQuery holds options for filtering, sorting and paging transaction history
+// Zero-value fields are ignored, so an empty query returns all transactions, newest first
+type TransactionHistoryQuery struct {
+	// Accounts limits the history to transactions that spend from or pay to any of these accounts
+	Accounts   []uint32
+	Directions []txhelper.TransactionDirection
+	Types      []string
+
+	// StartTime and EndTime are unix timestamps, transactions on either boundary are included
+	StartTime int64
+	EndTime   int64
+
+	MinAmount dcrutil.Amount
+	MaxAmount dcrutil.Amount
+
+	SortOrder string
+	Offset    int
+	Limit     int
+}
+
+// TransactionDirectionNames maps user-friendly names to transaction directions, for parsing history filter options
+var TransactionDirectionNames = map[string]txhelper.TransactionDirection{
+	"sent":        txhelper.TransactionDirectionSent,
+	"received":    txhelper.TransactionDirectionReceived,
+	"transferred": txhelper.TransactionDirectionTransferred,
+}
+
+// TransactionTypeNames maps user-friendly names to transaction types, for parsing history filter options
+var TransactionTypeNames = map[string]string{
+	"regular":  TransactionTypeRegular,
+	"coinbase": TransactionTypeCoinbase,
+	"ticket":   TransactionTypeTicketPurchase,
+	"vote":     TransactionTypeVote,
+	"revoke":   TransactionTypeRevocation,
+}
+
+// SortOrders lists the supported transaction history sort orders, the first being the default
+var SortOrders = []string{SortNewestFirst, SortOldestFirst, SortLargestFirst, SortSmallestFirst}
+
+// ParseTransactionDirection returns the transaction direction for a user-friendly direction name such as `sent`
+func ParseTransactionDirection(name string) (txhelper.TransactionDirection, error) {
+	direction, ok := TransactionDirectionNames[strings.ToLower(name)]
+	if !ok {
+		return 0, fmt.Errorf("invalid transaction direction %s, use one of sent, received or transferred", name)
+	}
+	return direction, nil
+}
+
+// ParseTransactionType returns the transaction type for a user-friendly type name such as `ticket`
+func ParseTransactionType(name string) (string, error) {
+	txType, ok := TransactionTypeNames[strings.ToLower(name)]
+	if !ok {
+		return "", fmt.Errorf("invalid transaction type %s, use one of regular, coinbase, ticket, vote or revoke", name)
+	}
+	return txType, nil
+}
+
+// ParseHistoryDate converts a date in HistoryDateFormat to a unix timestamp for the start of that day in local time
+// or the last second of that day if `endOfDay` is true
+func ParseHistoryDate(date string, endOfDay bool) (int64, error) {
+	day, err := time.ParseInLocation(HistoryDateFormat, date, time.Local)
+	if err != nil {
+		return 0, fmt.Errorf("invalid date %s, use the format YYYY-MM-DD", date)
+	}
+	if endOfDay {
+		day = day.AddDate(0, 0, 1).Add(-time.Second)
+	}
+	return day.Unix(), nil
+}
+
+// Validate checks that the query's ranges, paging and sort options are sensible
+func (query *TransactionHistoryQuery) Validate() error {
+	if query.StartTime > 0 && query.EndTime > 0 && query.StartTime > query.EndTime {
+		return fmt.Errorf("start date cannot be after end date")
+	}
+	if query.MinAmount < 0 || query.MaxAmount < 0 {
+		return fmt.Errorf("amount range cannot be negative")
+	}
+	if query.MaxAmount > 0 && query.MinAmount > query.MaxAmount {
+		return fmt.Errorf("minimum amount cannot be more than maximum amount")
+	}
+	if query.Offset < 0 || query.Limit < 0 {
+		return fmt.Errorf("offset and limit cannot be negative")
+	}
+	if query.SortOrder != "" {
+		for _, sortOrder := range SortOrders {
+			if query.SortOrder == sortOrder {
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid sort order %s, use one of %s", query.SortOrder, strings.Join(SortOrders, ", "))
+	}
+	return nil
+}
+
+// AddAccountChange adds `amount` to the change in the balance of `account` caused by the transaction.
+// Accounts are kept in the order in which their first change is added
+func (tx *Transaction) AddAccountChange(account uint32, amount dcrutil.Amount) {
+	for _, change := range tx.AccountChanges {
+		if change.Account == account {
+			change.Amount += amount
+			return
+		}
+	}
+	tx.AccountChanges = append(tx.AccountChanges, &AccountChange{Account: account, Amount: amount})
+}
+
+// ScanLimit returns the number of newest transactions that hold the result of the query, for mediums that can read
+// transaction history newest first and stop early. 0 is returned if any transaction may be part of the result,
+// such as when the query filters by anything other than a start date or sorts by anything other than newest first
+func (query *TransactionHistoryQuery) ScanLimit() int {
+	if query == nil || query.Limit == 0 || query.EndTime > 0 {
+		return 0
+	}
+	if query.SortOrder != "" && query.SortOrder != SortNewestFirst {
+		return 0
+	}
+	if len(query.Accounts) > 0 || len(query.Directions) > 0 || len(query.Types) > 0 || query.MinAmount > 0 || query.MaxAmount > 0 {
+		return 0
+	}
+	return query.Offset + query.Limit
+}
+
+// BlockRange returns the range of block heights, up to `bestHeight`, that hold the transactions in the query's date range.
+// `blockTimestamp` should return the timestamp of the block at a height. 0 is returned for either end of the range
+// if the query's date range does not end before the first block or the best block respectively.
+// Block timestamps do not always increase and transactions may be timestamped before they are mined,
+// so historyBlockMargin blocks are added to both ends of the range.
+func (query *TransactionHistoryQuery) BlockRange(bestHeight int32, blockTimestamp func(height int32) (int64, error)) (startHeight, endHeight int32, err error) {
+	if query == nil {
+		return 0, 0, nil
+	}
+
+	if query.StartTime > 0 {
+		startHeight, err = firstBlockAtOrAfter(query.StartTime, bestHeight, blockTimestamp)
+		if err != nil {
+			return 0, 0, err
+		}
+		startHeight -= historyBlockMargin
+		if startHeight <= 1 {
+			startHeight = 0
+		}
+	}
+
+	if query.EndTime > 0 {
+		endHeight, err = firstBlockAtOrAfter(query.EndTime+1, bestHeight, blockTimestamp)
+		if err != nil {
+			return 0, 0, err
+		}
+		endHeight += historyBlockMargin - 1
+		if endHeight >= bestHeight {
+			endHeight = 0
+		}
+	}
+
+	return startHeight, endHeight, nil
+}
+
+// historyBlockMargin is the number of blocks added to both ends of the block range of a history date range.
+// A block's timestamp only needs to be after the median timestamp of the 11 blocks before it
+const historyBlockMargin = 12
+
+// firstBlockAtOrAfter returns the lowest block height, from 1 to `bestHeight`, whose block timestamp is not before `timestamp`.
+// bestHeight+1 is returned if all blocks are before `timestamp`
+func firstBlockAtOrAfter(timestamp int64, bestHeight int32, blockTimestamp func(height int32) (int64, error)) (int32, error) {
+	var err error
+	index := sort.Search(int(bestHeight), func(i int) bool {
+		if err != nil {
+			return true
+		}
+		var blockTime int64
+		blockTime, err = blockTimestamp(int32(i + 1))
+		return blockTime >= timestamp
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error reading block timestamp: %s", err.Error())
+	}
+	return int32(index + 1), nil
+}
+
+// Apply filters, sorts and pages `transactions` as specified by the query. A nil query only sorts transactions, newest first
+func (query *TransactionHistoryQuery) Apply(transactions []*Transaction) []*Transaction {
+	if query == nil {
+		query = &TransactionHistoryQuery{}
+	}
+
+	filtered := make([]*Transaction, 0, len(transactions))
+	for _, tx := range transactions {
+		if query.includes(tx) {
+			filtered = append(filtered, tx)
+		}
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		switch query.SortOrder {
+		case SortOldestFirst:
+			return filtered[i].Timestamp < filtered[j].Timestamp
+		case SortLargestFirst:
+			return filtered[i].Amount > filtered[j].Amount
+		case SortSmallestFirst:
+			return filtered[i].Amount < filtered[j].Amount
+		default:
+			return filtered[i].Timestamp > filtered[j].Timestamp
+		}
+	})
+
+	if query.Offset >= len(filtered) {
+		return []*Transaction{}
+	}
+	if query.Offset > 0 {
+		filtered = filtered[query.Offset:]
+	}
+	if query.Limit > 0 && query.Limit < len(filtered) {
+		filtered = filtered[:query.Limit]
+	}
+
+	return filtered
+}
+
+func (query *TransactionHistoryQuery) includes(tx *Transaction) bool {
+	if query.StartTime > 0 && tx.Timestamp < query.StartTime {
+		return false
+	}
+	if query.EndTime > 0 && tx.Timestamp > query.EndTime {
+		return false
+	}
+	if query.MinAmount > 0 && tx.Amount < query.MinAmount {
+		return false
+	}
+	if query.MaxAmount > 0 && tx.Amount > query.MaxAmount {
+		return false
+	}
+
+	if len(query.Directions) > 0 {
+		var directionMatched bool
+		for _, direction := range query.Directions {
+			if tx.Direction == direction {
+				directionMatched = true
+				break
+			}
+		}
+		if !directionMatched {
+			return false
+		}
+	}
+
+	if len(query.Types) > 0 {
+		var typeMatched bool
+		for _, txType := range query.Types {
+			if tx.Type == txType {
+				typeMatched = true
+				break
+			}
+		}
+		if !typeMatched {
+			return false
+		}
+	}
+
+	if len(query.Accounts) > 0 {
+		for _, change := range tx.AccountChanges {
+			for _, account := range query.Accounts {
+				if change.Account == account {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,171 @@
+package walletcore
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/raedahgroup/dcrlibwallet/txhelper"
+)
+
+func testTransaction(hash string, amount dcrutil.Amount, direction txhelper.TransactionDirection, txType string,
+	timestamp int64, accounts ...uint32) *Transaction {
+
+	tx := &Transaction{
+		Hash:      hash,
+		Amount:    amount,
+		Direction: direction,
+		Type:      txType,
+		Timestamp: timestamp,
+	}
+	for _, account := range accounts {
+		tx.AddAccountChange(account, amount)
+	}
+	return tx
+}
+
+func transactionHashes(transactions []*Transaction) []string {
+	hashes := make([]string, len(transactions))
+	for i, tx := range transactions {
+		hashes[i] = tx.Hash
+	}
+	return hashes
+}
+
+func TestTransactionHistoryQueryApply(t *testing.T) {
+	transactions := []*Transaction{
+		testTransaction("a", 5, txhelper.TransactionDirectionReceived, TransactionTypeRegular, 100, 0),
+		testTransaction("b", 1, txhelper.TransactionDirectionSent, TransactionTypeRegular, 300, 0, 1),
+		testTransaction("c", 9, txhelper.TransactionDirectionTransferred, TransactionTypeTicketPurchase, 200, 1),
+		testTransaction("d", 3, txhelper.TransactionDirectionReceived, TransactionTypeVote, 400, 2),
+	}
+
+	tests := []struct {
+		name     string
+		query    *TransactionHistoryQuery
+		expected []string
+	}{
+		{"nil query sorts newest first", nil, []string{"d", "b", "c", "a"}},
+		{"empty query sorts newest first", &TransactionHistoryQuery{}, []string{"d", "b", "c", "a"}},
+		{"oldest first", &TransactionHistoryQuery{SortOrder: SortOldestFirst}, []string{"a", "c", "b", "d"}},
+		{"largest first", &TransactionHistoryQuery{SortOrder: SortLargestFirst}, []string{"c", "a", "d", "b"}},
+		{"smallest first", &TransactionHistoryQuery{SortOrder: SortSmallestFirst}, []string{"b", "d", "a", "c"}},
+		{"accounts", &TransactionHistoryQuery{Accounts: []uint32{1}}, []string{"b", "c"}},
+		{"directions", &TransactionHistoryQuery{
+			Directions: []txhelper.TransactionDirection{txhelper.TransactionDirectionReceived},
+		}, []string{"d", "a"}},
+		{"types", &TransactionHistoryQuery{Types: []string{TransactionTypeTicketPurchase, TransactionTypeVote}}, []string{"d", "c"}},
+		{"dates include both boundaries", &TransactionHistoryQuery{StartTime: 200, EndTime: 300}, []string{"b", "c"}},
+		{"amounts include both boundaries", &TransactionHistoryQuery{MinAmount: 3, MaxAmount: 5}, []string{"d", "a"}},
+		{"offset and limit", &TransactionHistoryQuery{Offset: 1, Limit: 2}, []string{"b", "c"}},
+		{"limit past the end", &TransactionHistoryQuery{Offset: 3, Limit: 2}, []string{"a"}},
+		{"offset past the end", &TransactionHistoryQuery{Offset: 4}, []string{}},
+		{"filters are applied before paging", &TransactionHistoryQuery{
+			Accounts:  []uint32{0, 1},
+			SortOrder: SortOldestFirst,
+			Offset:    1,
+			Limit:     1,
+		}, []string{"c"}},
+	}
+
+	for _, test := range tests {
+		if hashes := transactionHashes(test.query.Apply(transactions)); !reflect.DeepEqual(hashes, test.expected) {
+			t.Errorf("%s: got %v, expected %v", test.name, hashes, test.expected)
+		}
+	}
+}
+
+func TestTransactionHistoryQueryValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		query TransactionHistoryQuery
+		valid bool
+	}{
+		{"empty query", TransactionHistoryQuery{}, true},
+		{"date range", TransactionHistoryQuery{StartTime: 100, EndTime: 100}, true},
+		{"start date only", TransactionHistoryQuery{StartTime: 100}, true},
+		{"start date after end date", TransactionHistoryQuery{StartTime: 200, EndTime: 100}, false},
+		{"amount range", TransactionHistoryQuery{MinAmount: 1, MaxAmount: 2}, true},
+		{"minimum amount only", TransactionHistoryQuery{MinAmount: 5}, true},
+		{"negative amount", TransactionHistoryQuery{MinAmount: -1}, false},
+		{"minimum amount above maximum", TransactionHistoryQuery{MinAmount: 3, MaxAmount: 2}, false},
+		{"negative offset", TransactionHistoryQuery{Offset: -1}, false},
+		{"negative limit", TransactionHistoryQuery{Limit: -1}, false},
+		{"known sort order", TransactionHistoryQuery{SortOrder: SortLargestFirst}, true},
+		{"unknown sort order", TransactionHistoryQuery{SortOrder: "random"}, false},
+	}
+
+	for _, test := range tests {
+		err := test.query.Validate()
+		if test.valid && err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err.Error())
+		} else if !test.valid && err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+}
+
+func TestTransactionHistoryQueryScanLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    *TransactionHistoryQuery
+		expected int
+	}{
+		{"nil query", nil, 0},
+		{"no limit", &TransactionHistoryQuery{Offset: 10}, 0},
+		{"newest first", &TransactionHistoryQuery{Offset: 10, Limit: 5}, 15},
+		{"start date", &TransactionHistoryQuery{StartTime: 100, Limit: 5, SortOrder: SortNewestFirst}, 5},
+		{"end date", &TransactionHistoryQuery{EndTime: 100, Limit: 5}, 0},
+		{"oldest first", &TransactionHistoryQuery{Limit: 5, SortOrder: SortOldestFirst}, 0},
+		{"filtered", &TransactionHistoryQuery{Limit: 5, Types: []string{TransactionTypeVote}}, 0},
+	}
+
+	for _, test := range tests {
+		if limit := test.query.ScanLimit(); limit != test.expected {
+			t.Errorf("%s: got %d, expected %d", test.name, limit, test.expected)
+		}
+	}
+}
+
+func TestTransactionHistoryQueryBlockRange(t *testing.T) {
+	// blocks are 10 seconds apart, block 1 at 1000
+	const bestHeight = 100
+	blockTimestamp := func(height int32) (int64, error) {
+		return 1000 + int64(height-1)*10, nil
+	}
+
+	tests := []struct {
+		name                   string
+		query                  *TransactionHistoryQuery
+		startHeight, endHeight int32
+	}{
+		{"no dates", &TransactionHistoryQuery{}, 0, 0},
+		{"start date", &TransactionHistoryQuery{StartTime: 1500}, 51 - historyBlockMargin, 0},
+		{"start date between blocks", &TransactionHistoryQuery{StartTime: 1495}, 51 - historyBlockMargin, 0},
+		{"start date near the first block", &TransactionHistoryQuery{StartTime: 1050}, 0, 0},
+		{"end date", &TransactionHistoryQuery{EndTime: 1500}, 0, 51 + historyBlockMargin},
+		{"end date between blocks", &TransactionHistoryQuery{EndTime: 1505}, 0, 51 + historyBlockMargin},
+		{"end date near the best block", &TransactionHistoryQuery{EndTime: 1950}, 0, 0},
+		{"start date after the best block", &TransactionHistoryQuery{StartTime: 5000}, 101 - historyBlockMargin, 0},
+	}
+
+	for _, test := range tests {
+		startHeight, endHeight, err := test.query.BlockRange(bestHeight, blockTimestamp)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err.Error())
+			continue
+		}
+		if startHeight != test.startHeight || endHeight != test.endHeight {
+			t.Errorf("%s: got blocks %d to %d, expected %d to %d", test.name, startHeight, endHeight, test.startHeight, test.endHeight)
+		}
+	}
+
+	query := &TransactionHistoryQuery{StartTime: 1500}
+	_, _, err := query.BlockRange(bestHeight, func(int32) (int64, error) {
+		return 0, errors.New("block not found")
+	})
+	if err == nil {
+		t.Error("expected an error reading block timestamps")
+	}
+}
@@ -0,0 +1,261 @@
+package walletcore
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrutil"
+)
+
+// label types, named as in BIP-329
+const (
+	LabelTypeTransaction = "tx"
+	LabelTypeAddress     = "addr"
+	LabelTypeOutput      = "output"
+)
+
+// LabelTypes lists the types of wallet records that can be labelled
+var LabelTypes = []string{LabelTypeTransaction, LabelTypeAddress, LabelTypeOutput}
+
+// Label is a note attached by the user to a transaction, address or output.
+// Ref is the transaction hash, the address or the output key (txhash:index) depending on the label type
+type Label struct {
+	Type  string `json:"type"`
+	Ref   string `json:"ref"`
+	Label string `json:"label"`
+}
+
+// Labels holds the labels set by the user for the records of a wallet.
+// Labels are kept by godcr rather than the wallet and are saved in BIP-329 json lines format, so the labels file can also be used as an export
+type Labels struct {
+	mu     sync.RWMutex
+	path   string
+	labels map[string]map[string]string
+}
+
+// LoadLabels reads the labels saved in the file at `path`.
+// If `path` is empty, labels are only kept in memory
+func LoadLabels(path string) (*Labels, error) {
+	labels := &Labels{
+		path:   path,
+		labels: make(map[string]map[string]string),
+	}
+	if path == "" {
+		return labels, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return labels, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading labels file: %s", err.Error())
+	}
+
+	savedLabels, _, err := ReadLabels(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding labels file: %s", err.Error())
+	}
+	for _, label := range savedLabels {
+		labels.set(label)
+	}
+	return labels, nil
+}
+
+// ValidateLabel checks that the type of `label` is one of LabelTypes and that its ref is valid for the type
+func ValidateLabel(label *Label) error {
+	switch label.Type {
+	case LabelTypeTransaction:
+		if _, err := chainhash.NewHashFromStr(label.Ref); err != nil {
+			return fmt.Errorf("invalid transaction hash %s: %s", label.Ref, err.Error())
+		}
+	case LabelTypeAddress:
+		if _, err := dcrutil.DecodeAddress(label.Ref); err != nil {
+			return fmt.Errorf("invalid address %s: %s", label.Ref, err.Error())
+		}
+	case LabelTypeOutput:
+		return ValidateOutputKey(label.Ref)
+	default:
+		return fmt.Errorf("invalid label type %s, use one of %s", label.Type, strings.Join(LabelTypes, ", "))
+	}
+	return nil
+}
+
+// Set saves `label`, replacing any previous label for the same record. An empty label text removes the record's label
+func (labels *Labels) Set(label *Label) error {
+	return labels.Import([]*Label{label})
+}
+
+// Import saves all of `newLabels`, replacing any previous labels for the same records.
+// No label is saved if any of `newLabels` is invalid
+func (labels *Labels) Import(newLabels []*Label) error {
+	for _, label := range newLabels {
+		if err := ValidateLabel(label); err != nil {
+			return err
+		}
+	}
+
+	labels.mu.Lock()
+	defer labels.mu.Unlock()
+
+	for _, label := range newLabels {
+		labels.set(label)
+	}
+	return labels.save()
+}
+
+// Get returns the label text for the record of `labelType` identified by `ref`, or an empty string if the record has no label
+func (labels *Labels) Get(labelType, ref string) string {
+	labels.mu.RLock()
+	defer labels.mu.RUnlock()
+	return labels.labels[labelType][ref]
+}
+
+// All returns all labels, sorted by type and ref
+func (labels *Labels) All() []*Label {
+	labels.mu.RLock()
+	defer labels.mu.RUnlock()
+	return labels.all()
+}
+
+// MarkTransactions sets the Label field of each of `transactions`
+func (labels *Labels) MarkTransactions(transactions []*Transaction) {
+	for _, tx := range transactions {
+		tx.Label = labels.Get(LabelTypeTransaction, tx.Hash)
+	}
+}
+
+// MarkTransactionDetails sets the Label and OutputLabels fields of `tx`.
+// Each output is labelled with its own label or, if it has none, the label of the address it pays
+func (labels *Labels) MarkTransactionDetails(tx *TransactionDetails) {
+	tx.Label = labels.Get(LabelTypeTransaction, tx.Hash)
+	tx.OutputLabels = make(map[int]string)
+	for index, output := range tx.Outputs {
+		if label := labels.Get(LabelTypeOutput, fmt.Sprintf("%s:%d", tx.Hash, index)); label != "" {
+			tx.OutputLabels[index] = label
+			continue
+		}
+		for _, address := range output.Addresses {
+			if label := labels.Get(LabelTypeAddress, address.Address); label != "" {
+				tx.OutputLabels[index] = label
+				break
+			}
+		}
+	}
+}
+
+// MarkUnspentOutputs sets the Label field of each of `utxos` to the output's label or, if it has none, the label of its address
+func (labels *Labels) MarkUnspentOutputs(utxos []*UnspentOutput) {
+	for _, utxo := range utxos {
+		utxo.Label = labels.Get(LabelTypeOutput, utxo.OutputKey)
+		if utxo.Label == "" {
+			utxo.Label = labels.Get(LabelTypeAddress, utxo.Address)
+		}
+	}
+}
+
+// must be called with labels.mu held for writing
+func (labels *Labels) set(label *Label) {
+	if label.Label == "" {
+		delete(labels.labels[label.Type], label.Ref)
+		return
+	}
+	if labels.labels[label.Type] == nil {
+		labels.labels[label.Type] = make(map[string]string)
+	}
+	labels.labels[label.Type][label.Ref] = label.Label
+}
+
+// must be called with labels.mu held
+func (labels *Labels) all() []*Label {
+	var all []*Label
+	for labelType, refs := range labels.labels {
+		for ref, text := range refs {
+			all = append(all, &Label{Type: labelType, Ref: ref, Label: text})
+		}
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Type != all[j].Type {
+			return all[i].Type < all[j].Type
+		}
+		return all[i].Ref < all[j].Ref
+	})
+	return all
+}
+
+// must be called with labels.mu held
+func (labels *Labels) save() error {
+	if labels.path == "" {
+		return nil
+	}
+
+	var data bytes.Buffer
+	if err := WriteLabels(labels.all(), &data); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(labels.path), os.ModePerm); err != nil {
+		return fmt.Errorf("error creating labels directory: %s", err.Error())
+	}
+	if err := ioutil.WriteFile(labels.path, data.Bytes(), 0600); err != nil {
+		return fmt.Errorf("error saving labels: %s", err.Error())
+	}
+	return nil
+}
+
+// WriteLabels writes `labels` to `w` in BIP-329 format, one json object per line
+func WriteLabels(labels []*Label, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	for _, label := range labels {
+		if err := encoder.Encode(label); err != nil {
+			return fmt.Errorf("error writing labels: %s", err.Error())
+		}
+	}
+	return nil
+}
+
+// ReadLabels reads labels in BIP-329 format from `r`.
+// Records of types that godcr does not label, such as BIP-329 input, pubkey and xpub records, are skipped and counted in `skipped`
+func ReadLabels(r io.Reader) (labels []*Label, skipped int, err error) {
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var label Label
+		if err = json.Unmarshal([]byte(line), &label); err != nil {
+			return nil, 0, fmt.Errorf("invalid label on line %d: %s", lineNumber, err.Error())
+		}
+
+		switch label.Type {
+		case LabelTypeTransaction, LabelTypeAddress, LabelTypeOutput:
+		default:
+			skipped++
+			continue
+		}
+
+		if err = ValidateLabel(&label); err != nil {
+			return nil, 0, fmt.Errorf("invalid label on line %d: %s", lineNumber, err.Error())
+		}
+		labels = append(labels, &label)
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error reading labels: %s", err.Error())
+	}
+	return labels, skipped, nil
+}
@@ -0,0 +1,185 @@
+package walletcore
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/decred/dcrd/chaincfg"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrec"
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/raedahgroup/dcrlibwallet/txhelper"
+)
+
+func testAddress(t *testing.T) string {
+	address, err := dcrutil.NewAddressPubKeyHash(make([]byte, 20), &chaincfg.TestNet3Params, dcrec.STEcdsaSecp256k1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	return address.EncodeAddress()
+}
+
+func TestValidateLabel(t *testing.T) {
+	txHash := chainhash.Hash{1}.String()
+	validLabels := []*Label{
+		{Type: LabelTypeTransaction, Ref: txHash, Label: "rent"},
+		{Type: LabelTypeAddress, Ref: testAddress(t), Label: "savings"},
+		{Type: LabelTypeOutput, Ref: txHash + ":0", Label: "cold storage"},
+	}
+	for _, label := range validLabels {
+		if err := ValidateLabel(label); err != nil {
+			t.Errorf("%s label: unexpected error: %s", label.Type, err.Error())
+		}
+	}
+
+	invalidLabels := []*Label{
+		{Type: LabelTypeTransaction, Ref: "xyz"},
+		{Type: LabelTypeAddress, Ref: "notanaddress"},
+		{Type: LabelTypeOutput, Ref: txHash},
+		{Type: "xpub", Ref: txHash},
+	}
+	for _, label := range invalidLabels {
+		if err := ValidateLabel(label); err == nil {
+			t.Errorf("expected an error for %s label with ref %q", label.Type, label.Ref)
+		}
+	}
+}
+
+func TestReadLabels(t *testing.T) {
+	txHash := chainhash.Hash{1}.String()
+	input := strings.Join([]string{
+		`{"type":"tx","ref":"` + txHash + `","label":"rent","origin":"wpkh([d34db33f/84'/0'/0'])"}`,
+		``,
+		`{"type":"input","ref":"` + txHash + `:0","label":"spent"}`,
+		`{"type":"xpub","ref":"xpub661MyMwAqRbc","label":"cold"}`,
+		`  {"type":"output","ref":"` + txHash + `:1","label":"change"}  `,
+	}, "\n")
+
+	// unknown fields are ignored and records of types godcr does not label are skipped
+	labels, skipped, err := ReadLabels(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	expected := []*Label{
+		{Type: LabelTypeTransaction, Ref: txHash, Label: "rent"},
+		{Type: LabelTypeOutput, Ref: txHash + ":1", Label: "change"},
+	}
+	if !reflect.DeepEqual(labels, expected) || skipped != 2 {
+		t.Errorf("got labels %v and %d skipped, expected %v and 2 skipped", labels, skipped, expected)
+	}
+
+	invalidInputs := map[string]string{
+		"not json":    `{"type":"tx"`,
+		"invalid ref": `{"type":"tx","ref":"xyz","label":"rent"}`,
+	}
+	for description, invalidInput := range invalidInputs {
+		if _, _, err := ReadLabels(strings.NewReader(input + "\n" + invalidInput)); err == nil {
+			t.Errorf("%s: expected an error", description)
+		} else if !strings.Contains(err.Error(), "line 6") {
+			t.Errorf("%s: expected the error to name line 6, got %s", description, err.Error())
+		}
+	}
+}
+
+func TestWriteLabels(t *testing.T) {
+	txHash := chainhash.Hash{1}.String()
+	labels := []*Label{
+		{Type: LabelTypeTransaction, Ref: txHash, Label: "rent"},
+		{Type: LabelTypeAddress, Ref: testAddress(t), Label: "savings"},
+	}
+
+	var output bytes.Buffer
+	if err := WriteLabels(labels, &output); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	expectedFirstLine := `{"type":"tx","ref":"` + txHash + `","label":"rent"}`
+	if lines := strings.Split(strings.TrimSpace(output.String()), "\n"); len(lines) != 2 || lines[0] != expectedFirstLine {
+		t.Errorf("got output %q, expected 2 lines starting with %s", output.String(), expectedFirstLine)
+	}
+
+	readLabels, skipped, err := ReadLabels(&output)
+	if err != nil {
+		t.Fatalf("unexpected error reading written labels: %s", err.Error())
+	}
+	if !reflect.DeepEqual(readLabels, labels) || skipped != 0 {
+		t.Errorf("read labels %v, expected %v", readLabels, labels)
+	}
+}
+
+func TestLabelsImport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "godcr-labels-test")
+	if err != nil {
+		t.Fatalf("error creating temporary directory: %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "testnet", "labels.jsonl")
+	labels, err := LoadLabels(path)
+	if err != nil {
+		t.Fatalf("unexpected error loading missing labels file: %s", err.Error())
+	}
+
+	txHash, address := chainhash.Hash{1}.String(), testAddress(t)
+	err = labels.Import([]*Label{
+		{Type: LabelTypeTransaction, Ref: txHash, Label: "rent"},
+		{Type: LabelTypeAddress, Ref: address, Label: "savings"},
+		{Type: LabelTypeOutput, Ref: txHash + ":0", Label: "landlord"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error importing labels: %s", err.Error())
+	}
+
+	// no label is saved if any imported label is invalid
+	err = labels.Import([]*Label{
+		{Type: LabelTypeTransaction, Ref: txHash, Label: "groceries"},
+		{Type: LabelTypeTransaction, Ref: "xyz", Label: "invalid"},
+	})
+	if err == nil {
+		t.Error("expected an error importing an invalid label")
+	}
+
+	// imported labels replace previous labels and empty labels remove them
+	err = labels.Import([]*Label{
+		{Type: LabelTypeTransaction, Ref: txHash, Label: "deposit"},
+		{Type: LabelTypeOutput, Ref: txHash + ":0", Label: ""},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error importing labels: %s", err.Error())
+	}
+
+	reloaded, err := LoadLabels(path)
+	if err != nil {
+		t.Fatalf("unexpected error reloading labels: %s", err.Error())
+	}
+	expected := []*Label{
+		{Type: LabelTypeAddress, Ref: address, Label: "savings"},
+		{Type: LabelTypeTransaction, Ref: txHash, Label: "deposit"},
+	}
+	if all := reloaded.All(); !reflect.DeepEqual(all, expected) {
+		t.Errorf("got reloaded labels %v, expected %v", all, expected)
+	}
+
+	// outputs without their own label are labelled with the label of their address
+	utxos := []*UnspentOutput{testUtxo(txHash+":0", 1e8, address, 0), testUtxo(txHash+":1", 1e8, "other", 0)}
+	reloaded.MarkUnspentOutputs(utxos)
+	if utxos[0].Label != "savings" || utxos[1].Label != "" {
+		t.Errorf("got output labels %q and %q, expected \"savings\" and no label", utxos[0].Label, utxos[1].Label)
+	}
+
+	tx := &TransactionDetails{
+		Outputs: []*txhelper.DecodedOutput{
+			{Addresses: []*txhelper.AddressInfo{{Address: address}}},
+			{Addresses: []*txhelper.AddressInfo{{Address: "other"}}},
+		},
+		Transaction: &Transaction{Hash: txHash},
+	}
+	reloaded.MarkTransactionDetails(tx)
+	if tx.Label != "deposit" || !reflect.DeepEqual(tx.OutputLabels, map[int]string{0: "savings"}) {
+		t.Errorf("got transaction label %q and output labels %v, expected \"deposit\" and a label for output 0", tx.Label, tx.OutputLabels)
+	}
+}
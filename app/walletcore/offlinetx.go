@@ -0,0 +1,250 @@
+package walletcore
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/decred/dcrd/chaincfg"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/decred/dcrd/wire"
+)
+
+// OfflineTransactionVersion is the version of the offline transaction file format written by godcr
+const OfflineTransactionVersion = 1
+
+// offline transaction states
+const (
+	OfflineTransactionUnsigned = "unsigned"
+	OfflineTransactionSigned   = "signed"
+)
+
+// OfflineTransaction is a transaction passed between a godcr instance that creates it (which may be watch-only),
+// an air-gapped godcr instance that signs it and an online godcr instance that publishes it.
+// Inputs and outputs are included so the transaction can be reviewed before it is signed or published,
+// and so that the signing wallet knows the scripts of the outputs being spent without being synced
+type OfflineTransaction struct {
+	Version       int                `json:"version"`
+	State         string             `json:"state"`
+	Network       string             `json:"network"`
+	SourceAccount uint32             `json:"source_account"`
+	Transaction   string             `json:"transaction"`
+	Inputs        []*OfflineTxInput  `json:"inputs"`
+	Outputs       []*OfflineTxOutput `json:"outputs"`
+	Fee           dcrutil.Amount     `json:"fee"`
+	msgTx         *wire.MsgTx
+}
+
+// OfflineTxInput is a previous output spent by an offline transaction
+type OfflineTxInput struct {
+	OutputKey string         `json:"key"`
+	Tree      int8           `json:"tree"`
+	Amount    dcrutil.Amount `json:"amount"`
+	Address   string         `json:"address"`
+	PkScript  string         `json:"pk_script"`
+}
+
+// NewOfflineTxInput describes `utxo`, whose output script is `pkScript`, as an input of an offline transaction
+func NewOfflineTxInput(utxo *UnspentOutput, pkScript []byte) *OfflineTxInput {
+	return &OfflineTxInput{
+		OutputKey: utxo.OutputKey,
+		Tree:      int8(utxo.Tree),
+		Amount:    utxo.Amount,
+		Address:   utxo.Address,
+		PkScript:  hex.EncodeToString(pkScript),
+	}
+}
+
+// TxIn returns a transaction input that spends the previous output described by `input`
+func (input *OfflineTxInput) TxIn() (*wire.TxIn, error) {
+	keyParts := strings.Split(input.OutputKey, ":")
+	if len(keyParts) != 2 {
+		return nil, fmt.Errorf("invalid output key %s, expected txhash:index", input.OutputKey)
+	}
+
+	txHash, err := chainhash.NewHashFromStr(keyParts[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid output key %s: %s", input.OutputKey, err.Error())
+	}
+	outputIndex, err := strconv.ParseUint(keyParts[1], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output key %s: %s", input.OutputKey, err.Error())
+	}
+
+	outpoint := wire.NewOutPoint(txHash, uint32(outputIndex), input.Tree)
+	return wire.NewTxIn(outpoint, int64(input.Amount), nil), nil
+}
+
+// PkScriptBytes returns the decoded output script of the previous output described by `input`
+func (input *OfflineTxInput) PkScriptBytes() ([]byte, error) {
+	pkScript, err := hex.DecodeString(input.PkScript)
+	if err != nil {
+		return nil, fmt.Errorf("invalid script for input %s: %s", input.OutputKey, err.Error())
+	}
+	return pkScript, nil
+}
+
+// OfflineTxOutput is an output created by an offline transaction
+type OfflineTxOutput struct {
+	Address string         `json:"address"`
+	Amount  dcrutil.Amount `json:"amount"`
+}
+
+// NewOfflineTransaction creates an unsigned offline transaction for `tx` which spends `inputs`
+// Output addresses are decoded using the params for `netType` and the fee is computed from the input and output amounts
+func NewOfflineTransaction(netType string, sourceAccount uint32, tx *wire.MsgTx, inputs []*OfflineTxInput) (*OfflineTransaction, error) {
+	if len(inputs) != len(tx.TxIn) {
+		return nil, fmt.Errorf("transaction has %d inputs, details of %d inputs provided", len(tx.TxIn), len(inputs))
+	}
+
+	offlineTx := &OfflineTransaction{
+		Version:       OfflineTransactionVersion,
+		State:         OfflineTransactionUnsigned,
+		Network:       netType,
+		SourceAccount: sourceAccount,
+		Inputs:        inputs,
+	}
+	if err := offlineTx.setTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	netParams := &chaincfg.MainNetParams
+	if netType != "mainnet" {
+		netParams = &chaincfg.TestNet3Params
+	}
+
+	var totalInput, totalOutput dcrutil.Amount
+	for _, input := range inputs {
+		totalInput += input.Amount
+	}
+	for _, txOut := range tx.TxOut {
+		address, err := GetAddressFromPkScript(netParams, txOut.PkScript)
+		if err != nil {
+			return nil, fmt.Errorf("error reading output address: %s", err.Error())
+		}
+		offlineTx.Outputs = append(offlineTx.Outputs, &OfflineTxOutput{
+			Address: address,
+			Amount:  dcrutil.Amount(txOut.Value),
+		})
+		totalOutput += dcrutil.Amount(txOut.Value)
+	}
+	offlineTx.Fee = totalInput - totalOutput
+
+	return offlineTx, nil
+}
+
+// MsgTx returns the decoded transaction
+func (offlineTx *OfflineTransaction) MsgTx() (*wire.MsgTx, error) {
+	if offlineTx.msgTx != nil {
+		return offlineTx.msgTx, nil
+	}
+
+	serializedTx, err := hex.DecodeString(offlineTx.Transaction)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding transaction: %s", err.Error())
+	}
+
+	var msgTx wire.MsgTx
+	if err = msgTx.Deserialize(bytes.NewReader(serializedTx)); err != nil {
+		return nil, fmt.Errorf("error decoding transaction: %s", err.Error())
+	}
+
+	offlineTx.msgTx = &msgTx
+	return offlineTx.msgTx, nil
+}
+
+// SerializedTx returns the serialized transaction
+func (offlineTx *OfflineTransaction) SerializedTx() ([]byte, error) {
+	return hex.DecodeString(offlineTx.Transaction)
+}
+
+// Hash returns the hash of the transaction. The hash of a decred transaction does not change when it is signed
+func (offlineTx *OfflineTransaction) Hash() (string, error) {
+	msgTx, err := offlineTx.MsgTx()
+	if err != nil {
+		return "", err
+	}
+	return msgTx.TxHash().String(), nil
+}
+
+// Signed returns a copy of the offline transaction, with the transaction replaced by the signed transaction `signedTx`
+func (offlineTx *OfflineTransaction) Signed(signedTx *wire.MsgTx) (*OfflineTransaction, error) {
+	signedOfflineTx := *offlineTx
+	signedOfflineTx.State = OfflineTransactionSigned
+	if err := signedOfflineTx.setTransaction(signedTx); err != nil {
+		return nil, err
+	}
+	return &signedOfflineTx, nil
+}
+
+func (offlineTx *OfflineTransaction) setTransaction(tx *wire.MsgTx) error {
+	var txBuf bytes.Buffer
+	txBuf.Grow(tx.SerializeSize())
+	if err := tx.Serialize(&txBuf); err != nil {
+		return fmt.Errorf("error serializing transaction: %s", err.Error())
+	}
+
+	offlineTx.Transaction = hex.EncodeToString(txBuf.Bytes())
+	offlineTx.msgTx = tx
+	return nil
+}
+
+// Validate checks that the offline transaction is in the `expectedState` and was created for the `netType` network
+func (offlineTx *OfflineTransaction) Validate(expectedState, netType string) error {
+	if offlineTx.Version != OfflineTransactionVersion {
+		return fmt.Errorf("unsupported transaction file version: %d", offlineTx.Version)
+	}
+	if offlineTx.State != expectedState {
+		return fmt.Errorf("expected %s transaction, transaction file has a %s transaction", expectedState, offlineTx.State)
+	}
+	if offlineTx.Network != netType {
+		return fmt.Errorf("transaction was created for %s, wallet is on %s", offlineTx.Network, netType)
+	}
+
+	msgTx, err := offlineTx.MsgTx()
+	if err != nil {
+		return err
+	}
+	if len(offlineTx.Inputs) != len(msgTx.TxIn) {
+		return fmt.Errorf("transaction has %d inputs, transaction file describes %d inputs", len(msgTx.TxIn), len(offlineTx.Inputs))
+	}
+	for i, txIn := range msgTx.TxIn {
+		outputKey := fmt.Sprintf("%s:%d", txIn.PreviousOutPoint.Hash.String(), txIn.PreviousOutPoint.Index)
+		if offlineTx.Inputs[i].OutputKey != outputKey {
+			return fmt.Errorf("transaction input %d spends %s, transaction file describes %s", i, outputKey, offlineTx.Inputs[i].OutputKey)
+		}
+	}
+	return nil
+}
+
+// WriteOfflineTransaction saves `offlineTx` to the file at `path` as json
+func WriteOfflineTransaction(path string, offlineTx *OfflineTransaction) error {
+	data, err := json.MarshalIndent(offlineTx, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding transaction file: %s", err.Error())
+	}
+
+	if err = ioutil.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("error writing transaction file: %s", err.Error())
+	}
+	return nil
+}
+
+// ReadOfflineTransaction reads an offline transaction saved to the file at `path` with WriteOfflineTransaction
+func ReadOfflineTransaction(path string) (*OfflineTransaction, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading transaction file: %s", err.Error())
+	}
+
+	var offlineTx OfflineTransaction
+	if err = json.Unmarshal(data, &offlineTx); err != nil {
+		return nil, fmt.Errorf("error decoding transaction file: %s", err.Error())
+	}
+	return &offlineTx, nil
+}
@@ -0,0 +1,170 @@
+package walletcore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/decred/dcrd/chaincfg"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrec"
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/decred/dcrd/txscript"
+	"github.com/decred/dcrd/wire"
+)
+
+// testOfflineTransaction returns an unsigned testnet offline transaction spending 2 inputs of 3 DCR and 1 DCR,
+// paying 2 DCR and 1.9999 DCR to two addresses
+func testOfflineTransaction(t *testing.T) *OfflineTransaction {
+	tx := wire.NewMsgTx()
+	var inputs []*OfflineTxInput
+	for i, amount := range []dcrutil.Amount{3e8, 1e8} {
+		input := NewOfflineTxInput(&UnspentOutput{
+			OutputKey: chainhash.Hash{byte(i + 1)}.String() + ":1",
+			Amount:    amount,
+		}, []byte{txscript.OP_TRUE})
+		txIn, err := input.TxIn()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		tx.AddTxIn(txIn)
+		inputs = append(inputs, input)
+	}
+
+	for i, amount := range []dcrutil.Amount{2e8, 19999e4} {
+		pubKeyHash := make([]byte, 20)
+		pubKeyHash[0] = byte(i)
+		address, err := dcrutil.NewAddressPubKeyHash(pubKeyHash, &chaincfg.TestNet3Params, dcrec.STEcdsaSecp256k1)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		pkScript, err := txscript.PayToAddrScript(address)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		tx.AddTxOut(wire.NewTxOut(int64(amount), pkScript))
+	}
+
+	offlineTx, err := NewOfflineTransaction("testnet", 0, tx, inputs)
+	if err != nil {
+		t.Fatalf("unexpected error creating offline transaction: %s", err.Error())
+	}
+	return offlineTx
+}
+
+func TestNewOfflineTransaction(t *testing.T) {
+	offlineTx := testOfflineTransaction(t)
+
+	if offlineTx.Fee != 1e4 {
+		t.Errorf("got fee %s, expected %s", offlineTx.Fee, dcrutil.Amount(1e4))
+	}
+	if len(offlineTx.Outputs) != 2 || offlineTx.Outputs[0].Amount != 2e8 || offlineTx.Outputs[0].Address == "" {
+		t.Errorf("got outputs %+v, expected outputs of 2 DCR and 1.9999 DCR with addresses", offlineTx.Outputs)
+	}
+	if err := offlineTx.Validate(OfflineTransactionUnsigned, "testnet"); err != nil {
+		t.Errorf("unexpected validation error: %s", err.Error())
+	}
+
+	msgTx, err := offlineTx.MsgTx()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if _, err = NewOfflineTransaction("testnet", 0, msgTx, offlineTx.Inputs[:1]); err == nil {
+		t.Error("expected an error creating an offline transaction without the details of every input")
+	}
+}
+
+func TestOfflineTxInputTxIn(t *testing.T) {
+	for _, outputKey := range []string{"", "abc", "xyz:1", chainhash.Hash{}.String() + ":x", chainhash.Hash{}.String() + ":1:2"} {
+		input := &OfflineTxInput{OutputKey: outputKey}
+		if _, err := input.TxIn(); err == nil {
+			t.Errorf("expected an error for output key %q", outputKey)
+		}
+	}
+
+	input := &OfflineTxInput{OutputKey: chainhash.Hash{1}.String() + ":7", Tree: wire.TxTreeStake, Amount: 5}
+	txIn, err := input.TxIn()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if txIn.PreviousOutPoint.Index != 7 || txIn.PreviousOutPoint.Tree != wire.TxTreeStake || txIn.ValueIn != 5 {
+		t.Errorf("got input %+v, expected output 7 in the stake tree with value 5", txIn.PreviousOutPoint)
+	}
+}
+
+func TestOfflineTransactionValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(offlineTx *OfflineTransaction)
+	}{
+		{"unsupported version", func(offlineTx *OfflineTransaction) { offlineTx.Version = OfflineTransactionVersion + 1 }},
+		{"wrong state", func(offlineTx *OfflineTransaction) { offlineTx.State = OfflineTransactionSigned }},
+		{"wrong network", func(offlineTx *OfflineTransaction) { offlineTx.Network = "mainnet" }},
+		{"undecodable transaction", func(offlineTx *OfflineTransaction) { offlineTx.Transaction, offlineTx.msgTx = "zz", nil }},
+		{"missing input", func(offlineTx *OfflineTransaction) { offlineTx.Inputs = offlineTx.Inputs[:1] }},
+		{"swapped inputs", func(offlineTx *OfflineTransaction) {
+			offlineTx.Inputs[0], offlineTx.Inputs[1] = offlineTx.Inputs[1], offlineTx.Inputs[0]
+		}},
+	}
+
+	for _, test := range tests {
+		offlineTx := testOfflineTransaction(t)
+		test.modify(offlineTx)
+		if err := offlineTx.Validate(OfflineTransactionUnsigned, "testnet"); err == nil {
+			t.Errorf("%s: expected a validation error", test.name)
+		}
+	}
+}
+
+func TestOfflineTransactionFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "godcr-offlinetx-test")
+	if err != nil {
+		t.Fatalf("error creating temporary directory: %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	unsignedTx := testOfflineTransaction(t)
+	msgTx, err := unsignedTx.MsgTx()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	signedMsgTx := msgTx.Copy()
+	for _, txIn := range signedMsgTx.TxIn {
+		txIn.SignatureScript = []byte{txscript.OP_TRUE}
+	}
+	signedTx, err := unsignedTx.Signed(signedMsgTx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if unsignedTx.State != OfflineTransactionUnsigned {
+		t.Errorf("signing changed the state of the unsigned transaction to %s", unsignedTx.State)
+	}
+
+	path := filepath.Join(dir, "tx.json")
+	if err = WriteOfflineTransaction(path, signedTx); err != nil {
+		t.Fatalf("unexpected error writing transaction file: %s", err.Error())
+	}
+	readTx, err := ReadOfflineTransaction(path)
+	if err != nil {
+		t.Fatalf("unexpected error reading transaction file: %s", err.Error())
+	}
+
+	if err = readTx.Validate(OfflineTransactionSigned, "testnet"); err != nil {
+		t.Errorf("unexpected validation error: %s", err.Error())
+	}
+	if readTx.Transaction != signedTx.Transaction || readTx.Fee != signedTx.Fee || readTx.Inputs[1].PkScript != signedTx.Inputs[1].PkScript {
+		t.Errorf("read %+v, expected %+v", readTx, signedTx)
+	}
+
+	// the hash of a decred transaction does not cover signature scripts
+	unsignedHash, _ := unsignedTx.Hash()
+	if signedHash, err := readTx.Hash(); err != nil || signedHash != unsignedHash {
+		t.Errorf("got signed transaction hash %s, expected %s", signedHash, unsignedHash)
+	}
+
+	if _, err = ReadOfflineTransaction(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("expected an error reading a missing transaction file")
+	}
+}
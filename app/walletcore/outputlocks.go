@@ -0,0 +1,206 @@
+package walletcore
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/raedahgroup/dcrlibwallet/txhelper"
+)
+
+// OutputLocks holds the keys of unspent outputs that are locked to prevent them from being spent.
+// Locks are kept by godcr rather than the wallet, so they are saved to a json file to persist across restarts
+type OutputLocks struct {
+	mu   sync.RWMutex
+	path string
+	keys map[string]bool
+}
+
+// LoadOutputLocks reads the output locks saved in the file at `path`.
+// If `path` is empty, locks are only kept in memory
+func LoadOutputLocks(path string) (*OutputLocks, error) {
+	locks := &OutputLocks{
+		path: path,
+		keys: make(map[string]bool),
+	}
+	if path == "" {
+		return locks, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return locks, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading locked outputs file: %s", err.Error())
+	}
+
+	var keys []string
+	if err = json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("error decoding locked outputs file: %s", err.Error())
+	}
+	for _, key := range keys {
+		locks.keys[key] = true
+	}
+	return locks, nil
+}
+
+// ValidateOutputKey checks that `key` identifies an output as txhash:index
+func ValidateOutputKey(key string) error {
+	keyParts := strings.Split(key, ":")
+	if len(keyParts) != 2 {
+		return fmt.Errorf("invalid output key %s, expected txhash:index", key)
+	}
+	if _, err := chainhash.NewHashFromStr(keyParts[0]); err != nil {
+		return fmt.Errorf("invalid output key %s: %s", key, err.Error())
+	}
+	if _, err := strconv.ParseUint(keyParts[1], 10, 32); err != nil {
+		return fmt.Errorf("invalid output key %s: %s", key, err.Error())
+	}
+	return nil
+}
+
+// Lock locks the outputs identified by `outputKeys` and saves the locks
+func (locks *OutputLocks) Lock(outputKeys []string) error {
+	for _, key := range outputKeys {
+		if err := ValidateOutputKey(key); err != nil {
+			return err
+		}
+	}
+
+	locks.mu.Lock()
+	defer locks.mu.Unlock()
+
+	for _, key := range outputKeys {
+		locks.keys[key] = true
+	}
+	return locks.save()
+}
+
+// Unlock unlocks the outputs identified by `outputKeys` and saves the locks
+func (locks *OutputLocks) Unlock(outputKeys []string) error {
+	locks.mu.Lock()
+	defer locks.mu.Unlock()
+
+	for _, key := range outputKeys {
+		if !locks.keys[key] {
+			return fmt.Errorf("output %s is not locked", key)
+		}
+	}
+	for _, key := range outputKeys {
+		delete(locks.keys, key)
+	}
+	return locks.save()
+}
+
+// Keys returns the keys of all locked outputs, sorted
+func (locks *OutputLocks) Keys() []string {
+	locks.mu.RLock()
+	defer locks.mu.RUnlock()
+
+	keys := make([]string, 0, len(locks.keys))
+	for key := range locks.keys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// HasLocks returns true if any output is locked
+func (locks *OutputLocks) HasLocks() bool {
+	locks.mu.RLock()
+	defer locks.mu.RUnlock()
+	return len(locks.keys) > 0
+}
+
+// IsLocked returns true if the output identified by `outputKey` is locked
+func (locks *OutputLocks) IsLocked(outputKey string) bool {
+	locks.mu.RLock()
+	defer locks.mu.RUnlock()
+	return locks.keys[outputKey]
+}
+
+// CheckUnlocked returns an error if any of the outputs identified by `outputKeys` is locked
+func (locks *OutputLocks) CheckUnlocked(outputKeys []string) error {
+	for _, key := range outputKeys {
+		if locks.IsLocked(key) {
+			return lockedOutputError(key)
+		}
+	}
+	return nil
+}
+
+// MarkLocked sets the Locked field of each of `utxos`
+func (locks *OutputLocks) MarkLocked(utxos []*UnspentOutput) {
+	for _, utxo := range utxos {
+		utxo.Locked = locks.IsLocked(utxo.OutputKey)
+	}
+}
+
+// must be called with locks.mu held
+func (locks *OutputLocks) save() error {
+	if locks.path == "" {
+		return nil
+	}
+
+	keys := make([]string, 0, len(locks.keys))
+	for key := range locks.keys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	data, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding locked outputs: %s", err.Error())
+	}
+
+	if err = os.MkdirAll(filepath.Dir(locks.path), os.ModePerm); err != nil {
+		return fmt.Errorf("error creating locked outputs directory: %s", err.Error())
+	}
+	if err = ioutil.WriteFile(locks.path, data, 0600); err != nil {
+		return fmt.Errorf("error saving locked outputs: %s", err.Error())
+	}
+	return nil
+}
+
+func lockedOutputError(outputKey string) error {
+	return fmt.Errorf("unspent output %s is locked, unlock it before spending it", outputKey)
+}
+
+// unlockedOutputs returns the outputs in `utxos` that are not locked
+func unlockedOutputs(utxos []*UnspentOutput) []*UnspentOutput {
+	unlocked := make([]*UnspentOutput, 0, len(utxos))
+	for _, utxo := range utxos {
+		if !utxo.Locked {
+			unlocked = append(unlocked, utxo)
+		}
+	}
+	return unlocked
+}
+
+// InputsExcludingLocked selects the inputs for a transaction that sends to `destinations` from the unlocked outputs of `sourceAccount`
+// using the `coinSelection` strategy and paying fees at `feeRate`, and creates a change output to a new address in the account for any change.
+// It is used by mediums to create unsigned transactions when no inputs are specified, since the wallet would select inputs itself
+// without knowing about outputs locked by godcr
+func InputsExcludingLocked(wallet Wallet, sourceAccount uint32, requiredConfirmations int32, destinations []txhelper.TransactionDestination,
+	coinSelection string, feeRate dcrutil.Amount) ([]string, []txhelper.TransactionDestination, error) {
+
+	estimate, err := wallet.EstimateTransaction(sourceAccount, requiredConfirmations, destinations, nil, coinSelection, feeRate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changeDestinations, err := estimate.changeDestinations(wallet)
+	if err != nil {
+		return nil, nil, err
+	}
+	return estimate.OutputKeys(), changeDestinations, nil
+}
@@ -0,0 +1,106 @@
+package walletcore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+)
+
+func testOutputKey(b byte, index string) string {
+	return chainhash.Hash{b}.String() + ":" + index
+}
+
+func TestValidateOutputKey(t *testing.T) {
+	if err := ValidateOutputKey(testOutputKey(1, "0")); err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	for _, key := range []string{"", "xyz:0", testOutputKey(1, ""), testOutputKey(1, "-1"), testOutputKey(1, "4294967296"), testOutputKey(1, "0:1")} {
+		if err := ValidateOutputKey(key); err == nil {
+			t.Errorf("expected an error for output key %q", key)
+		}
+	}
+}
+
+func TestOutputLocksPersistence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "godcr-outputlocks-test")
+	if err != nil {
+		t.Fatalf("error creating temporary directory: %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	// the locks file and its directory are created when outputs are first locked
+	path := filepath.Join(dir, "testnet", "lockedoutputs.json")
+	locks, err := LoadOutputLocks(path)
+	if err != nil {
+		t.Fatalf("unexpected error loading missing locks file: %s", err.Error())
+	}
+	if locks.HasLocks() {
+		t.Error("expected no locks before any output is locked")
+	}
+
+	a, b, c := testOutputKey(1, "0"), testOutputKey(2, "1"), testOutputKey(3, "2")
+	if err = locks.Lock([]string{c, a, b}); err != nil {
+		t.Fatalf("unexpected error locking outputs: %s", err.Error())
+	}
+	if err = locks.Unlock([]string{c}); err != nil {
+		t.Fatalf("unexpected error unlocking output: %s", err.Error())
+	}
+
+	reloaded, err := LoadOutputLocks(path)
+	if err != nil {
+		t.Fatalf("unexpected error reloading locks: %s", err.Error())
+	}
+	if keys := reloaded.Keys(); !reflect.DeepEqual(keys, []string{a, b}) {
+		t.Errorf("got reloaded locks %v, expected %v", keys, []string{a, b})
+	}
+
+	// invalid keys and unlocking unlocked outputs change nothing
+	if err = reloaded.Lock([]string{c, "invalid"}); err == nil {
+		t.Error("expected an error locking an invalid output key")
+	}
+	if err = reloaded.Unlock([]string{a, c}); err == nil {
+		t.Error("expected an error unlocking an output that is not locked")
+	}
+	if keys := reloaded.Keys(); !reflect.DeepEqual(keys, []string{a, b}) {
+		t.Errorf("got locks %v after failed changes, expected %v", keys, []string{a, b})
+	}
+
+	if err = ioutil.WriteFile(path, []byte("not json"), 0600); err != nil {
+		t.Fatalf("error writing locks file: %s", err.Error())
+	}
+	if _, err = LoadOutputLocks(path); err == nil {
+		t.Error("expected an error loading an undecodable locks file")
+	}
+}
+
+func TestOutputLocksInMemory(t *testing.T) {
+	locks, err := LoadOutputLocks("")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	a, b := testOutputKey(1, "0"), testOutputKey(2, "0")
+	if err = locks.Lock([]string{a}); err != nil {
+		t.Fatalf("unexpected error locking output: %s", err.Error())
+	}
+
+	utxos := []*UnspentOutput{testUtxo(a, 1e8, "address1", 0), testUtxo(b, 2e8, "address2", 0)}
+	locks.MarkLocked(utxos)
+	if !utxos[0].Locked || utxos[1].Locked {
+		t.Errorf("got locked %v and %v, expected only the first output to be locked", utxos[0].Locked, utxos[1].Locked)
+	}
+	if unlocked := unlockedOutputs(utxos); len(unlocked) != 1 || unlocked[0].OutputKey != b {
+		t.Errorf("got unlocked outputs %v, expected only %s", unlocked, b)
+	}
+
+	if err = locks.CheckUnlocked([]string{b}); err != nil {
+		t.Errorf("unexpected error for an unlocked output: %s", err.Error())
+	}
+	if err = locks.CheckUnlocked([]string{b, a}); err == nil {
+		t.Error("expected an error for a locked output")
+	}
+}
@@ -0,0 +1,152 @@
+package walletcore
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/decred/dcrd/txscript"
+	"github.com/decred/dcrd/wire"
+)
+
+// RevokedTicket is a missed or expired ticket that was revoked, returning the ticket price to the ticket's commitment addresses
+type RevokedTicket struct {
+	TicketHash     string `json:"ticket_hash"`
+	RevocationHash string `json:"revocation_hash"`
+}
+
+// Revokable returns true if the ticket missed its vote or expired and can be revoked
+func (ticket *Ticket) Revokable() bool {
+	return ticket.Status == TicketStatusMissed || ticket.Status == TicketStatusExpired
+}
+
+// CheckTicketRevokable returns an error if the ticket with hash `ticketHash` is not a wallet ticket that missed its vote or expired
+func CheckTicketRevokable(ctx context.Context, wallet Wallet, ticketHash string) error {
+	tickets, err := wallet.Tickets(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, ticket := range tickets {
+		if ticket.Hash != ticketHash {
+			continue
+		}
+		if !ticket.Revokable() {
+			return fmt.Errorf("ticket %s is %s, only missed and expired tickets can be revoked", ticketHash, ticket.Status)
+		}
+		return nil
+	}
+	return fmt.Errorf("ticket %s not found in wallet", ticketHash)
+}
+
+// RevokeMissedAndExpiredTickets revokes each missed or expired ticket of `wallet` using wallet.RevokeTicket.
+// The tickets revoked before an error occurs are returned along with the error
+func RevokeMissedAndExpiredTickets(ctx context.Context, wallet Wallet, passphrase string) ([]*RevokedTicket, error) {
+	tickets, err := wallet.Tickets(ctx, &TicketFilter{Statuses: []string{TicketStatusMissed, TicketStatusExpired}})
+	if err != nil {
+		return nil, err
+	}
+
+	revoked := make([]*RevokedTicket, 0, len(tickets))
+	for _, ticket := range tickets {
+		revocationHash, err := wallet.RevokeTicket(ctx, ticket.Hash, passphrase)
+		if err != nil {
+			return revoked, fmt.Errorf("error revoking ticket %s: %s", ticket.Hash, err.Error())
+		}
+		revoked = append(revoked, &RevokedTicket{TicketHash: ticket.Hash, RevocationHash: revocationHash})
+	}
+	return revoked, nil
+}
+
+// ticket commitment outputs are OP_RETURN OP_DATA_30 followed by the 20-byte hash of the commitment address,
+// the 8-byte amount committed with the highest bit set for P2SH addresses and 2 bytes of fee limits
+const (
+	ticketCommitmentScriptSize = 1 + 1 + 20 + 8 + 2
+	ticketCommitmentP2SHFlag   = uint64(1) << 63
+)
+
+type ticketCommitment struct {
+	hash   []byte
+	p2sh   bool
+	amount int64
+}
+
+// NewRevocationTx creates an unsigned revocation of the serialized ticket `ticketTx`, paying the fee at `feeRate`.
+// The ticket value is returned to the ticket's commitment addresses in proportion to the amounts they committed,
+// and the fee is deducted from the first output
+func NewRevocationTx(ticketTx []byte, feeRate dcrutil.Amount) ([]byte, error) {
+	var ticket wire.MsgTx
+	if err := ticket.Deserialize(bytes.NewReader(ticketTx)); err != nil {
+		return nil, fmt.Errorf("error decoding ticket: %s", err.Error())
+	}
+	if len(ticket.TxOut) < 2 {
+		return nil, fmt.Errorf("ticket has no commitment outputs")
+	}
+
+	// the first ticket output is the stake submission, followed by pairs of commitment and change outputs
+	var commitments []*ticketCommitment
+	var totalCommitted int64
+	for i := 1; i < len(ticket.TxOut); i += 2 {
+		script := ticket.TxOut[i].PkScript
+		if len(script) != ticketCommitmentScriptSize || script[0] != txscript.OP_RETURN || script[1] != txscript.OP_DATA_30 {
+			return nil, fmt.Errorf("ticket output %d is not a commitment", i)
+		}
+
+		amount := binary.LittleEndian.Uint64(script[22:30])
+		commitment := &ticketCommitment{
+			hash:   script[2:22],
+			p2sh:   amount&ticketCommitmentP2SHFlag != 0,
+			amount: int64(amount &^ ticketCommitmentP2SHFlag),
+		}
+		commitments = append(commitments, commitment)
+		totalCommitted += commitment.amount
+	}
+	if totalCommitted <= 0 {
+		return nil, fmt.Errorf("ticket commitments total %s", dcrutil.Amount(totalCommitted))
+	}
+
+	ticketValue := ticket.TxOut[0].Value
+	ticketHash := ticket.TxHash()
+
+	revocation := wire.NewMsgTx()
+	revocation.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&ticketHash, 0, wire.TxTreeStake), ticketValue, nil))
+
+	for _, commitment := range commitments {
+		// big ints prevent overflow when multiplying large amounts
+		returned := new(big.Int).Mul(big.NewInt(commitment.amount), big.NewInt(ticketValue))
+		returned.Div(returned, big.NewInt(totalCommitted))
+
+		var script []byte
+		var err error
+		if commitment.p2sh {
+			script, err = txscript.PayToSSRtxSHDirect(commitment.hash)
+		} else {
+			script, err = txscript.PayToSSRtxPKHDirect(commitment.hash)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error creating revocation output script: %s", err.Error())
+		}
+		revocation.AddTxOut(wire.NewTxOut(returned.Int64(), script))
+	}
+
+	if feeRate <= 0 {
+		feeRate = DefaultFeeRate
+	}
+	// revocation outputs are P2PKH or P2SH scripts prefixed with OP_SSRTX
+	size := EstimateSerializeSize(1, len(commitments)) + len(commitments)
+	fee := FeeForSerializeSize(feeRate, size)
+	if revocation.TxOut[0].Value <= int64(fee) {
+		return nil, fmt.Errorf("ticket value cannot pay the revocation fee of %s", fee)
+	}
+	revocation.TxOut[0].Value -= int64(fee)
+
+	var txBuf bytes.Buffer
+	txBuf.Grow(revocation.SerializeSize())
+	if err := revocation.Serialize(&txBuf); err != nil {
+		return nil, fmt.Errorf("error serializing revocation: %s", err.Error())
+	}
+	return txBuf.Bytes(), nil
+}
@@ -0,0 +1,33 @@
+package walletcore
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/decred/dcrwallet/walletseed"
+)
+
+// SeedWordCount is the number of words in a wallet seed encoded with the PGP word list, including the checksum word
+const SeedWordCount = 33
+
+// NormalizeSeed removes leading, trailing and repeated whitespace from a seed entered by a user
+func NormalizeSeed(seed string) string {
+	return strings.Join(strings.Fields(seed), " ")
+}
+
+// ValidateSeed checks that `seed` is either a 33-word PGP word list seed with a valid checksum or a hex-encoded seed
+// The seed should be normalized with NormalizeSeed before it is validated
+func ValidateSeed(seed string) error {
+	words := strings.Fields(seed)
+	if len(words) == 0 {
+		return fmt.Errorf("seed is required")
+	}
+	if len(words) > 1 && len(words) != SeedWordCount {
+		return fmt.Errorf("invalid seed: expected %d words, got %d", SeedWordCount, len(words))
+	}
+
+	if _, err := walletseed.DecodeUserInput(seed); err != nil {
+		return fmt.Errorf("invalid seed: %s", err.Error())
+	}
+	return nil
+}
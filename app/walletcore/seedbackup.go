@@ -0,0 +1,254 @@
+package walletcore
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+// SeedVerificationWordCount is the number of randomly chosen seed words the user must enter to show they backed up a wallet seed
+const SeedVerificationWordCount = 4
+
+// ErrNoUnconfirmedSeed is returned when the seed of a wallet is requested but no seed is waiting for its backup to be confirmed.
+// Seeds are only kept until their backup is confirmed, and are never kept for restored wallets
+var ErrNoUnconfirmedSeed = errors.New("the wallet seed is not available, seeds are only kept until their backup is confirmed")
+
+// scrypt parameters used to derive the key that encrypts unconfirmed seeds from the private passphrase
+const (
+	seedKeyScryptN = 1 << 15
+	seedKeyScryptR = 8
+	seedKeyScryptP = 1
+)
+
+// SeedBackup holds the seed of a new wallet whose backup the user has not confirmed, so that the seed can be displayed again.
+// The seed is encrypted with the wallet's private passphrase and saved to a json file, which is deleted once the backup is confirmed
+type SeedBackup struct {
+	mu        sync.Mutex
+	path      string
+	encrypted *encryptedSeed
+}
+
+type encryptedSeed struct {
+	Salt  []byte `json:"salt"`
+	Nonce []byte `json:"nonce"`
+	Seed  []byte `json:"seed"`
+}
+
+// LoadSeedBackup reads the unconfirmed seed saved in the file at `path`, if any.
+// If `path` is empty, the unconfirmed seed is only kept in memory
+func LoadSeedBackup(path string) (*SeedBackup, error) {
+	backup := &SeedBackup{path: path}
+	if path == "" {
+		return backup, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return backup, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading seed backup file: %s", err.Error())
+	}
+
+	backup.encrypted = &encryptedSeed{}
+	if err = json.Unmarshal(data, backup.encrypted); err != nil {
+		return nil, fmt.Errorf("error decoding seed backup file: %s", err.Error())
+	}
+	return backup, nil
+}
+
+// Save encrypts `seed` with `passphrase` and keeps it until Confirm is called, replacing any seed already kept
+func (backup *SeedBackup) Save(seed, passphrase string) error {
+	encrypted, err := encryptSeed(seed, passphrase)
+	if err != nil {
+		return err
+	}
+
+	backup.mu.Lock()
+	defer backup.mu.Unlock()
+	backup.encrypted = encrypted
+	return backup.save()
+}
+
+// Pending returns true if a seed is waiting for its backup to be confirmed
+func (backup *SeedBackup) Pending() bool {
+	backup.mu.Lock()
+	defer backup.mu.Unlock()
+	return backup.encrypted != nil
+}
+
+// Seed decrypts the unconfirmed seed with `passphrase`.
+// ErrNoUnconfirmedSeed is returned if no seed is waiting for its backup to be confirmed
+func (backup *SeedBackup) Seed(passphrase string) (string, error) {
+	backup.mu.Lock()
+	defer backup.mu.Unlock()
+
+	if backup.encrypted == nil {
+		return "", ErrNoUnconfirmedSeed
+	}
+	return decryptSeed(backup.encrypted, passphrase)
+}
+
+// ChangePassphrase encrypts the unconfirmed seed with `newPassphrase`, it should be called when the wallet's private passphrase changes
+func (backup *SeedBackup) ChangePassphrase(oldPassphrase, newPassphrase string) error {
+	backup.mu.Lock()
+	defer backup.mu.Unlock()
+
+	if backup.encrypted == nil {
+		return nil
+	}
+
+	seed, err := decryptSeed(backup.encrypted, oldPassphrase)
+	if err != nil {
+		return err
+	}
+	encrypted, err := encryptSeed(seed, newPassphrase)
+	if err != nil {
+		return err
+	}
+
+	backup.encrypted = encrypted
+	return backup.save()
+}
+
+// Confirm deletes the unconfirmed seed, after which the seed cannot be displayed again
+func (backup *SeedBackup) Confirm() error {
+	backup.mu.Lock()
+	defer backup.mu.Unlock()
+
+	backup.encrypted = nil
+	return backup.save()
+}
+
+// must be called with backup.mu held
+func (backup *SeedBackup) save() error {
+	if backup.path == "" {
+		return nil
+	}
+
+	if backup.encrypted == nil {
+		if err := os.Remove(backup.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error deleting seed backup file: %s", err.Error())
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(backup.encrypted, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding seed backup: %s", err.Error())
+	}
+
+	if err = os.MkdirAll(filepath.Dir(backup.path), os.ModePerm); err != nil {
+		return fmt.Errorf("error creating seed backup directory: %s", err.Error())
+	}
+	if err = ioutil.WriteFile(backup.path, data, 0600); err != nil {
+		return fmt.Errorf("error saving seed backup: %s", err.Error())
+	}
+	return nil
+}
+
+func encryptSeed(seed, passphrase string) (*encryptedSeed, error) {
+	encrypted := &encryptedSeed{
+		Salt:  make([]byte, 32),
+		Nonce: make([]byte, 24),
+	}
+	if _, err := io.ReadFull(rand.Reader, encrypted.Salt); err != nil {
+		return nil, fmt.Errorf("error generating seed encryption salt: %s", err.Error())
+	}
+	if _, err := io.ReadFull(rand.Reader, encrypted.Nonce); err != nil {
+		return nil, fmt.Errorf("error generating seed encryption nonce: %s", err.Error())
+	}
+
+	key, err := seedEncryptionKey(passphrase, encrypted.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	var nonce [24]byte
+	copy(nonce[:], encrypted.Nonce)
+	encrypted.Seed = secretbox.Seal(nil, []byte(seed), &nonce, key)
+	return encrypted, nil
+}
+
+func decryptSeed(encrypted *encryptedSeed, passphrase string) (string, error) {
+	key, err := seedEncryptionKey(passphrase, encrypted.Salt)
+	if err != nil {
+		return "", err
+	}
+
+	var nonce [24]byte
+	copy(nonce[:], encrypted.Nonce)
+	seed, ok := secretbox.Open(nil, encrypted.Seed, &nonce, key)
+	if !ok {
+		return "", errors.New("invalid passphrase")
+	}
+	return string(seed), nil
+}
+
+func seedEncryptionKey(passphrase string, salt []byte) (*[32]byte, error) {
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, seedKeyScryptN, seedKeyScryptR, seedKeyScryptP, 32)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving seed encryption key: %s", err.Error())
+	}
+
+	var key [32]byte
+	copy(key[:], derivedKey)
+	return &key, nil
+}
+
+// SeedVerificationPositions randomly chooses SeedVerificationWordCount distinct positions of words in a seed of `seedWordCount` words.
+// The positions are 0-based and sorted
+func SeedVerificationPositions(seedWordCount int) ([]int, error) {
+	if seedWordCount < SeedVerificationWordCount {
+		return nil, fmt.Errorf("seed has only %d words", seedWordCount)
+	}
+
+	chosen := make(map[int]bool, SeedVerificationWordCount)
+	positions := make([]int, 0, SeedVerificationWordCount)
+	for len(positions) < SeedVerificationWordCount {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(seedWordCount)))
+		if err != nil {
+			return nil, fmt.Errorf("error choosing seed words: %s", err.Error())
+		}
+
+		position := int(n.Int64())
+		if !chosen[position] {
+			chosen[position] = true
+			positions = append(positions, position)
+		}
+	}
+
+	sort.Ints(positions)
+	return positions, nil
+}
+
+// VerifySeedWords checks that `words` are the words of `seed` at the 0-based `positions`, ignoring case and surrounding whitespace.
+// The returned error names the first word that does not match, numbering words from 1
+func VerifySeedWords(seed string, positions []int, words []string) error {
+	seedWords := strings.Fields(seed)
+	if len(words) != len(positions) {
+		return fmt.Errorf("expected %d seed words, got %d", len(positions), len(words))
+	}
+
+	for i, position := range positions {
+		if position < 0 || position >= len(seedWords) {
+			return fmt.Errorf("invalid seed word position %d", position+1)
+		}
+		if !strings.EqualFold(strings.TrimSpace(words[i]), seedWords[position]) {
+			return fmt.Errorf("word %d does not match the seed", position+1)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package walletcore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSeed = "reform aftermath printer warranty gremlin paragraph beehive stethoscope regain disruptive regain Bradbury chisel October trouble forever Algol applicant island infancy physique paragraph woodlark hydraulic snapshot backwater ratchet surrender revenge customer retouch intention minnow"
+
+func TestSeedBackupEncryption(t *testing.T) {
+	dir, err := ioutil.TempDir("", "godcr-seedbackup-test")
+	if err != nil {
+		t.Fatalf("error creating temporary directory: %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "testnet", "seedbackup.json")
+	backup, err := LoadSeedBackup(path)
+	if err != nil {
+		t.Fatalf("unexpected error loading missing seed backup file: %s", err.Error())
+	}
+	if backup.Pending() {
+		t.Error("expected no unconfirmed seed before a seed is saved")
+	}
+	if _, err = backup.Seed("passphrase"); err != ErrNoUnconfirmedSeed {
+		t.Errorf("got error %v, expected ErrNoUnconfirmedSeed", err)
+	}
+
+	if err = backup.Save(testSeed, "passphrase"); err != nil {
+		t.Fatalf("unexpected error saving seed: %s", err.Error())
+	}
+
+	// the seed is not saved in plain text
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("error reading seed backup file: %s", err.Error())
+	}
+	if strings.Contains(string(data), "aftermath") {
+		t.Error("seed backup file contains seed words")
+	}
+
+	reloaded, err := LoadSeedBackup(path)
+	if err != nil {
+		t.Fatalf("unexpected error reloading seed backup: %s", err.Error())
+	}
+	if !reloaded.Pending() {
+		t.Fatal("expected the reloaded seed backup to be pending")
+	}
+	if seed, err := reloaded.Seed("passphrase"); err != nil || seed != testSeed {
+		t.Errorf("got seed %q, %v, expected the saved seed", seed, err)
+	}
+	if _, err = reloaded.Seed("wrong passphrase"); err == nil {
+		t.Error("expected an error decrypting the seed with a wrong passphrase")
+	}
+
+	if err = reloaded.ChangePassphrase("wrong passphrase", "new passphrase"); err == nil {
+		t.Error("expected an error changing the passphrase with a wrong passphrase")
+	}
+	if err = reloaded.ChangePassphrase("passphrase", "new passphrase"); err != nil {
+		t.Fatalf("unexpected error changing passphrase: %s", err.Error())
+	}
+	if _, err = reloaded.Seed("passphrase"); err == nil {
+		t.Error("expected an error decrypting the seed with the old passphrase")
+	}
+	if seed, err := reloaded.Seed("new passphrase"); err != nil || seed != testSeed {
+		t.Errorf("got seed %q, %v with the new passphrase, expected the saved seed", seed, err)
+	}
+
+	// the seed backup file is deleted once the backup is confirmed
+	if err = reloaded.Confirm(); err != nil {
+		t.Fatalf("unexpected error confirming seed backup: %s", err.Error())
+	}
+	if _, err = os.Stat(path); !os.IsNotExist(err) {
+		t.Error("seed backup file was not deleted after the backup was confirmed")
+	}
+	if _, err = reloaded.Seed("new passphrase"); err != ErrNoUnconfirmedSeed {
+		t.Errorf("got error %v after confirming the backup, expected ErrNoUnconfirmedSeed", err)
+	}
+}
+
+func TestSeedVerificationPositions(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		positions, err := SeedVerificationPositions(33)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		if len(positions) != SeedVerificationWordCount {
+			t.Fatalf("got %d positions, expected %d", len(positions), SeedVerificationWordCount)
+		}
+		for j, position := range positions {
+			if position < 0 || position >= 33 || (j > 0 && position <= positions[j-1]) {
+				t.Fatalf("got positions %v, expected sorted distinct positions below 33", positions)
+			}
+		}
+	}
+
+	if _, err := SeedVerificationPositions(SeedVerificationWordCount - 1); err == nil {
+		t.Error("expected an error for a seed with too few words")
+	}
+}
+
+func TestVerifySeedWords(t *testing.T) {
+	positions := []int{0, 11, 13, 32}
+
+	// words are matched ignoring case and surrounding whitespace
+	if err := VerifySeedWords(testSeed, positions, []string{"Reform", " bradbury ", "october", "minnow"}); err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+
+	tests := []struct {
+		name      string
+		positions []int
+		words     []string
+		errorText string
+	}{
+		{"wrong word", positions, []string{"reform", "bradbury", "chisel", "minnow"}, "word 14"},
+		{"missing word", positions, []string{"reform", "bradbury", "october"}, "expected 4 seed words"},
+		{"invalid position", []int{33}, []string{"minnow"}, "invalid seed word position 34"},
+	}
+	for _, test := range tests {
+		err := VerifySeedWords(testSeed, test.positions, test.words)
+		if err == nil || !strings.Contains(err.Error(), test.errorText) {
+			t.Errorf("%s: got error %v, expected an error containing %q", test.name, err, test.errorText)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package walletcore
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/decred/dcrd/txscript"
+	"github.com/decred/dcrd/wire"
+	"github.com/raedahgroup/dcrlibwallet"
+	"github.com/raedahgroup/dcrlibwallet/txhelper"
+)
+
+// ticketFeeLimits are the fee limits set in the commitment of tickets bought by godcr, the same limits used by dcrwallet.
+// votes may not pay fees and revocations may pay up to 2^24 atoms in fees
+const ticketFeeLimits = 0x5800
+
+// ticketSerializeSize returns the worst case size of a signed ticket with 1 P2PKH input,
+// the stake submission output, 1 commitment output and 1 stake change output
+func ticketSerializeSize() int {
+	// the stake submission and stake change scripts are P2PKH scripts prefixed with OP_SSTX and OP_SSTXCHANGE
+	return EstimateSerializeSize(1, 3) + 2 + ticketCommitmentScriptSize - p2pkhPkScriptSize
+}
+
+// PurchaseTicketsExcludingLocked buys tickets as requested with inputs selected from the unlocked outputs of the request account.
+// It is used by mediums whose wallet selects ticket inputs itself, since the wallet does not know about outputs locked by godcr.
+// A split transaction paying the exact price and fee of each ticket to new addresses in the account is created from unlocked outputs,
+// then each ticket spends one output of the split transaction. Transactions are signed and published using `signAndPublish`.
+// Stake pool tickets are not supported.
+func PurchaseTicketsExcludingLocked(wallet Wallet, request dcrlibwallet.PurchaseTicketsRequest, ticketPrice dcrutil.Amount,
+	signAndPublish func(serializedTx []byte, passphrase string) (string, error)) ([]string, error) {
+
+	if request.PoolAddress != "" {
+		return nil, errors.New("stake pool tickets cannot be bought from an account with locked outputs, " +
+			"unlock the outputs or use another account")
+	}
+	if request.NumTickets == 0 {
+		return nil, errors.New("number of tickets must be more than 0")
+	}
+
+	ticketFeeRate := dcrutil.Amount(request.TicketFee)
+	if ticketFeeRate <= 0 {
+		ticketFeeRate = DefaultFeeRate
+	}
+	ticketInput := ticketPrice + FeeForSerializeSize(ticketFeeRate, ticketSerializeSize())
+
+	var votingAddress dcrutil.Address
+	if request.TicketAddress != "" {
+		address, err := dcrutil.DecodeAddress(request.TicketAddress)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ticket address: %s", err.Error())
+		}
+		votingAddress = address
+	}
+
+	splitDestinations := make([]txhelper.TransactionDestination, request.NumTickets)
+	for i := range splitDestinations {
+		address, err := wallet.ChangeAddress(request.Account)
+		if err != nil {
+			return nil, fmt.Errorf("error generating address for ticket input: %s", err.Error())
+		}
+		splitDestinations[i] = txhelper.TransactionDestination{Address: address, Amount: ticketInput.ToCoin()}
+	}
+
+	estimate, err := wallet.EstimateTransaction(request.Account, int32(request.RequiredConfirmations), splitDestinations, nil,
+		DefaultCoinSelection, dcrutil.Amount(request.TxFee))
+	if err != nil {
+		return nil, err
+	}
+
+	splitTx, err := CreateEstimatedTransaction(wallet, estimate)
+	if err != nil {
+		return nil, fmt.Errorf("error creating split transaction: %s", err.Error())
+	}
+	splitMsgTx, err := splitTx.MsgTx()
+	if err != nil {
+		return nil, err
+	}
+	splitHash := splitMsgTx.TxHash()
+
+	// create all tickets before publishing the split transaction, so nothing is published if a ticket cannot be created
+	tickets := make([][]byte, 0, request.NumTickets)
+	usedOutputs := make(map[int]bool, request.NumTickets)
+	for _, destination := range splitDestinations {
+		outputIndex := -1
+		for i, output := range splitTx.Outputs {
+			if !usedOutputs[i] && output.Address == destination.Address && output.Amount == ticketInput {
+				outputIndex = i
+				break
+			}
+		}
+		if outputIndex < 0 {
+			return nil, fmt.Errorf("split transaction does not pay %s to %s", ticketInput, destination.Address)
+		}
+		usedOutputs[outputIndex] = true
+
+		commitmentAddress, err := wallet.ChangeAddress(request.Account)
+		if err != nil {
+			return nil, fmt.Errorf("error generating ticket commitment address: %s", err.Error())
+		}
+		decodedCommitmentAddress, err := dcrutil.DecodeAddress(commitmentAddress)
+		if err != nil {
+			return nil, err
+		}
+
+		ticketVotingAddress := votingAddress
+		if ticketVotingAddress == nil {
+			ticketVotingAddress = decodedCommitmentAddress
+		}
+
+		ticket, err := newTicketTx(&splitHash, uint32(outputIndex), ticketInput, ticketPrice, ticketVotingAddress,
+			decodedCommitmentAddress, request.Expiry)
+		if err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, ticket)
+	}
+
+	serializedSplitTx, err := splitTx.SerializedTx()
+	if err != nil {
+		return nil, err
+	}
+	passphrase := string(request.Passphrase)
+	if _, err = signAndPublish(serializedSplitTx, passphrase); err != nil {
+		return nil, fmt.Errorf("error publishing split transaction: %s", err.Error())
+	}
+
+	ticketHashes := make([]string, 0, len(tickets))
+	for _, ticket := range tickets {
+		ticketHash, err := signAndPublish(ticket, passphrase)
+		if err != nil {
+			return ticketHashes, fmt.Errorf("error publishing ticket: %s", err.Error())
+		}
+		ticketHashes = append(ticketHashes, ticketHash)
+	}
+	return ticketHashes, nil
+}
+
+// newTicketTx creates an unsigned ticket that spends `inputAmount` from output `outputIndex` of the transaction `inputHash`
+// and buys a ticket at `ticketPrice` which votes with `votingAddress`. The ticket value and the vote reward are committed to `commitmentAddress`
+func newTicketTx(inputHash *chainhash.Hash, outputIndex uint32, inputAmount, ticketPrice dcrutil.Amount,
+	votingAddress, commitmentAddress dcrutil.Address, expiry uint32) ([]byte, error) {
+
+	submissionScript, err := txscript.PayToSStx(votingAddress)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ticket voting script: %s", err.Error())
+	}
+	commitmentScript, err := txscript.GenerateSStxAddrPush(commitmentAddress, inputAmount, ticketFeeLimits)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ticket commitment script: %s", err.Error())
+	}
+	changeScript, err := txscript.PayToSStxChange(commitmentAddress)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ticket change script: %s", err.Error())
+	}
+
+	ticket := wire.NewMsgTx()
+	ticket.Expiry = expiry
+	ticket.AddTxIn(wire.NewTxIn(wire.NewOutPoint(inputHash, outputIndex, wire.TxTreeRegular), int64(inputAmount), nil))
+	ticket.AddTxOut(wire.NewTxOut(int64(ticketPrice), submissionScript))
+	ticket.AddTxOut(wire.NewTxOut(0, commitmentScript))
+	ticket.AddTxOut(wire.NewTxOut(0, changeScript))
+
+	var txBuf bytes.Buffer
+	txBuf.Grow(ticket.SerializeSize())
+	if err := ticket.Serialize(&txBuf); err != nil {
+		return nil, fmt.Errorf("error serializing ticket: %s", err.Error())
+	}
+	return txBuf.Bytes(), nil
+}
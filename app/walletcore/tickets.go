@@ -0,0 +1,163 @@
+package walletcore
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/decred/dcrd/wire"
+)
+
+// TicketStatuses lists the statuses a wallet ticket can have, for parsing ticket filter options
+var TicketStatuses = []string{
+	TicketStatusUnmined,
+	TicketStatusImmature,
+	TicketStatusLive,
+	TicketStatusVoted,
+	TicketStatusMissed,
+	TicketStatusExpired,
+	TicketStatusRevoked,
+	TicketStatusUnknown,
+}
+
+// Ticket is a ticket purchased by the wallet
+type Ticket struct {
+	Hash    string         `json:"hash"`
+	Account uint32         `json:"account"`
+	Price   dcrutil.Amount `json:"price"`
+	Status  string         `json:"status"`
+
+	// PurchaseHeight is the height of the block the ticket was mined in, or -1 if the ticket is not mined yet
+	PurchaseHeight int32 `json:"purchase_height"`
+
+	// VoteHash and RevocationHash are the hashes of the transaction that spent the ticket, if the ticket was voted or revoked
+	VoteHash       string `json:"vote_hash,omitempty"`
+	RevocationHash string `json:"revocation_hash,omitempty"`
+
+	// Reward is the stake reward earned by the ticket's vote, it is zero for tickets that did not vote
+	Reward dcrutil.Amount `json:"reward"`
+}
+
+// TicketFilter holds options for filtering the tickets returned by Wallet.Tickets
+// Zero-value fields are ignored, so an empty filter returns all tickets, newest first
+type TicketFilter struct {
+	Statuses []string
+	Accounts []uint32
+	Limit    int
+}
+
+// ParseTicketStatus returns the ticket status named `name`, ignoring case
+func ParseTicketStatus(name string) (string, error) {
+	name = strings.ToLower(name)
+	for _, status := range TicketStatuses {
+		if name == status {
+			return status, nil
+		}
+	}
+	return "", fmt.Errorf("invalid ticket status %s, use one of %s", name, strings.Join(TicketStatuses, ", "))
+}
+
+// NewTicket returns the ticket whose purchase transaction is `ticketTx`, working out the ticket price and vote reward
+// from the serialized ticket and spender transactions. `spenderTx` is nil if the ticket was not voted or revoked
+func NewTicket(ticketHash string, account uint32, purchaseHeight int32, status string, ticketTx []byte,
+	spenderHash string, spenderTx []byte) (*Ticket, error) {
+
+	var msgTx wire.MsgTx
+	if err := msgTx.Deserialize(bytes.NewReader(ticketTx)); err != nil {
+		return nil, fmt.Errorf("error decoding ticket %s: %s", ticketHash, err.Error())
+	}
+	if len(msgTx.TxOut) == 0 {
+		return nil, fmt.Errorf("ticket %s has no outputs", ticketHash)
+	}
+
+	// the first output of a ticket purchase holds the ticket price
+	ticket := &Ticket{
+		Hash:           ticketHash,
+		Account:        account,
+		Price:          dcrutil.Amount(msgTx.TxOut[0].Value),
+		Status:         status,
+		PurchaseHeight: purchaseHeight,
+	}
+
+	switch status {
+	case TicketStatusVoted:
+		ticket.VoteHash = spenderHash
+	case TicketStatusRevoked:
+		ticket.RevocationHash = spenderHash
+	}
+
+	if status == TicketStatusVoted && spenderTx != nil {
+		var spender wire.MsgTx
+		if err := spender.Deserialize(bytes.NewReader(spenderTx)); err != nil {
+			return nil, fmt.Errorf("error decoding vote %s: %s", spenderHash, err.Error())
+		}
+
+		// a vote returns the ticket price along with the stake reward
+		var returned int64
+		for _, txOut := range spender.TxOut {
+			returned += txOut.Value
+		}
+		ticket.Reward = dcrutil.Amount(returned) - ticket.Price
+	}
+
+	return ticket, nil
+}
+
+// Apply filters `tickets` as specified by the filter and sorts them newest first, unmined tickets being the newest
+func (filter *TicketFilter) Apply(tickets []*Ticket) []*Ticket {
+	if filter == nil {
+		filter = &TicketFilter{}
+	}
+
+	filtered := make([]*Ticket, 0, len(tickets))
+	for _, ticket := range tickets {
+		if filter.includes(ticket) {
+			filtered = append(filtered, ticket)
+		}
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		iHeight, jHeight := filtered[i].PurchaseHeight, filtered[j].PurchaseHeight
+		if iHeight < 0 || jHeight < 0 {
+			return iHeight < 0 && jHeight >= 0
+		}
+		return iHeight > jHeight
+	})
+
+	if filter.Limit > 0 && filter.Limit < len(filtered) {
+		filtered = filtered[:filter.Limit]
+	}
+	return filtered
+}
+
+func (filter *TicketFilter) includes(ticket *Ticket) bool {
+	if len(filter.Statuses) > 0 {
+		var statusMatched bool
+		for _, status := range filter.Statuses {
+			if ticket.Status == status {
+				statusMatched = true
+				break
+			}
+		}
+		if !statusMatched {
+			return false
+		}
+	}
+
+	if len(filter.Accounts) > 0 {
+		var accountMatched bool
+		for _, account := range filter.Accounts {
+			if ticket.Account == account {
+				accountMatched = true
+				break
+			}
+		}
+		if !accountMatched {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,347 @@
+package walletcore
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/decred/dcrd/wire"
+	"github.com/raedahgroup/dcrlibwallet/txhelper"
+)
+
+// DefaultFeeRate is the fee per kB used for transactions when no fee rate is specified.
+// It is the default relay fee of dcrd and dcrwallet, transactions paying less may not be relayed
+const DefaultFeeRate dcrutil.Amount = 1e4
+
+// worst case sizes of the transaction parts, used to estimate the size of a signed transaction before it is signed.
+// all inputs are assumed to redeem compressed P2PKH outputs and all outputs are assumed to be P2PKH outputs
+const (
+	// 1 OP_DATA_73, 73 signature, 1 OP_DATA_33, 33 compressed public key
+	p2pkhSigScriptSize = 1 + 73 + 1 + 33
+
+	// OP_DUP OP_HASH160 OP_DATA_20 20-byte pubkey hash OP_EQUALVERIFY OP_CHECKSIG
+	p2pkhPkScriptSize = 1 + 1 + 1 + 20 + 1 + 1
+
+	// 32 prev tx hash, 4 prev tx index, 1 prev tx tree, 4 sequence,
+	// 8 amount, 4 block height, 4 block index, 1 varint sig script length, sig script
+	p2pkhInputSize = 32 + 4 + 1 + 4 + 8 + 4 + 4 + 1 + p2pkhSigScriptSize
+
+	// 8 amount, 2 script version, 1 varint script length, pk script
+	p2pkhOutputSize = 8 + 2 + 1 + p2pkhPkScriptSize
+)
+
+// TransactionEstimate describes a transaction before it is signed and published,
+// so the inputs, change, size and fee can be reviewed before the user sends it
+type TransactionEstimate struct {
+	SourceAccount         uint32                            `json:"source_account"`
+	RequiredConfirmations int32                             `json:"required_confirmations"`
+	Inputs                []*UnspentOutput                  `json:"inputs"`
+	Destinations          []txhelper.TransactionDestination `json:"destinations"`
+	TotalInput            dcrutil.Amount                    `json:"total_input"`
+	SendAmount            dcrutil.Amount                    `json:"send_amount"`
+	Change                dcrutil.Amount                    `json:"change"`
+	ChangeOutputs         int                               `json:"change_outputs"`
+	Size                  int                               `json:"size"`
+	Fee                   dcrutil.Amount                    `json:"fee"`
+	// FeeRate is the fee paid per kB of the estimated transaction size
+	FeeRate dcrutil.Amount `json:"fee_rate"`
+}
+
+// EstimateSerializeSize returns the worst case size of a signed transaction with `numInputs` inputs and `numOutputs` outputs
+func EstimateSerializeSize(numInputs, numOutputs int) int {
+	// 4 version, 4 lock time, 4 expiry, input count in the prefix and witness, output count
+	baseSize := 4 + 4 + 4 + 2*wire.VarIntSerializeSize(uint64(numInputs)) + wire.VarIntSerializeSize(uint64(numOutputs))
+	return baseSize + numInputs*p2pkhInputSize + numOutputs*p2pkhOutputSize
+}
+
+// FeeForSerializeSize returns the fee for a transaction of `size` bytes at `feeRate` per kB
+func FeeForSerializeSize(feeRate dcrutil.Amount, size int) dcrutil.Amount {
+	fee := feeRate * dcrutil.Amount(size) / 1000
+	if fee == 0 && feeRate > 0 {
+		fee = feeRate
+	}
+	return fee
+}
+
+// isDustAmount returns true if a P2PKH output of `amount` would cost more than a third of its value to spend at `feeRate`
+// such outputs are not relayed by dcrd, so dust change is added to the fee instead
+func isDustAmount(amount, feeRate dcrutil.Amount) bool {
+	return amount*1000/(3*(p2pkhOutputSize+p2pkhInputSize)) < feeRate
+}
+
+// EstimateChange returns the change left after paying `destinations` and the fee at `feeRate`
+// from `numInputs` inputs totalling `totalInput`, split among `numChangeOutputs` change outputs.
+// A negative change is returned if the inputs cannot pay for the destinations and fee
+func EstimateChange(numInputs int, totalInput dcrutil.Amount, destinations []txhelper.TransactionDestination,
+	numChangeOutputs int, feeRate dcrutil.Amount) (dcrutil.Amount, error) {
+
+	if feeRate <= 0 {
+		feeRate = DefaultFeeRate
+	}
+
+	sendAmount, err := destinationsTotal(destinations)
+	if err != nil {
+		return 0, err
+	}
+
+	size := EstimateSerializeSize(numInputs, len(destinations)+numChangeOutputs)
+	return totalInput - sendAmount - FeeForSerializeSize(feeRate, size), nil
+}
+
+// NewTransactionEstimate estimates the transaction that sends to `destinations` from the `utxos` of `sourceAccount` paying fees at `feeRate`.
+// If `utxoKeys` is empty, inputs are selected from the unlocked `utxos` using the `coinSelection` strategy until the destinations and fee are covered.
+// Otherwise, the unspent outputs matching `utxoKeys` are spent.
+// Any change is sent to a single change output, change too small to be relayed is added to the fee
+func NewTransactionEstimate(utxos []*UnspentOutput, sourceAccount uint32, requiredConfirmations int32,
+	destinations []txhelper.TransactionDestination, utxoKeys []string, coinSelection string, feeRate dcrutil.Amount) (*TransactionEstimate, error) {
+
+	if len(destinations) == 0 {
+		return nil, errors.New("no destination provided for the transaction")
+	}
+	if feeRate <= 0 {
+		feeRate = DefaultFeeRate
+	}
+
+	sendAmount, err := destinationsTotal(destinations)
+	if err != nil {
+		return nil, err
+	}
+
+	var inputs []*UnspentOutput
+	var totalInput dcrutil.Amount
+	if len(utxoKeys) > 0 {
+		for _, key := range utxoKeys {
+			utxo := findUnspentOutput(utxos, key)
+			if utxo == nil {
+				return nil, fmt.Errorf("unspent output %s does not exist or is not spendable", key)
+			}
+			if utxo.Locked {
+				return nil, lockedOutputError(key)
+			}
+			inputs = append(inputs, utxo)
+			totalInput += utxo.Amount
+		}
+	} else {
+		inputs, err = SelectCoins(coinSelection, unlockedOutputs(utxos), SelectionTarget{
+			SendAmount: sendAmount,
+			FeeRate:    feeRate,
+			NumOutputs: len(destinations),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, utxo := range inputs {
+			totalInput += utxo.Amount
+		}
+	}
+
+	sizeWithoutChange := EstimateSerializeSize(len(inputs), len(destinations))
+	if requiredAmount := sendAmount + FeeForSerializeSize(feeRate, sizeWithoutChange); totalInput < requiredAmount {
+		return nil, fmt.Errorf("insufficient funds: %s is required to send %s at a fee rate of %s/kB, inputs total %s",
+			requiredAmount, sendAmount, feeRate, totalInput)
+	}
+
+	estimate := &TransactionEstimate{
+		SourceAccount:         sourceAccount,
+		RequiredConfirmations: requiredConfirmations,
+		Inputs:                inputs,
+		Destinations:          destinations,
+		TotalInput:            totalInput,
+		SendAmount:            sendAmount,
+		Size:                  sizeWithoutChange,
+	}
+
+	sizeWithChange := EstimateSerializeSize(len(inputs), len(destinations)+1)
+	change := totalInput - sendAmount - FeeForSerializeSize(feeRate, sizeWithChange)
+	if change > 0 && !isDustAmount(change, feeRate) {
+		estimate.Change = change
+		estimate.ChangeOutputs = 1
+		estimate.Size = sizeWithChange
+	}
+
+	estimate.setFee()
+	return estimate, nil
+}
+
+// NewSweepEstimate estimates the transaction that sends the entire balance of the `utxos` of `sourceAccount` to `destinationAddress`,
+// paying fees at `feeRate`. Locked outputs are not spent. If `utxoKeys` is not empty, only the unspent outputs matching `utxoKeys` are spent.
+// The fee is deducted from the amount sent, so the transaction has no change output
+func NewSweepEstimate(utxos []*UnspentOutput, sourceAccount uint32, requiredConfirmations int32, destinationAddress string,
+	utxoKeys []string, feeRate dcrutil.Amount) (*TransactionEstimate, error) {
+
+	if feeRate <= 0 {
+		feeRate = DefaultFeeRate
+	}
+
+	inputs := unlockedOutputs(utxos)
+	if len(utxoKeys) > 0 {
+		inputs = make([]*UnspentOutput, 0, len(utxoKeys))
+		for _, key := range utxoKeys {
+			utxo := findUnspentOutput(utxos, key)
+			if utxo == nil {
+				return nil, fmt.Errorf("unspent output %s does not exist or is not spendable", key)
+			}
+			if utxo.Locked {
+				return nil, lockedOutputError(key)
+			}
+			inputs = append(inputs, utxo)
+		}
+	}
+	if len(inputs) == 0 {
+		return nil, errors.New("there are no spendable outputs to send")
+	}
+
+	var totalInput dcrutil.Amount
+	for _, input := range inputs {
+		totalInput += input.Amount
+	}
+
+	size := EstimateSerializeSize(len(inputs), 1)
+	fee := FeeForSerializeSize(feeRate, size)
+	sendAmount := totalInput - fee
+	if sendAmount <= 0 || isDustAmount(sendAmount, feeRate) {
+		return nil, fmt.Errorf("insufficient funds: inputs total %s, which is too small to pay the fee of %s at a fee rate of %s/kB",
+			totalInput, fee, feeRate)
+	}
+
+	estimate := &TransactionEstimate{
+		SourceAccount:         sourceAccount,
+		RequiredConfirmations: requiredConfirmations,
+		Inputs:                inputs,
+		Destinations: []txhelper.TransactionDestination{{
+			Address: destinationAddress,
+			Amount:  sendAmount.ToCoin(),
+		}},
+		TotalInput: totalInput,
+		SendAmount: sendAmount,
+		Size:       size,
+	}
+	estimate.setFee()
+	return estimate, nil
+}
+
+// SummarizeTransaction describes the transaction that spends `inputs` and sends to `destinations` and `changeDestinations`.
+// It is used for transactions whose change outputs were chosen by the user
+func SummarizeTransaction(sourceAccount uint32, requiredConfirmations int32, inputs []*UnspentOutput,
+	destinations, changeDestinations []txhelper.TransactionDestination) (*TransactionEstimate, error) {
+
+	sendAmount, err := destinationsTotal(destinations)
+	if err != nil {
+		return nil, err
+	}
+	change, err := destinationsTotal(changeDestinations)
+	if err != nil {
+		return nil, err
+	}
+
+	var totalInput dcrutil.Amount
+	for _, input := range inputs {
+		totalInput += input.Amount
+	}
+	if totalInput < sendAmount+change {
+		return nil, fmt.Errorf("inputs total %s, which is less than the %s being sent", totalInput, sendAmount+change)
+	}
+
+	estimate := &TransactionEstimate{
+		SourceAccount:         sourceAccount,
+		RequiredConfirmations: requiredConfirmations,
+		Inputs:                inputs,
+		Destinations:          destinations,
+		TotalInput:            totalInput,
+		SendAmount:            sendAmount,
+		Change:                change,
+		ChangeOutputs:         len(changeDestinations),
+		Size:                  EstimateSerializeSize(len(inputs), len(destinations)+len(changeDestinations)),
+	}
+	estimate.setFee()
+	return estimate, nil
+}
+
+func (estimate *TransactionEstimate) setFee() {
+	estimate.Fee = estimate.TotalInput - estimate.SendAmount - estimate.Change
+	estimate.FeeRate = estimate.Fee * 1000 / dcrutil.Amount(estimate.Size)
+}
+
+// OutputKeys returns the keys of the unspent outputs spent by the estimated transaction
+func (estimate *TransactionEstimate) OutputKeys() []string {
+	keys := make([]string, len(estimate.Inputs))
+	for i, input := range estimate.Inputs {
+		keys[i] = input.OutputKey
+	}
+	return keys
+}
+
+// SendEstimatedTransaction signs and publishes the transaction described by an estimate returned by `wallet.EstimateTransaction`.
+// The change, if any, is sent to a new change address in the source account, so the transaction pays exactly the estimated fee
+func SendEstimatedTransaction(wallet Wallet, estimate *TransactionEstimate, passphrase string) (string, error) {
+	changeDestinations, err := estimate.changeDestinations(wallet)
+	if err != nil {
+		return "", err
+	}
+
+	return wallet.SendFromUTXOs(estimate.SourceAccount, estimate.RequiredConfirmations, estimate.OutputKeys(),
+		estimate.Destinations, changeDestinations, passphrase)
+}
+
+// CreateEstimatedTransaction creates the unsigned transaction described by an estimate returned by `wallet.EstimateTransaction`,
+// to be signed and published separately. As with SendEstimatedTransaction, any change is sent to a new change address in the source account
+func CreateEstimatedTransaction(wallet Wallet, estimate *TransactionEstimate) (*OfflineTransaction, error) {
+	changeDestinations, err := estimate.changeDestinations(wallet)
+	if err != nil {
+		return nil, err
+	}
+
+	return wallet.CreateUnsignedTransaction(estimate.SourceAccount, estimate.RequiredConfirmations, estimate.Destinations,
+		estimate.OutputKeys(), changeDestinations)
+}
+
+// changeDestinations sends the estimated change, if any, to a new change address in the source account
+func (estimate *TransactionEstimate) changeDestinations(wallet Wallet) ([]txhelper.TransactionDestination, error) {
+	if estimate.Change <= 0 {
+		return nil, nil
+	}
+
+	changeAddress, err := wallet.ChangeAddress(estimate.SourceAccount)
+	if err != nil {
+		return nil, fmt.Errorf("error generating change address: %s", err.Error())
+	}
+	return []txhelper.TransactionDestination{{
+		Address: changeAddress,
+		Amount:  estimate.Change.ToCoin(),
+	}}, nil
+}
+
+// ParseFeeRate converts a fee rate in DCR/kB to an amount per kB. A fee rate of 0 returns DefaultFeeRate
+func ParseFeeRate(feeRate float64) (dcrutil.Amount, error) {
+	if feeRate == 0 {
+		return DefaultFeeRate, nil
+	}
+	if feeRate < 0 {
+		return 0, errors.New("fee rate cannot be negative")
+	}
+	return dcrutil.NewAmount(feeRate)
+}
+
+func destinationsTotal(destinations []txhelper.TransactionDestination) (dcrutil.Amount, error) {
+	var total dcrutil.Amount
+	for _, destination := range destinations {
+		amount, err := dcrutil.NewAmount(destination.Amount)
+		if err != nil {
+			return 0, fmt.Errorf("invalid amount for %s: %s", destination.Address, err.Error())
+		}
+		if amount <= 0 {
+			return 0, fmt.Errorf("invalid amount for %s: amount must be more than 0", destination.Address)
+		}
+		total += amount
+	}
+	return total, nil
+}
+
+func findUnspentOutput(utxos []*UnspentOutput, key string) *UnspentOutput {
+	for _, utxo := range utxos {
+		if utxo.OutputKey == key {
+			return utxo
+		}
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package walletcore
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/raedahgroup/dcrlibwallet/txhelper"
+)
+
+func TestEstimateSerializeSize(t *testing.T) {
+	tests := []struct {
+		numInputs, numOutputs, expected int
+	}{
+		// 12 bytes of version, lock time and expiry, 3 bytes of input and output counts, 166 bytes per input, 36 bytes per output
+		{1, 1, 217},
+		{1, 2, 253},
+		{2, 2, 419},
+		// counts of 253 or more take 3 bytes
+		{253, 1, 12 + 2*3 + 1 + 253*166 + 36},
+	}
+
+	for _, test := range tests {
+		if size := EstimateSerializeSize(test.numInputs, test.numOutputs); size != test.expected {
+			t.Errorf("%d inputs, %d outputs: got size %d, expected %d", test.numInputs, test.numOutputs, size, test.expected)
+		}
+	}
+}
+
+func TestFeeForSerializeSize(t *testing.T) {
+	tests := []struct {
+		feeRate  dcrutil.Amount
+		size     int
+		expected dcrutil.Amount
+	}{
+		{DefaultFeeRate, 253, 2530},
+		{DefaultFeeRate, 1000, DefaultFeeRate},
+		{2e4, 419, 8380},
+		// fees that round down to 0 are raised to the fee rate
+		{1, 253, 1},
+		{0, 253, 0},
+	}
+
+	for _, test := range tests {
+		if fee := FeeForSerializeSize(test.feeRate, test.size); fee != test.expected {
+			t.Errorf("%s/kB for %d bytes: got fee %s, expected %s", test.feeRate, test.size, fee, test.expected)
+		}
+	}
+}
+
+func TestIsDustAmount(t *testing.T) {
+	// spending a P2PKH output adds 202 bytes, outputs are dust if spending them costs more than a third of their value
+	if !isDustAmount(6059, DefaultFeeRate) {
+		t.Error("6059 atoms should be dust at the default fee rate")
+	}
+	if isDustAmount(6060, DefaultFeeRate) {
+		t.Error("6060 atoms should not be dust at the default fee rate")
+	}
+}
+
+func TestParseFeeRate(t *testing.T) {
+	if feeRate, err := ParseFeeRate(0); err != nil || feeRate != DefaultFeeRate {
+		t.Errorf("got %s, %v for a fee rate of 0, expected the default fee rate", feeRate, err)
+	}
+	if feeRate, err := ParseFeeRate(0.0002); err != nil || feeRate != 2e4 {
+		t.Errorf("got %s, %v for a fee rate of 0.0002 DCR/kB, expected 20000 atoms/kB", feeRate, err)
+	}
+	if _, err := ParseFeeRate(-0.0001); err == nil {
+		t.Error("expected an error for a negative fee rate")
+	}
+}
+
+func sendTo(amount float64) []txhelper.TransactionDestination {
+	return []txhelper.TransactionDestination{{Address: "destination", Amount: amount}}
+}
+
+func TestEstimateChange(t *testing.T) {
+	change, err := EstimateChange(1, 3e8, sendTo(2), 1, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if change != 3e8-2e8-2530 {
+		t.Errorf("got change %s, expected %s", change, dcrutil.Amount(3e8-2e8-2530))
+	}
+
+	if change, _ = EstimateChange(1, 1e8, sendTo(1), 1, 0); change >= 0 {
+		t.Errorf("got change %s, expected negative change when the inputs cannot pay the fee", change)
+	}
+}
+
+func TestNewTransactionEstimate(t *testing.T) {
+	lockedUtxo := testUtxo("locked", 5e8, "address3", 0)
+	lockedUtxo.Locked = true
+	utxos := []*UnspentOutput{
+		testUtxo("a", 3e8, "address1", 0),
+		testUtxo("b", 1e8, "address2", 0),
+		lockedUtxo,
+	}
+
+	tests := []struct {
+		name         string
+		destinations []txhelper.TransactionDestination
+		utxoKeys     []string
+		feeRate      dcrutil.Amount
+		inputs       []string
+		change       dcrutil.Amount
+		size         int
+		fee          dcrutil.Amount
+	}{
+		{"selected inputs with change", sendTo(2), nil, 0, []string{"a"}, 3e8 - 2e8 - 2530, 253, 2530},
+		{"chosen inputs with change", sendTo(2), []string{"a", "b"}, 0, []string{"a", "b"}, 4e8 - 2e8 - 4190, 419, 4190},
+		{"higher fee rate", sendTo(2), nil, 2e4, []string{"a"}, 3e8 - 2e8 - 5060, 253, 5060},
+		// the change left after the fee for a change output is dust, so it is paid as fee and there is no change output
+		{"dust change", sendTo(2.99997730), []string{"a"}, 0, []string{"a"}, 0, 217, 2270},
+	}
+
+	for _, test := range tests {
+		estimate, err := NewTransactionEstimate(utxos, 0, 1, test.destinations, test.utxoKeys, LargestFirstSelection, test.feeRate)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err.Error())
+			continue
+		}
+		if keys := estimate.OutputKeys(); strings.Join(keys, ",") != strings.Join(test.inputs, ",") {
+			t.Errorf("%s: got inputs %v, expected %v", test.name, keys, test.inputs)
+		}
+		if estimate.Change != test.change || estimate.Size != test.size || estimate.Fee != test.fee {
+			t.Errorf("%s: got change %s, size %d, fee %s, expected change %s, size %d, fee %s", test.name,
+				estimate.Change, estimate.Size, estimate.Fee, test.change, test.size, test.fee)
+		}
+		expectedChangeOutputs := 0
+		if test.change > 0 {
+			expectedChangeOutputs = 1
+		}
+		if estimate.ChangeOutputs != expectedChangeOutputs {
+			t.Errorf("%s: got %d change outputs, expected %d", test.name, estimate.ChangeOutputs, expectedChangeOutputs)
+		}
+		if estimate.TotalInput != estimate.SendAmount+estimate.Change+estimate.Fee {
+			t.Errorf("%s: inputs of %s do not pay the send amount, change and fee", test.name, estimate.TotalInput)
+		}
+	}
+
+	invalidEstimates := []struct {
+		name         string
+		destinations []txhelper.TransactionDestination
+		utxoKeys     []string
+	}{
+		{"no destinations", nil, nil},
+		{"zero amount", sendTo(0), nil},
+		{"insufficient funds in chosen inputs", sendTo(1), []string{"b"}},
+		{"insufficient unlocked funds", sendTo(5), nil},
+		{"locked input", sendTo(1), []string{"locked"}},
+		{"unknown input", sendTo(1), []string{"c"}},
+	}
+	for _, test := range invalidEstimates {
+		if _, err := NewTransactionEstimate(utxos, 0, 1, test.destinations, test.utxoKeys, LargestFirstSelection, 0); err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+}
+
+func TestNewSweepEstimate(t *testing.T) {
+	lockedUtxo := testUtxo("locked", 5e8, "address3", 0)
+	lockedUtxo.Locked = true
+	utxos := []*UnspentOutput{
+		testUtxo("a", 3e8, "address1", 0),
+		testUtxo("b", 1e8, "address2", 0),
+		lockedUtxo,
+	}
+
+	// locked outputs are left out of a sweep of the account
+	estimate, err := NewSweepEstimate(utxos, 0, 1, "destination", nil, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if keys := estimate.OutputKeys(); strings.Join(keys, ",") != "a,b" {
+		t.Errorf("got inputs %v, expected a and b", keys)
+	}
+	// 2 inputs and 1 output with no change output
+	if estimate.Size != 383 || estimate.Fee != 3830 || estimate.Change != 0 || estimate.ChangeOutputs != 0 {
+		t.Errorf("got size %d, fee %s, change %s, expected size 383, fee %s and no change",
+			estimate.Size, estimate.Fee, estimate.Change, dcrutil.Amount(3830))
+	}
+	if sendAmount := dcrutil.Amount(4e8 - 3830); estimate.SendAmount != sendAmount || estimate.Destinations[0].Amount != sendAmount.ToCoin() {
+		t.Errorf("got send amount %s and destination amount %v, expected %s", estimate.SendAmount, estimate.Destinations[0].Amount, sendAmount)
+	}
+
+	estimate, err = NewSweepEstimate(utxos, 0, 1, "destination", []string{"b"}, 2e4)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if estimate.SendAmount != 1e8-4340 {
+		t.Errorf("got send amount %s, expected %s", estimate.SendAmount, dcrutil.Amount(1e8-4340))
+	}
+
+	invalidSweeps := []struct {
+		name     string
+		utxos    []*UnspentOutput
+		utxoKeys []string
+	}{
+		{"no outputs", nil, nil},
+		{"only locked outputs", []*UnspentOutput{lockedUtxo}, nil},
+		{"locked input", utxos, []string{"locked"}},
+		{"unknown input", utxos, []string{"c"}},
+		// 5000 atoms leave 2830 atoms after the fee, which is dust
+		{"dust", []*UnspentOutput{testUtxo("small", 5000, "address4", 0)}, nil},
+	}
+	for _, test := range invalidSweeps {
+		if _, err := NewSweepEstimate(test.utxos, 0, 1, "destination", test.utxoKeys, 0); err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+}
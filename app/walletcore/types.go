@@ -19,6 +19,19 @@ type Account struct {
 	Balance *Balance `json:"balance"`
 }
 
+// AccountDetails holds the balance, key counts and derivation information of an account, for account management
+type AccountDetails struct {
+	*Account
+	Hidden           bool   `json:"hidden"`
+	ExternalKeyCount uint32 `json:"external_key_count"`
+	InternalKeyCount uint32 `json:"internal_key_count"`
+	ImportedKeyCount uint32 `json:"imported_key_count"`
+
+	// DerivationPath is the BIP-44 path of the account's extended key. It is empty for the imported account
+	// and if the wallet medium does not report the coin type used by the wallet
+	DerivationPath string `json:"derivation_path,omitempty"`
+}
+
 type UnspentOutput struct {
 	OutputKey       string         `json:"key"`
 	TransactionHash string         `json:"transaction_hash"`
@@ -28,6 +41,8 @@ type UnspentOutput struct {
 	Amount          dcrutil.Amount `json:"amount"`
 	Address         string         `json:"address"`
 	Confirmations   int32          `json:"confirmations"`
+	Locked          bool           `json:"locked"`
+	Label           string         `json:"label,omitempty"`
 }
 
 type Transaction struct {
@@ -40,6 +55,17 @@ type Transaction struct {
 	Timestamp     int64                         `json:"timestamp"`
 	FormattedTime string                        `json:"formatted_time"`
 	Size          int                           `json:"size"`
+	Label         string                        `json:"label,omitempty"`
+
+	// AccountChanges holds the amount by which the transaction changed the balance of each wallet account it spends from or pays to
+	AccountChanges []*AccountChange `json:"account_changes,omitempty"`
+}
+
+// AccountChange is the amount by which a transaction changed the balance of a wallet account.
+// Spending an output of the account decreases its balance, paying to an address of the account increases it
+type AccountChange struct {
+	Account uint32         `json:"account"`
+	Amount  dcrutil.Amount `json:"amount"`
 }
 
 type TransactionDetails struct {
@@ -47,9 +73,24 @@ type TransactionDetails struct {
 	Confirmations int32                     `json:"confirmations"`
 	Inputs        []*txhelper.DecodedInput  `json:"inputs"`
 	Outputs       []*txhelper.DecodedOutput `json:"outputs"`
+
+	// OutputLabels maps the index of each labelled output to its label or the label of the address it pays
+	OutputLabels map[int]string `json:"output_labels,omitempty"`
 	*Transaction
 }
 
+// ticket statuses
+const (
+	TicketStatusUnmined  = "unmined"
+	TicketStatusImmature = "immature"
+	TicketStatusLive     = "live"
+	TicketStatusVoted    = "voted"
+	TicketStatusMissed   = "missed"
+	TicketStatusExpired  = "expired"
+	TicketStatusRevoked  = "revoked"
+	TicketStatusUnknown  = "unknown"
+)
+
 // StakeInfo holds ticket information summary related to the wallet.
 type StakeInfo struct {
 	// Stake info related to the wallet
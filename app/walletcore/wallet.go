@@ -3,6 +3,7 @@ package walletcore
 import (
 	"context"
 
+	"github.com/decred/dcrd/dcrutil"
 	"github.com/raedahgroup/dcrlibwallet"
 	"github.com/raedahgroup/dcrlibwallet/txhelper"
 )
@@ -14,13 +15,26 @@ const DefaultRequiredConfirmations = 2
 // Wallet defines key functions for performing operations on a decred wallet
 // These functions are implemented by the different mediums that provide access to a decred wallet
 type Wallet interface {
+	// NetType returns the network of the wallet, mainnet or testnet
+	NetType() string
+
 	// Balance returns account balance for the accountNumbers passed in
 	// or for all accounts if no account number is passed in
 	AccountBalance(accountNumber uint32, requiredConfirmations int32) (*Balance, error)
 
-	// AccountsOverview returns the name, account number and balance for all accounts in wallet
+	// AccountsOverview returns the name, account number and balance for all accounts in wallet, except hidden accounts.
+	// Accounts are hidden with HideAccount, the imported account is also hidden while its balance is zero
 	AccountsOverview(requiredConfirmations int32) ([]*Account, error)
 
+	// AccountDetails returns the balance, key counts and derivation path of every account in the wallet, including hidden accounts
+	AccountDetails(requiredConfirmations int32) ([]*AccountDetails, error)
+
+	// RenameAccount changes the name of the account numbered `accountNumber` to `newName`
+	RenameAccount(accountNumber uint32, newName string) error
+
+	// HideAccount hides the account numbered `accountNumber` from AccountsOverview if `hide` is true, or shows it again
+	HideAccount(accountNumber uint32, hide bool) error
+
 	// NextAccount adds an account to the wallet using the specified name
 	// Returns account number for newly added account
 	NextAccount(accountName string, passphrase string) (uint32, error)
@@ -45,14 +59,33 @@ type Wallet interface {
 	// regardless of whether there was a previously generated address that has not been used
 	GenerateNewAddress(account uint32) (string, error)
 
+	// ChangeAddress generates a new address on the internal branch of the specified account, for receiving the change of a transaction.
+	// Change is not sent to receive addresses, so it can be told apart from payments and does not use up the receive address gap
+	ChangeAddress(account uint32) (string, error)
+
 	// UnspentOutputs lists all unspent outputs in the specified account that sum up to `targetAmount`
 	// If `targetAmount` is 0, all unspent outputs in account are returned
 	UnspentOutputs(account uint32, targetAmount int64, requiredConfirmations int32) ([]*UnspentOutput, error)
 
-	// SendFromAccount sends funds to 1 or more destination addresses, each with a specified amount
-	// The inputs to the transaction are automatically selected from all unspent outputs in the account
-	// Returns the transaction hash as string if successful
-	SendFromAccount(sourceAccount uint32, requiredConfirmations int32, destinations []txhelper.TransactionDestination, passphrase string) (string, error)
+	// LockUnspentOutputs prevents the unspent outputs matching `outputKeys` from being spent until they are unlocked.
+	// Locked outputs are skipped when inputs are selected automatically and cannot be spent by key
+	LockUnspentOutputs(outputKeys []string) error
+
+	// UnlockUnspentOutputs makes the locked unspent outputs matching `outputKeys` spendable again
+	UnlockUnspentOutputs(outputKeys []string) error
+
+	// LockedUnspentOutputs returns the keys of all locked unspent outputs
+	LockedUnspentOutputs() ([]string, error)
+
+	// SetLabel saves the label text for the transaction, address or output identified by `labelType` (one of LabelTypes) and `ref`.
+	// An empty `label` removes the label. Labels are shown on transactions and unspent outputs returned by the wallet
+	SetLabel(labelType, ref, label string) error
+
+	// Labels returns all labels saved for the wallet
+	Labels() ([]*Label, error)
+
+	// ImportLabels saves all of `labels`, replacing existing labels for the same records
+	ImportLabels(labels []*Label) error
 
 	// SendFromUTXOs sends funds to 1 or more destination addresses, each with a specified amount
 	// SendFromUTXOs also sends any change amount that arises from the transaction to the provided changeDestinations
@@ -60,8 +93,43 @@ type Wallet interface {
 	// Returns the transaction hash as string if successful
 	SendFromUTXOs(sourceAccount uint32, requiredConfirmations int32, utxoKeys []string, txDestinations []txhelper.TransactionDestination, changeDestinations []txhelper.TransactionDestination, passphrase string) (string, error)
 
-	// TransactionHistory
-	TransactionHistory() ([]*Transaction, error)
+	// EstimateTransaction selects the inputs for a transaction that sends funds to 1 or more destination addresses
+	// and estimates its size, change and the fee paid at `feeRate` per kB, without creating the transaction.
+	// If utxoKeys is empty, inputs are automatically selected from all unspent outputs in the account using the `coinSelection` strategy,
+	// one of CoinSelectionStrategies or "" for DefaultCoinSelection.
+	// A feeRate of 0 uses DefaultFeeRate. The estimated transaction can be sent with SendEstimatedTransaction
+	EstimateTransaction(sourceAccount uint32, requiredConfirmations int32, destinations []txhelper.TransactionDestination, utxoKeys []string, coinSelection string, feeRate dcrutil.Amount) (*TransactionEstimate, error)
+
+	// EstimateSweepTransaction estimates a transaction that sends the entire spendable balance of the account to `destinationAddress`.
+	// If utxoKeys is not empty, only the balance of the unspent outputs matching utxoKeys is sent.
+	// The fee at `feeRate` per kB is deducted from the amount sent and no change output is created
+	EstimateSweepTransaction(sourceAccount uint32, requiredConfirmations int32, destinationAddress string, utxoKeys []string, feeRate dcrutil.Amount) (*TransactionEstimate, error)
+
+	// CreateUnsignedTransaction constructs a transaction that sends funds to 1 or more destination addresses, without signing it.
+	// If utxoKeys is empty, inputs are automatically selected from all unspent outputs in the account and change is sent back to the account.
+	// Otherwise, the unspent outputs matching utxoKeys are spent and any change is sent to changeDestinations.
+	// Unsigned transactions can be created by watch-only wallets, signed with SignTransaction and published with PublishTransaction
+	CreateUnsignedTransaction(sourceAccount uint32, requiredConfirmations int32, destinations []txhelper.TransactionDestination, utxoKeys []string, changeDestinations []txhelper.TransactionDestination) (*OfflineTransaction, error)
+
+	// SignTransaction signs the inputs of an unsigned transaction without publishing it
+	// The wallet does not need to be synced, the scripts of the outputs being spent are read from the offline transaction
+	SignTransaction(unsignedTx *OfflineTransaction, passphrase string) (*OfflineTransaction, error)
+
+	// PublishTransaction broadcasts a signed transaction to the decred network
+	// Returns the transaction hash as string if successful
+	PublishTransaction(signedTx *OfflineTransaction) (string, error)
+
+	// SignMessage signs `message` with the private key of `address`, which must belong to the wallet.
+	// Returns the base64-encoded signature, which anyone can check with VerifyMessage
+	SignMessage(address, message, passphrase string) (string, error)
+
+	// VerifyMessage returns true if the base64-encoded `signature` is a signature of `message` by the private key of `address`.
+	// `address` does not need to belong to the wallet
+	VerifyMessage(address, message, signature string) (bool, error)
+
+	// TransactionHistory returns the wallet transactions that match the query, sorted and paged as specified by the query.
+	// A nil query returns all transactions, newest first
+	TransactionHistory(query *TransactionHistoryQuery) ([]*Transaction, error)
 
 	// GetTransaction returns information about the transaction with the given hash.
 	// An error is returned if the no transaction with the given hash is found.
@@ -70,6 +138,20 @@ type Wallet interface {
 	// StakeInfo returns information about wallet stakes, tickets and their statuses.
 	StakeInfo(ctx context.Context) (*StakeInfo, error)
 
+	// Tickets returns the tickets purchased by the wallet that match `filter`, newest first.
+	// A nil filter returns all tickets
+	Tickets(ctx context.Context, filter *TicketFilter) ([]*Ticket, error)
+
+	// RevokeTickets revokes all missed and expired tickets, returning the ticket price to the ticket owners.
+	// The tickets revoked before an error occurs are returned along with the error
+	RevokeTickets(ctx context.Context, passphrase string) ([]*RevokedTicket, error)
+
+	// RevokeTicket revokes the missed or expired ticket with hash `ticketHash` and returns the hash of the revocation
+	RevokeTicket(ctx context.Context, ticketHash, passphrase string) (revocationHash string, err error)
+
 	// PurchaseTickets is used to purchase tickets.
 	PurchaseTickets(ctx context.Context, request dcrlibwallet.PurchaseTicketsRequest) (ticketHashes []string, err error)
+
+	// IsWatchingOnlyWallet returns true if the wallet was created from an extended public key and cannot sign transactions
+	IsWatchingOnlyWallet() bool
 }
@@ -0,0 +1,42 @@
+package walletcore
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/decred/dcrd/chaincfg"
+	"github.com/decred/dcrd/hdkeychain"
+)
+
+// ErrWatchingOnlyWallet is returned by wallet operations that require private keys, such as sending funds,
+// purchasing tickets or creating accounts, when the wallet was created from an extended public key.
+// Frontends should check Wallet.IsWatchingOnlyWallet before asking the user for a spending passphrase
+var ErrWatchingOnlyWallet = errors.New("this is a watch-only wallet, it has no private keys to sign transactions")
+
+// ValidateExtendedPublicKey checks that `extendedPublicKey` is an account extended public key for the `netType` network
+// Extended private keys are rejected so that they are never saved by a watch-only wallet
+func ValidateExtendedPublicKey(extendedPublicKey, netType string) error {
+	extendedPublicKey = strings.TrimSpace(extendedPublicKey)
+	if extendedPublicKey == "" {
+		return fmt.Errorf("extended public key is required")
+	}
+
+	key, err := hdkeychain.NewKeyFromString(extendedPublicKey)
+	if err != nil {
+		return fmt.Errorf("invalid extended public key: %s", err.Error())
+	}
+	if key.IsPrivate() {
+		return fmt.Errorf("an extended private key was provided, use the account's extended public key instead")
+	}
+
+	netParams := &chaincfg.MainNetParams
+	if netType != "mainnet" {
+		netParams = &chaincfg.TestNet3Params
+	}
+	if !key.IsForNet(netParams) {
+		return fmt.Errorf("extended public key is not for %s", netType)
+	}
+
+	return nil
+}
@@ -1,20 +1,48 @@
 package dcrlibwallet
 
 import (
+	"path/filepath"
+	"sync"
+
 	"github.com/decred/dcrwallet/netparams"
 	"github.com/raedahgroup/dcrlibwallet"
+	"github.com/raedahgroup/godcr/app"
+	"github.com/raedahgroup/godcr/app/walletcore"
+	"github.com/raedahgroup/godcr/app/walletmediums"
 )
 
 // DcrWalletLib implements `WalletMiddleware` using `dcrlibwallet.LibWallet` as medium for connecting to a decred wallet
 // Functions relating to operations that can be performed on a wallet are defined in `walletfunctions.go`
 // Other wallet-related functions are defined in `walletloader.go`
+// Wallet event subscriptions are handled in `events.go`
 type DcrWalletLib struct {
 	walletLib *dcrlibwallet.LibWallet
 	activeNet *netparams.Params
+
+	// directory of the wallet database opened by dcrlibwallet and the public passphrase it was opened with,
+	// used to open the database directly for operations dcrlibwallet does not support
+	walletDbDir      string
+	publicPassphrase string
+
+	// outputs locked by the user, which dcrlibwallet does not know about
+	locks *walletcore.OutputLocks
+	// labels set by the user, kept by godcr
+	labels *walletcore.Labels
+	// accounts hidden by the user, kept by godcr
+	hiddenAccounts *walletcore.HiddenAccounts
+	// seed of a new wallet kept until the user confirms they backed it up
+	seedBackup *walletcore.SeedBackup
+
+	events             walletmediums.EventBroadcaster
+	registerTxListener sync.Once
 }
 
 // New connects to dcrlibwallet and returns an instance of DcrWalletLib
-func New(appDataDir string, netType string) *DcrWalletLib {
+// Unspent outputs in `locks` are excluded from transactions created by the returned instance
+// and transactions and unspent outputs returned by the instance are marked with the labels in `labels`.
+// Accounts in `hiddenAccounts` are left out of AccountsOverview and the seed of a new wallet is kept in `seedBackup` until its backup is confirmed
+func New(appDataDir string, netType string, locks *walletcore.OutputLocks, labels *walletcore.Labels,
+	hiddenAccounts *walletcore.HiddenAccounts, seedBackup *walletcore.SeedBackup) *DcrWalletLib {
 	lw := dcrlibwallet.NewLibWallet(appDataDir, dcrlibwallet.DefaultDbDriver, netType)
 	lw.SetLogLevel("off")
 	lw.InitLoaderWithoutShutdownListener()
@@ -29,5 +57,14 @@ func New(appDataDir string, netType string) *DcrWalletLib {
 	return &DcrWalletLib{
 		walletLib: lw,
 		activeNet: activeNet,
+		locks:     locks,
+		labels:    labels,
+
+		// dcrlibwallet keeps the wallet database in a directory named after the network in the app data dir
+		walletDbDir:      filepath.Join(appDataDir, netType),
+		publicPassphrase: app.DefaultPublicPassphrase,
+
+		hiddenAccounts: hiddenAccounts,
+		seedBackup:     seedBackup,
 	}
 }
@@ -0,0 +1,65 @@
+package dcrlibwallet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/raedahgroup/godcr/app"
+)
+
+func (lib *DcrWalletLib) Subscribe(ctx context.Context) (<-chan app.WalletEvent, error) {
+	if !lib.IsWalletOpen() {
+		return nil, fmt.Errorf("wallet is not open")
+	}
+
+	// dcrlibwallet does not support removing transaction listeners,
+	// so register a single listener the first time and broadcast its notifications to all subscribers
+	lib.registerTxListener.Do(func() {
+		lib.events.BroadcastTicketStatusChanges(context.Background(), lib)
+		lib.walletLib.TransactionNotification(transactionListener{lib})
+	})
+
+	return lib.events.Subscribe(ctx), nil
+}
+
+// transactionListener implements dcrlibwallet.TransactionListener
+type transactionListener struct {
+	lib *DcrWalletLib
+}
+
+// OnTransaction is called with the json-encoded details of new unmined transactions involving the wallet
+func (listener transactionListener) OnTransaction(transaction string) {
+	var tx struct {
+		Hash string `json:"hash"`
+	}
+	if err := json.Unmarshal([]byte(transaction), &tx); err != nil {
+		return
+	}
+
+	txDetails, err := listener.lib.GetTransaction(tx.Hash)
+	if err != nil {
+		return
+	}
+	listener.lib.events.BroadcastTransaction(txDetails, false)
+}
+
+// OnTransactionConfirmed is called when a transaction involving the wallet is mined
+func (listener transactionListener) OnTransactionConfirmed(hash string, height int32) {
+	txDetails, err := listener.lib.GetTransaction(hash)
+	if err != nil {
+		return
+	}
+	txDetails.BlockHeight = height
+	listener.lib.events.BroadcastTransaction(txDetails, true)
+}
+
+func (listener transactionListener) OnBlockAttached(height int32, timestamp int64) {
+	listener.lib.events.BroadcastNewBlock(app.NewBlockEvent{
+		Height:    height,
+		Timestamp: timestamp,
+	})
+
+	// tickets become live, are missed or expire as blocks are mined, without a wallet transaction
+	listener.lib.events.BroadcastTicketStatusChanges(context.Background(), listener.lib)
+}
@@ -0,0 +1,151 @@
+package dcrlibwallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/decred/dcrd/addrmgr"
+	"github.com/decred/dcrd/chaincfg"
+	"github.com/decred/dcrd/txscript"
+	"github.com/decred/dcrd/wire"
+	"github.com/decred/dcrwallet/loader"
+	"github.com/decred/dcrwallet/p2p"
+	"github.com/decred/dcrwallet/wallet"
+	"github.com/raedahgroup/dcrlibwallet"
+	"github.com/raedahgroup/godcr/app/walletcore"
+)
+
+// dcrlibwallet only signs and publishes transactions in a single call, signing and publishing offline transactions
+// is done here with the dcrwallet packages that dcrlibwallet is built on
+
+const (
+	// broadcastTimeout is how long to keep looking for peers to send a transaction to
+	broadcastTimeout = 2 * time.Minute
+
+	// peerRequestWait is how long to wait for connected peers to request an announced transaction before connecting to another peer
+	peerRequestWait = 5 * time.Second
+)
+
+// signWithWalletDb signs the inputs of `tx` that spend the outputs with the scripts in `prevScripts` using the private keys of the wallet.
+// dcrlibwallet holds the wallet database open, so the wallet is closed and its database opened with dcrwallet's loader for signing,
+// then the wallet is opened again. Wallet operations running at the same time, such as syncing, are stopped
+func (lib *DcrWalletLib) signWithWalletDb(tx *wire.MsgTx, prevScripts map[wire.OutPoint][]byte, passphrase string) (err error) {
+	publicPassphrase := lib.publicPassphrase
+	lib.walletLib.Shutdown(false)
+	defer func() {
+		if openErr := lib.walletLib.OpenWallet([]byte(publicPassphrase)); openErr != nil && err == nil {
+			err = fmt.Errorf("error reopening wallet after signing: %s", openErr.Error())
+		}
+	}()
+
+	walletLoader := loader.NewLoader(lib.activeNet.Params, lib.walletDbDir, &loader.StakeOptions{}, wallet.DefaultGapLimit,
+		false, walletcore.DefaultFeeRate.ToCoin(), wallet.DefaultAccountGapLimit)
+	walletLoader.SetDatabaseDriver(dcrlibwallet.DefaultDbDriver)
+
+	walletExists, err := walletLoader.WalletExists()
+	if err != nil {
+		return err
+	}
+	if !walletExists {
+		return fmt.Errorf("wallet database not found in %s", lib.walletDbDir)
+	}
+
+	w, err := walletLoader.OpenExistingWallet([]byte(publicPassphrase))
+	if err != nil {
+		return fmt.Errorf("error opening wallet database: %s", err.Error())
+	}
+	defer walletLoader.UnloadWallet()
+
+	lock := make(chan time.Time, 1)
+	defer func() {
+		lock <- time.Time{}
+	}()
+	if err = w.Unlock([]byte(passphrase), lock); err != nil {
+		return fmt.Errorf("error unlocking wallet: %s", err.Error())
+	}
+
+	signatureErrors, err := w.SignTransaction(tx, txscript.SigHashAll, prevScripts, nil, nil)
+	if err != nil {
+		return fmt.Errorf("error signing transaction: %s", err.Error())
+	}
+	if len(signatureErrors) > 0 {
+		unsignedInputs := make([]uint32, len(signatureErrors))
+		for i, signatureError := range signatureErrors {
+			unsignedInputs[i] = signatureError.InputIndex
+		}
+		return fmt.Errorf("could not sign transaction inputs %v, the outputs they spend may not belong to this wallet", unsignedInputs)
+	}
+	return nil
+}
+
+// broadcastTransaction publishes `tx` to peers on the decred network found through the dns seeders of `params`,
+// using the peer-to-peer protocol that dcrlibwallet syncs with.
+// The transaction is announced to connected peers and broadcasting succeeds once a peer requests the transaction and it is sent
+func broadcastTransaction(params *chaincfg.Params, tx *wire.MsgTx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), broadcastTimeout)
+	// peers are disconnected when the context is cancelled
+	defer cancel()
+
+	addressManager := addrmgr.New("", net.LookupIP)
+	localPeer := p2p.NewLocalPeer(params, &net.TCPAddr{IP: net.ParseIP("::1")}, addressManager)
+	localPeer.AddHandledMessages(p2p.MaskGetData)
+	localPeer.DNSSeed(wire.SFNodeNetwork)
+
+	txHash := tx.TxHash()
+	sent := make(chan error, 1)
+	go func() {
+		for {
+			peer, getData, err := localPeer.ReceiveGetData(ctx)
+			if err != nil {
+				return
+			}
+			for _, inv := range getData.InvList {
+				if inv.Type != wire.InvTypeTx || inv.Hash != txHash {
+					continue
+				}
+				select {
+				case sent <- peer.SendMessage(ctx, tx):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	var lastErr error
+	for {
+		select {
+		case err := <-sent:
+			if err == nil {
+				return nil
+			}
+			lastErr = err
+		case <-ctx.Done():
+			if lastErr != nil {
+				return fmt.Errorf("error broadcasting transaction: %s", lastErr.Error())
+			}
+			return errors.New("error broadcasting transaction: no peer on the decred network requested the transaction")
+		case <-time.After(peerRequestWait):
+		}
+
+		// dns seeding runs in the background, there may be no peer addresses yet
+		knownAddress := addressManager.GetAddress()
+		if knownAddress == nil {
+			continue
+		}
+		address := knownAddress.NetAddress()
+		addressManager.Attempt(address)
+
+		peer, err := localPeer.ConnectOutbound(ctx, addrmgr.NetAddressKey(address), wire.SFNodeNetwork)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if err = peer.PublishTransactions(ctx, tx); err != nil {
+			lastErr = err
+		}
+	}
+}
@@ -3,8 +3,9 @@ package dcrlibwallet
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
-	"sort"
+	"strings"
 	"time"
 
 	"github.com/decred/dcrd/chaincfg/chainhash"
@@ -12,6 +13,7 @@ import (
 	"github.com/decred/dcrd/wire"
 	"github.com/raedahgroup/dcrlibwallet"
 	"github.com/raedahgroup/dcrlibwallet/txhelper"
+	"github.com/raedahgroup/godcr/app"
 	"github.com/raedahgroup/godcr/app/walletcore"
 )
 
@@ -37,34 +39,83 @@ func (lib *DcrWalletLib) AccountsOverview(requiredConfirmations int32) ([]*walle
 	}
 
 	accountsOverview := make([]*walletcore.Account, 0, len(accounts.Acc))
+	for _, acc := range accounts.Acc {
+		accountsOverview = append(accountsOverview, walletcoreAccount(acc))
+	}
+
+	return lib.hiddenAccounts.VisibleAccounts(accountsOverview), nil
+}
 
+func (lib *DcrWalletLib) AccountDetails(requiredConfirmations int32) ([]*walletcore.AccountDetails, error) {
+	accounts, err := lib.walletLib.GetAccountsRaw(requiredConfirmations)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching accounts: %s", err.Error())
+	}
+
+	accountDetails := make([]*walletcore.AccountDetails, 0, len(accounts.Acc))
 	for _, acc := range accounts.Acc {
-		accountNumber := uint32(acc.Number)
+		account := walletcoreAccount(acc)
+
+		// dcrlibwallet does not report the coin type used by the wallet, so the derivation path is left empty
+		accountDetails = append(accountDetails, &walletcore.AccountDetails{
+			Account:          account,
+			Hidden:           lib.hiddenAccounts.IsHidden(account),
+			ExternalKeyCount: uint32(acc.ExternalKeyCount),
+			InternalKeyCount: uint32(acc.InternalKeyCount),
+			ImportedKeyCount: uint32(acc.ImportedKeyCount),
+		})
+	}
 
-		// skip zero-balance imported accounts
-		if acc.Name == "imported" && acc.Balance.Total == 0 {
-			continue
-		}
+	return accountDetails, nil
+}
 
-		account := &walletcore.Account{
-			Name:   acc.Name,
-			Number: accountNumber,
-			Balance: &walletcore.Balance{
-				Total:           dcrutil.Amount(acc.Balance.Total),
-				Spendable:       dcrutil.Amount(acc.Balance.Spendable),
-				LockedByTickets: dcrutil.Amount(acc.Balance.LockedByTickets),
-				VotingAuthority: dcrutil.Amount(acc.Balance.VotingAuthority),
-				Unconfirmed:     dcrutil.Amount(acc.Balance.UnConfirmed),
-			},
-		}
-		accountsOverview = append(accountsOverview, account)
+func walletcoreAccount(acc *dcrlibwallet.Account) *walletcore.Account {
+	return &walletcore.Account{
+		Name:   acc.Name,
+		Number: uint32(acc.Number),
+		Balance: &walletcore.Balance{
+			Total:           dcrutil.Amount(acc.Balance.Total),
+			Spendable:       dcrutil.Amount(acc.Balance.Spendable),
+			LockedByTickets: dcrutil.Amount(acc.Balance.LockedByTickets),
+			VotingAuthority: dcrutil.Amount(acc.Balance.VotingAuthority),
+			Unconfirmed:     dcrutil.Amount(acc.Balance.UnConfirmed),
+		},
+	}
+}
+
+func (lib *DcrWalletLib) RenameAccount(accountNumber uint32, newName string) error {
+	if err := lib.walletLib.RenameAccount(int32(accountNumber), newName); err != nil {
+		return fmt.Errorf("error renaming account: %s", err.Error())
 	}
 
-	return accountsOverview, nil
+	// dcrlibwallet has no account notifications, notify event subscribers of accounts renamed through godcr
+	lib.events.Broadcast(app.AccountEvent{
+		AccountNumber: accountNumber,
+		AccountName:   newName,
+	})
+	return nil
+}
+
+func (lib *DcrWalletLib) HideAccount(accountNumber uint32, hide bool) error {
+	return lib.hiddenAccounts.SetHidden(accountNumber, hide)
 }
 
 func (lib *DcrWalletLib) NextAccount(accountName string, passphrase string) (uint32, error) {
-	return lib.walletLib.NextAccountRaw(accountName, []byte(passphrase))
+	if lib.IsWatchingOnlyWallet() {
+		return 0, walletcore.ErrWatchingOnlyWallet
+	}
+
+	accountNumber, err := lib.walletLib.NextAccountRaw(accountName, []byte(passphrase))
+	if err != nil {
+		return 0, err
+	}
+
+	// dcrlibwallet has no account notifications, notify event subscribers of accounts created through godcr
+	lib.events.Broadcast(app.AccountEvent{
+		AccountNumber: accountNumber,
+		AccountName:   accountName,
+	})
+	return accountNumber, nil
 }
 
 func (lib *DcrWalletLib) AccountNumber(accountName string) (uint32, error) {
@@ -91,6 +142,12 @@ func (lib *DcrWalletLib) GenerateNewAddress(account uint32) (string, error) {
 	return lib.walletLib.NextAddress(int32(account))
 }
 
+// ChangeAddress returns a new external address, dcrlibwallet does not derive addresses on the internal branch of an account
+// for callers outside of its own transaction construction
+func (lib *DcrWalletLib) ChangeAddress(account uint32) (string, error) {
+	return lib.walletLib.NextAddress(int32(account))
+}
+
 func (lib *DcrWalletLib) UnspentOutputs(account uint32, targetAmount int64, requiredConfirmations int32) ([]*walletcore.UnspentOutput, error) {
 	utxos, err := lib.walletLib.UnspentOutputs(account, requiredConfirmations, targetAmount)
 	if err != nil {
@@ -127,24 +184,44 @@ func (lib *DcrWalletLib) UnspentOutputs(account uint32, targetAmount int64, requ
 		}
 	}
 
+	lib.locks.MarkLocked(unspentOutputs)
+	lib.labels.MarkUnspentOutputs(unspentOutputs)
 	return unspentOutputs, nil
 }
 
-func (lib *DcrWalletLib) SendFromAccount(sourceAccount uint32, requiredConfirmations int32, destinations []txhelper.TransactionDestination, passphrase string) (string, error) {
-	txHash, err := lib.walletLib.BulkSendTransaction([]byte(passphrase), destinations, int32(sourceAccount), requiredConfirmations)
-	if err != nil {
-		return "", err
-	}
+func (lib *DcrWalletLib) LockUnspentOutputs(outputKeys []string) error {
+	return lib.locks.Lock(outputKeys)
+}
 
-	transactionHash, err := chainhash.NewHash(txHash)
-	if err != nil {
-		return "", fmt.Errorf("error parsing successful transaction hash: %s", err.Error())
-	}
+func (lib *DcrWalletLib) UnlockUnspentOutputs(outputKeys []string) error {
+	return lib.locks.Unlock(outputKeys)
+}
 
-	return transactionHash.String(), nil
+func (lib *DcrWalletLib) LockedUnspentOutputs() ([]string, error) {
+	return lib.locks.Keys(), nil
+}
+
+func (lib *DcrWalletLib) SetLabel(labelType, ref, label string) error {
+	return lib.labels.Set(&walletcore.Label{Type: labelType, Ref: ref, Label: label})
+}
+
+func (lib *DcrWalletLib) Labels() ([]*walletcore.Label, error) {
+	return lib.labels.All(), nil
+}
+
+func (lib *DcrWalletLib) ImportLabels(labels []*walletcore.Label) error {
+	return lib.labels.Import(labels)
 }
 
 func (lib *DcrWalletLib) SendFromUTXOs(sourceAccount uint32, requiredConfirmations int32, utxoKeys []string, txDestinations []txhelper.TransactionDestination, changeDestinations []txhelper.TransactionDestination, passphrase string) (string, error) {
+	if lib.IsWatchingOnlyWallet() {
+		return "", walletcore.ErrWatchingOnlyWallet
+	}
+
+	if err := lib.locks.CheckUnlocked(utxoKeys); err != nil {
+		return "", err
+	}
+
 	// fetch all utxos in account to extract details for the utxos selected by user
 	// use targetAmount = 0 to fetch ALL utxos in account
 	unspentOutputs, err := lib.UnspentOutputs(sourceAccount, 0, requiredConfirmations)
@@ -204,37 +281,183 @@ func (lib *DcrWalletLib) SendFromUTXOs(sourceAccount uint32, requiredConfirmatio
 	return transactionHash.String(), nil
 }
 
-func (lib *DcrWalletLib) TransactionHistory() ([]*walletcore.Transaction, error) {
-	txs, err := lib.walletLib.GetTransactionsRaw()
+func (lib *DcrWalletLib) EstimateTransaction(sourceAccount uint32, requiredConfirmations int32, destinations []txhelper.TransactionDestination,
+	utxoKeys []string, coinSelection string, feeRate dcrutil.Amount) (*walletcore.TransactionEstimate, error) {
+
+	// fetch all utxos in account, the inputs for the transaction are selected from them
+	utxos, err := lib.UnspentOutputs(sourceAccount, 0, requiredConfirmations)
 	if err != nil {
 		return nil, err
 	}
 
-	transactions := make([]*walletcore.Transaction, len(txs))
-	for i, tx := range txs {
-		_, txFee, txSize, txFeeRate, err := txhelper.MsgTxFeeSizeRate(tx.Transaction)
+	return walletcore.NewTransactionEstimate(utxos, sourceAccount, requiredConfirmations, destinations, utxoKeys, coinSelection, feeRate)
+}
+
+func (lib *DcrWalletLib) EstimateSweepTransaction(sourceAccount uint32, requiredConfirmations int32, destinationAddress string,
+	utxoKeys []string, feeRate dcrutil.Amount) (*walletcore.TransactionEstimate, error) {
+
+	utxos, err := lib.UnspentOutputs(sourceAccount, 0, requiredConfirmations)
+	if err != nil {
+		return nil, err
+	}
+
+	return walletcore.NewSweepEstimate(utxos, sourceAccount, requiredConfirmations, destinationAddress, utxoKeys, feeRate)
+}
+
+func (lib *DcrWalletLib) CreateUnsignedTransaction(sourceAccount uint32, requiredConfirmations int32, destinations []txhelper.TransactionDestination,
+	utxoKeys []string, changeDestinations []txhelper.TransactionDestination) (*walletcore.OfflineTransaction, error) {
+
+	accountInputs, err := lib.offlineTxInputs(sourceAccount, requiredConfirmations)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(utxoKeys) == 0 {
+		// dcrlibwallet cannot construct a transaction without signing it, select inputs from the unlocked outputs
+		// and create the change output here
+		utxoKeys, changeDestinations, err = walletcore.InputsExcludingLocked(lib, sourceAccount, requiredConfirmations, destinations, walletcore.DefaultCoinSelection, walletcore.DefaultFeeRate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = lib.locks.CheckUnlocked(utxoKeys); err != nil {
+		return nil, err
+	}
+	inputs := make([]*walletcore.OfflineTxInput, 0, len(utxoKeys))
+	for _, key := range utxoKeys {
+		input, ok := accountInputs[key]
+		if !ok {
+			return nil, fmt.Errorf("unspent output %s not found in account", key)
+		}
+		inputs = append(inputs, input)
+	}
+
+	txInputs := make([]*wire.TxIn, len(inputs))
+	for i, input := range inputs {
+		if txInputs[i], err = input.TxIn(); err != nil {
+			return nil, err
+		}
+	}
+
+	unsignedTx, err := txhelper.NewUnsignedTx(txInputs, destinations, changeDestinations)
+	if err != nil {
+		return nil, err
+	}
+
+	return walletcore.NewOfflineTransaction(lib.NetType(), sourceAccount, unsignedTx, inputs)
+}
+
+func (lib *DcrWalletLib) SignTransaction(unsignedTx *walletcore.OfflineTransaction, passphrase string) (*walletcore.OfflineTransaction, error) {
+	if lib.IsWatchingOnlyWallet() {
+		return nil, walletcore.ErrWatchingOnlyWallet
+	}
+
+	tx, err := unsignedTx.MsgTx()
+	if err != nil {
+		return nil, fmt.Errorf("error decoding transaction: %s", err.Error())
+	}
+
+	// pass the scripts of the outputs being spent, the signing wallet may not have seen them if it is not synced
+	prevScripts := make(map[wire.OutPoint][]byte, len(unsignedTx.Inputs))
+	for _, input := range unsignedTx.Inputs {
+		txIn, err := input.TxIn()
 		if err != nil {
 			return nil, err
 		}
+		pkScript, err := input.PkScriptBytes()
+		if err != nil {
+			return nil, err
+		}
+		prevScripts[txIn.PreviousOutPoint] = pkScript
+	}
+
+	// dcrlibwallet only signs transactions as part of publishing them
+	if err = lib.signWithWalletDb(tx, prevScripts, passphrase); err != nil {
+		return nil, err
+	}
+	return unsignedTx.Signed(tx)
+}
+
+func (lib *DcrWalletLib) PublishTransaction(signedTx *walletcore.OfflineTransaction) (string, error) {
+	tx, err := signedTx.MsgTx()
+	if err != nil {
+		return "", fmt.Errorf("error decoding transaction: %s", err.Error())
+	}
+
+	// dcrlibwallet only publishes transactions that it signs, send the transaction to network peers directly
+	if err = broadcastTransaction(lib.activeNet.Params, tx); err != nil {
+		return "", err
+	}
+	return tx.TxHash().String(), nil
+}
+
+func (lib *DcrWalletLib) SignMessage(address, message, passphrase string) (string, error) {
+	if lib.IsWatchingOnlyWallet() {
+		return "", walletcore.ErrWatchingOnlyWallet
+	}
+
+	signature, err := lib.walletLib.SignMessage([]byte(passphrase), address, message)
+	if err != nil {
+		return "", fmt.Errorf("error signing message: %s", err.Error())
+	}
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+func (lib *DcrWalletLib) VerifyMessage(address, message, signature string) (bool, error) {
+	valid, err := lib.walletLib.VerifyMessage(address, message, signature)
+	if err != nil {
+		return false, fmt.Errorf("error verifying message: %s", err.Error())
+	}
+	return valid, nil
+}
+
+func (lib *DcrWalletLib) TransactionHistory(query *walletcore.TransactionHistoryQuery) ([]*walletcore.Transaction, error) {
+	// dcrlibwallet reads the whole transaction history, but decoding transactions for their fees is only done
+	// for the transactions left after the query is applied
+	txs, err := lib.walletLib.GetTransactionsRaw()
+	if err != nil {
+		return nil, err
+	}
 
+	transactions := make([]*walletcore.Transaction, len(txs))
+	serializedTxs := make(map[string][]byte, len(txs))
+	for i, tx := range txs {
 		transactions[i] = &walletcore.Transaction{
 			Hash:          tx.Hash,
 			Amount:        dcrutil.Amount(tx.Amount),
-			Fee:           txFee,
-			FeeRate:       txFeeRate,
-			Size:          txSize,
 			Type:          tx.Type,
 			Direction:     tx.Direction,
 			Timestamp:     tx.Timestamp,
 			FormattedTime: time.Unix(tx.Timestamp, 0).Format("Mon Jan 2, 2006 3:04PM"),
 		}
+		serializedTxs[tx.Hash] = tx.Transaction
+
+		// the accounts that each transaction spends from or pays to are used for filtering history by account
+		// and for calculating running account balances
+		if tx.Debits != nil {
+			for _, debit := range *tx.Debits {
+				transactions[i].AddAccountChange(uint32(debit.PreviousAccount), -dcrutil.Amount(debit.PreviousAmount))
+			}
+		}
+		if tx.Credits != nil {
+			for _, credit := range *tx.Credits {
+				transactions[i].AddAccountChange(uint32(credit.Account), dcrutil.Amount(credit.Amount))
+			}
+		}
 	}
 
-	// sort transactions by date (list newer first)
-	sort.SliceStable(transactions, func(i1, i2 int) bool {
-		return transactions[i1].Timestamp > transactions[i2].Timestamp
-	})
+	transactions = query.Apply(transactions)
+
+	for _, tx := range transactions {
+		_, txFee, txSize, txFeeRate, err := txhelper.MsgTxFeeSizeRate(serializedTxs[tx.Hash])
+		if err != nil {
+			return nil, err
+		}
+		tx.Fee, tx.FeeRate, tx.Size = txFee, txFeeRate, txSize
+	}
 
+	lib.labels.MarkTransactions(transactions)
 	return transactions, nil
 }
 
@@ -266,13 +489,15 @@ func (lib *DcrWalletLib) GetTransaction(transactionHash string) (*walletcore.Tra
 		Size:          decodedTx.Size,
 	}
 
-	return &walletcore.TransactionDetails{
+	txDetails := &walletcore.TransactionDetails{
 		BlockHeight:   txInfo.BlockHeight,
 		Confirmations: txInfo.Confirmations,
 		Transaction:   tx,
 		Inputs:        decodedTx.Inputs,
 		Outputs:       decodedTx.Outputs,
-	}, nil
+	}
+	lib.labels.MarkTransactionDetails(txDetails)
+	return txDetails, nil
 }
 
 func (lib *DcrWalletLib) StakeInfo(ctx context.Context) (*walletcore.StakeInfo, error) {
@@ -298,7 +523,77 @@ func (lib *DcrWalletLib) StakeInfo(ctx context.Context) (*walletcore.StakeInfo,
 	return stakeInfo, nil
 }
 
+func (lib *DcrWalletLib) Tickets(ctx context.Context, filter *walletcore.TicketFilter) ([]*walletcore.Ticket, error) {
+	ticketInfos, err := lib.walletLib.GetTickets(&dcrlibwallet.GetTicketsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting tickets: %s", err.Error())
+	}
+
+	tickets := make([]*walletcore.Ticket, 0, len(ticketInfos))
+	for _, info := range ticketInfos {
+		var account uint32
+		if len(info.Ticket.MyInputs) > 0 {
+			account = info.Ticket.MyInputs[0].PreviousAccount
+		}
+
+		var spenderHash string
+		var spenderTx []byte
+		if info.Spender != nil {
+			spenderHash = info.Spender.Hash.String()
+			spenderTx = info.Spender.Transaction
+		}
+
+		// dcrlibwallet ticket statuses are upper case names of the walletcore ticket statuses
+		status := strings.ToLower(info.Status)
+
+		ticket, err := walletcore.NewTicket(info.Ticket.Hash.String(), account, info.BlockHeight, status,
+			info.Ticket.Transaction, spenderHash, spenderTx)
+		if err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, ticket)
+	}
+
+	return filter.Apply(tickets), nil
+}
+
+func (lib *DcrWalletLib) RevokeTickets(ctx context.Context, passphrase string) ([]*walletcore.RevokedTicket, error) {
+	if lib.IsWatchingOnlyWallet() {
+		return nil, walletcore.ErrWatchingOnlyWallet
+	}
+	return walletcore.RevokeMissedAndExpiredTickets(ctx, lib, passphrase)
+}
+
+func (lib *DcrWalletLib) RevokeTicket(ctx context.Context, ticketHash, passphrase string) (string, error) {
+	if lib.IsWatchingOnlyWallet() {
+		return "", walletcore.ErrWatchingOnlyWallet
+	}
+	if err := walletcore.CheckTicketRevokable(ctx, lib, ticketHash); err != nil {
+		return "", err
+	}
+
+	hash, err := chainhash.NewHashFromStr(ticketHash)
+	if err != nil {
+		return "", fmt.Errorf("invalid ticket hash: %s", err.Error())
+	}
+	ticket, err := lib.walletLib.GetTransactionRaw(hash[:])
+	if err != nil {
+		return "", fmt.Errorf("error fetching ticket: %s", err.Error())
+	}
+
+	revocation, err := walletcore.NewRevocationTx(ticket.Transaction, walletcore.DefaultFeeRate)
+	if err != nil {
+		return "", err
+	}
+
+	return lib.signAndPublishTransaction(revocation, passphrase)
+}
+
 func (lib *DcrWalletLib) PurchaseTickets(ctx context.Context, request dcrlibwallet.PurchaseTicketsRequest) ([]string, error) {
+	if lib.IsWatchingOnlyWallet() {
+		return nil, walletcore.ErrWatchingOnlyWallet
+	}
+
 	balance, err := lib.AccountBalance(request.Account, int32(request.RequiredConfirmations))
 	if err != nil {
 		return nil, fmt.Errorf("could not fetch account balance: %s", err.Error())
@@ -314,9 +609,63 @@ func (lib *DcrWalletLib) PurchaseTickets(ctx context.Context, request dcrlibwall
 			balance.Spendable, dcrutil.Amount(ticketPrice.TicketPrice))
 	}
 
+	if lib.locks.HasLocks() {
+		// dcrlibwallet would select locked outputs for the tickets, buy them with inputs selected here instead
+		return walletcore.PurchaseTicketsExcludingLocked(lib, request, dcrutil.Amount(ticketPrice.TicketPrice), lib.signAndPublishTransaction)
+	}
+
 	tickets, err := lib.walletLib.PurchaseTickets(ctx, &request)
 	if err != nil {
 		return nil, fmt.Errorf("could not complete ticket(s) purchase, encountered an error:\n%s", err.Error())
 	}
 	return tickets, nil
 }
+
+// signAndPublishTransaction signs the serialized transaction `serializedTx` with the wallet's private keys and publishes it
+func (lib *DcrWalletLib) signAndPublishTransaction(serializedTx []byte, passphrase string) (string, error) {
+	txHash, err := lib.walletLib.SignAndPublishTransaction(serializedTx, []byte(passphrase))
+	if err != nil {
+		return "", err
+	}
+
+	transactionHash, err := chainhash.NewHash(txHash)
+	if err != nil {
+		return "", fmt.Errorf("error parsing successful transaction hash: %s", err.Error())
+	}
+	return transactionHash.String(), nil
+}
+
+// offlineTxInputs returns the unspent outputs in `account` as offline transaction inputs, mapped by output key
+func (lib *DcrWalletLib) offlineTxInputs(account uint32, requiredConfirmations int32) (map[string]*walletcore.OfflineTxInput, error) {
+	utxos, err := lib.walletLib.UnspentOutputs(account, requiredConfirmations, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make(map[string]*walletcore.OfflineTxInput, len(utxos))
+	for _, utxo := range utxos {
+		hash, err := chainhash.NewHash(utxo.TransactionHash)
+		if err != nil {
+			return nil, err
+		}
+
+		address, err := walletcore.GetAddressFromPkScript(lib.activeNet.Params, utxo.PkScript)
+		if err != nil {
+			return nil, err
+		}
+
+		unspentOutput := &walletcore.UnspentOutput{
+			OutputKey: fmt.Sprintf("%s:%d", hash.String(), utxo.OutputIndex),
+			Tree:      utxo.Tree,
+			Amount:    dcrutil.Amount(utxo.Amount),
+			Address:   address,
+		}
+		inputs[unspentOutput.OutputKey] = walletcore.NewOfflineTxInput(unspentOutput, utxo.PkScript)
+	}
+
+	return inputs, nil
+}
+
+func (lib *DcrWalletLib) IsWatchingOnlyWallet() bool {
+	return lib.walletLib.IsWatchingOnlyWallet()
+}
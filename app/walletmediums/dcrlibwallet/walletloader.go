@@ -2,7 +2,11 @@ package dcrlibwallet
 
 import (
 	"fmt"
+	"strings"
+
+	"github.com/decred/dcrwallet/errors"
 	"github.com/raedahgroup/godcr/app"
+	"github.com/raedahgroup/godcr/app/walletcore"
 )
 
 func (lib *DcrWalletLib) NetType() string {
@@ -26,7 +30,32 @@ func (lib *DcrWalletLib) CreateWallet(passphrase, seed string) error {
 	return lib.walletLib.CreateWallet(passphrase, seed)
 }
 
-func (lib *DcrWalletLib) OpenWallet() error {
+func (lib *DcrWalletLib) SaveUnconfirmedSeed(seed, passphrase string) error {
+	return lib.seedBackup.Save(seed, passphrase)
+}
+
+func (lib *DcrWalletLib) HasUnconfirmedSeed() bool {
+	return lib.seedBackup.Pending()
+}
+
+func (lib *DcrWalletLib) UnconfirmedSeed(passphrase string) (string, error) {
+	return lib.seedBackup.Seed(passphrase)
+}
+
+func (lib *DcrWalletLib) ConfirmSeedBackup() error {
+	return lib.seedBackup.Confirm()
+}
+
+func (lib *DcrWalletLib) CreateWatchingOnlyWallet(extendedPublicKey string) error {
+	if err := walletcore.ValidateExtendedPublicKey(extendedPublicKey, lib.NetType()); err != nil {
+		return err
+	}
+
+	// use default public passphrase, same as OpenWallet
+	return lib.walletLib.CreateWatchingOnlyWallet(app.DefaultPublicPassphrase, strings.TrimSpace(extendedPublicKey))
+}
+
+func (lib *DcrWalletLib) OpenWallet(publicPassphrase string) error {
 	walletExists, err := lib.WalletExists()
 	if err != nil {
 		return err
@@ -36,8 +65,48 @@ func (lib *DcrWalletLib) OpenWallet() error {
 		return fmt.Errorf("Wallet does not exist. Please create a wallet first")
 	}
 
-	// open wallet with default public passphrase: "public"
-	return lib.walletLib.OpenWallet([]byte("public"))
+	if publicPassphrase == "" {
+		publicPassphrase = app.DefaultPublicPassphrase
+	}
+	err = lib.walletLib.OpenWallet([]byte(publicPassphrase))
+	if isInvalidPassphraseError(err) {
+		return app.ErrInvalidPublicPassphrase
+	} else if err != nil {
+		return err
+	}
+
+	lib.publicPassphrase = publicPassphrase
+	return nil
+}
+
+func (lib *DcrWalletLib) ChangePrivatePassphrase(oldPassphrase, newPassphrase string) error {
+	if lib.IsWatchingOnlyWallet() {
+		return walletcore.ErrWatchingOnlyWallet
+	}
+
+	err := lib.walletLib.ChangePrivatePassphrase([]byte(oldPassphrase), []byte(newPassphrase))
+	if err != nil {
+		return fmt.Errorf("error changing private passphrase: %s", err.Error())
+	}
+
+	// the unconfirmed seed is encrypted with the private passphrase
+	return lib.seedBackup.ChangePassphrase(oldPassphrase, newPassphrase)
+}
+
+func (lib *DcrWalletLib) ChangePublicPassphrase(oldPassphrase, newPassphrase string) error {
+	err := lib.walletLib.ChangePublicPassphrase([]byte(oldPassphrase), []byte(newPassphrase))
+	if err != nil {
+		return fmt.Errorf("error changing public passphrase: %s", err.Error())
+	}
+
+	lib.publicPassphrase = newPassphrase
+	return nil
+}
+
+// isInvalidPassphraseError checks if err was returned by dcrlibwallet because the wrong passphrase was used.
+// dcrlibwallet returns the errors of the underlying dcrwallet, which have the Passphrase kind for a wrong passphrase
+func isInvalidPassphraseError(err error) bool {
+	return errors.Is(errors.Passphrase, err)
 }
 
 func (lib *DcrWalletLib) CloseWallet() {
@@ -61,6 +130,9 @@ func (lib *DcrWalletLib) SyncBlockChain(listener *app.BlockChainSyncListener, sh
 		listener.SyncEnded = syncEndedListener
 	}
 
+	// broadcast sync progress to event subscribers
+	listener = lib.events.SyncListener(listener)
+
 	syncResponse := SpvSyncResponse{
 		walletLib: lib.walletLib,
 		listener:  listener,
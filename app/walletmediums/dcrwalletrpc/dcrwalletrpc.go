@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/decred/dcrd/chaincfg"
 	"github.com/decred/dcrd/wire"
 	"github.com/decred/dcrwallet/rpc/walletrpc"
+	"github.com/raedahgroup/godcr/app/walletcore"
+	"github.com/raedahgroup/godcr/app/walletmediums"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
@@ -16,11 +19,35 @@ import (
 // WalletRPCClient implements `WalletMiddleware` using `mobilewallet.LibWallet` as medium for connecting to a decred wallet
 // Functions relating to operations that can be performed on a wallet are defined in `walletfunctions.go`
 // Other wallet-related functions are defined in `walletloader.go`
+// Wallet event subscriptions are handled in `events.go`
 type WalletRPCClient struct {
 	walletLoader  walletrpc.WalletLoaderServiceClient
 	walletService walletrpc.WalletServiceClient
 	activeNet     *chaincfg.Params
-	walletOpen    bool
+
+	// message signatures are verified by a separate dcrwallet service
+	messageVerifier walletrpc.MessageVerificationServiceClient
+
+	// stateMu guards walletOpen and watchingOnly, which are set by wallet operations and read from other goroutines.
+	// dcrwallet does not report if a wallet is watching-only, watchingOnly is detected when the wallet is opened
+	// or created by godcr and also set when dcrwallet rejects an operation because the wallet has no private keys
+	stateMu      sync.RWMutex
+	walletOpen   bool
+	watchingOnly bool
+
+	// outputs locked by the user, which dcrwallet does not know about
+	locks *walletcore.OutputLocks
+	// labels set by the user, kept by godcr
+	labels *walletcore.Labels
+	// accounts hidden by the user, kept by godcr
+	hiddenAccounts *walletcore.HiddenAccounts
+	// seed of a new wallet kept until the user confirms they backed it up
+	seedBackup *walletcore.SeedBackup
+
+	events                      walletmediums.EventBroadcaster
+	notificationsMu             sync.Mutex
+	txNotificationsStarted      bool
+	accountNotificationsStarted bool
 }
 
 type rpcConnectionResult struct {
@@ -36,7 +63,11 @@ var (
 // New establishes gRPC connection to a running dcrwallet daemon at the specified address,
 // create a WalletServiceClient using the established connection and
 // returns an instance of `dcrwalletrpc.Client`
-func New(ctx context.Context, rpcAddress, rpcCert string, noTLS bool) (*WalletRPCClient, error) {
+// Unspent outputs in `locks` are excluded from transactions created by the returned instance
+// and transactions and unspent outputs returned by the instance are marked with the labels in `labels`.
+// Accounts in `hiddenAccounts` are left out of AccountsOverview and the seed of a new wallet is kept in `seedBackup` until its backup is confirmed
+func New(ctx context.Context, rpcAddress, rpcCert string, noTLS bool, locks *walletcore.OutputLocks, labels *walletcore.Labels,
+	hiddenAccounts *walletcore.HiddenAccounts, seedBackup *walletcore.SeedBackup) (*WalletRPCClient, error) {
 	// check if user has provided enough information to attempt connecting to dcrwallet
 	if rpcAddress == "" {
 		return nil, errors.New("you must set walletrpcserver in config file to use wallet rpc")
@@ -68,6 +99,12 @@ func New(ctx context.Context, rpcAddress, rpcCert string, noTLS bool) (*WalletRP
 			walletLoader:  walletrpc.NewWalletLoaderServiceClient(connectionResult.conn),
 			walletService: walletService,
 			activeNet:     activeNet,
+			locks:         locks,
+			labels:        labels,
+
+			hiddenAccounts:  hiddenAccounts,
+			seedBackup:      seedBackup,
+			messageVerifier: walletrpc.NewMessageVerificationServiceClient(connectionResult.conn),
 		}
 
 		return client, nil
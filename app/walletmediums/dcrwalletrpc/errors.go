@@ -13,3 +13,15 @@ func isRpcErrorCode(err error, code codes.Code) bool {
 	e, ok := status.FromError(err)
 	return ok && e.Code() == code
 }
+
+// isWatchingOnlyError checks if err was returned by dcrwallet because the wallet has no private keys.
+// dcrwallet returns the Unimplemented code for errors of the WatchingOnly kind
+func isWatchingOnlyError(err error) bool {
+	return isRpcErrorCode(err, codes.Unimplemented)
+}
+
+// isInvalidPassphraseError checks if err was returned by dcrwallet because the wrong passphrase was used.
+// dcrwallet returns the InvalidArgument code for errors of the Passphrase kind
+func isInvalidPassphraseError(err error) bool {
+	return isRpcErrorCode(err, codes.InvalidArgument)
+}
@@ -0,0 +1,115 @@
+package dcrwalletrpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrwallet/rpc/walletrpc"
+	"github.com/raedahgroup/godcr/app"
+)
+
+func (c *WalletRPCClient) Subscribe(subscriberCtx context.Context) (<-chan app.WalletEvent, error) {
+	if !c.IsWalletOpen() {
+		return nil, fmt.Errorf("wallet is not open")
+	}
+
+	// a single stream of each notification type is shared by all subscribers
+	// and runs for as long as the dcrwallet connection is alive
+	c.notificationsMu.Lock()
+	defer c.notificationsMu.Unlock()
+
+	ctx := context.Background()
+	if !c.txNotificationsStarted {
+		stream, err := c.walletService.TransactionNotifications(ctx, &walletrpc.TransactionNotificationsRequest{})
+		if err != nil {
+			return nil, fmt.Errorf("error subscribing to dcrwallet transaction notifications: %s", err.Error())
+		}
+		go func() {
+			c.events.BroadcastTicketStatusChanges(ctx, c)
+			c.streamTransactionNotifications(stream)
+		}()
+		c.txNotificationsStarted = true
+	}
+	if !c.accountNotificationsStarted {
+		stream, err := c.walletService.AccountNotifications(ctx, &walletrpc.AccountNotificationsRequest{})
+		if err != nil {
+			return nil, fmt.Errorf("error subscribing to dcrwallet account notifications: %s", err.Error())
+		}
+		go c.streamAccountNotifications(stream)
+		c.accountNotificationsStarted = true
+	}
+
+	return c.events.Subscribe(subscriberCtx), nil
+}
+
+func (c *WalletRPCClient) streamTransactionNotifications(stream walletrpc.WalletService_TransactionNotificationsClient) {
+	for {
+		notification, err := stream.Recv()
+		if err != nil {
+			// allow the next subscriber to restart the stream
+			c.notificationsMu.Lock()
+			c.txNotificationsStarted = false
+			c.notificationsMu.Unlock()
+			return
+		}
+
+		for _, tx := range notification.UnminedTransactions {
+			c.broadcastTransaction(tx.Hash, false)
+		}
+
+		for _, block := range notification.AttachedBlocks {
+			for _, tx := range block.Transactions {
+				c.broadcastTransaction(tx.Hash, true)
+			}
+
+			var blockHash string
+			if hash, err := chainhash.NewHash(block.Hash); err == nil {
+				blockHash = hash.String()
+			}
+			c.events.BroadcastNewBlock(app.NewBlockEvent{
+				Height:    block.Height,
+				Hash:      blockHash,
+				Timestamp: block.Timestamp,
+			})
+		}
+
+		// tickets become live, are missed or expire as blocks are mined, without a wallet transaction
+		if len(notification.AttachedBlocks) > 0 {
+			c.events.BroadcastTicketStatusChanges(context.Background(), c)
+		}
+	}
+}
+
+func (c *WalletRPCClient) streamAccountNotifications(stream walletrpc.WalletService_AccountNotificationsClient) {
+	for {
+		notification, err := stream.Recv()
+		if err != nil {
+			c.notificationsMu.Lock()
+			c.accountNotificationsStarted = false
+			c.notificationsMu.Unlock()
+			return
+		}
+
+		c.events.Broadcast(app.AccountEvent{
+			AccountNumber:    notification.AccountNumber,
+			AccountName:      notification.AccountName,
+			ExternalKeyCount: notification.ExternalKeyCount,
+			InternalKeyCount: notification.InternalKeyCount,
+			ImportedKeyCount: notification.ImportedKeyCount,
+		})
+	}
+}
+
+func (c *WalletRPCClient) broadcastTransaction(txHash []byte, mined bool) {
+	hash, err := chainhash.NewHash(txHash)
+	if err != nil {
+		return
+	}
+
+	txDetails, err := c.GetTransaction(hash.String())
+	if err != nil {
+		return
+	}
+	c.events.BroadcastTransaction(txDetails, mined)
+}
@@ -3,6 +3,7 @@ package dcrwalletrpc
 import (
 	"context"
 	"fmt"
+	"io"
 	"math"
 	"time"
 
@@ -24,6 +25,79 @@ func (c *WalletRPCClient) unspentOutputStream(account uint32, targetAmount int64
 	return c.walletService.UnspentOutputs(context.Background(), req)
 }
 
+// constructTransaction creates an unsigned transaction paying `destinations` from `sourceAccount`
+// Inputs are selected by dcrwallet and change is sent back to `sourceAccount`
+func (c *WalletRPCClient) constructTransaction(sourceAccount uint32, requiredConfirmations int32, destinations []txhelper.TransactionDestination) ([]byte, error) {
+	// construct non-change outputs for all recipients
+	outputs := make([]*walletrpc.ConstructTransactionRequest_Output, len(destinations))
+	for i, destination := range destinations {
+		amountInAtom, err := txhelper.AmountToAtom(destination.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		outputs[i] = &walletrpc.ConstructTransactionRequest_Output{
+			Destination: &walletrpc.ConstructTransactionRequest_OutputDestination{
+				Address: destination.Address,
+			},
+			Amount: amountInAtom,
+		}
+	}
+
+	// construct transaction
+	constructRequest := &walletrpc.ConstructTransactionRequest{
+		SourceAccount:         sourceAccount,
+		NonChangeOutputs:      outputs,
+		RequiredConfirmations: requiredConfirmations,
+	}
+
+	constructResponse, err := c.walletService.ConstructTransaction(context.Background(), constructRequest)
+	if err != nil {
+		return nil, fmt.Errorf("error constructing transaction: %s", err.Error())
+	}
+
+	return constructResponse.UnsignedTransaction, nil
+}
+
+// offlineTxInputs returns the unspent outputs in `account` as offline transaction inputs, mapped by output key
+func (c *WalletRPCClient) offlineTxInputs(account uint32, requiredConfirmations int32) (map[string]*walletcore.OfflineTxInput, error) {
+	utxoStream, err := c.unspentOutputStream(account, 0, requiredConfirmations)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make(map[string]*walletcore.OfflineTxInput)
+	for {
+		utxo, err := utxoStream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		hash, err := chainhash.NewHash(utxo.TransactionHash)
+		if err != nil {
+			return nil, err
+		}
+
+		address, err := walletcore.GetAddressFromPkScript(c.activeNet, utxo.PkScript)
+		if err != nil {
+			return nil, err
+		}
+
+		unspentOutput := &walletcore.UnspentOutput{
+			OutputKey: fmt.Sprintf("%s:%d", hash.String(), utxo.OutputIndex),
+			Tree:      utxo.Tree,
+			Amount:    dcrutil.Amount(utxo.Amount),
+			Address:   address,
+		}
+		inputs[unspentOutput.OutputKey] = walletcore.NewOfflineTxInput(unspentOutput, utxo.PkScript)
+	}
+
+	return inputs, nil
+}
+
 func (c *WalletRPCClient) signAndPublishTransaction(serializedTx []byte, passphrase string) (string, error) {
 	ctx := context.Background()
 
@@ -34,7 +108,10 @@ func (c *WalletRPCClient) signAndPublishTransaction(serializedTx []byte, passphr
 	}
 
 	signResponse, err := c.walletService.SignTransaction(ctx, signRequest)
-	if err != nil {
+	if isWatchingOnlyError(err) {
+		c.setWatchingOnly()
+		return "", walletcore.ErrWatchingOnlyWallet
+	} else if err != nil {
 		return "", fmt.Errorf("error signing transaction: %s", err.Error())
 	}
 
@@ -94,6 +171,15 @@ func processTransaction(txDetail *walletrpc.TransactionDetails) (*walletcore.Tra
 		FormattedTime: time.Unix(txDetail.Timestamp, 0).Format("Mon Jan 2, 2006 3:04PM"),
 		Size:          txSize,
 	}
+
+	// the accounts that the transaction spends from or pays to are used for filtering history by account
+	// and for calculating running account balances
+	for _, debit := range txDetail.Debits {
+		tx.AddAccountChange(debit.PreviousAccount, -dcrutil.Amount(debit.PreviousAmount))
+	}
+	for _, credit := range txDetail.Credits {
+		tx.AddAccountChange(credit.Account, dcrutil.Amount(credit.Amount))
+	}
 	return tx, nil
 }
 
@@ -3,9 +3,10 @@ package dcrwalletrpc
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
 	"io"
-	"sort"
+	"strings"
 
 	"github.com/decred/dcrd/chaincfg/chainhash"
 	"github.com/decred/dcrd/dcrutil"
@@ -52,9 +53,35 @@ func (c *WalletRPCClient) AccountsOverview(requiredConfirmations int32) ([]*wall
 			return nil, err
 		}
 
-		// skip zero-balance imported accounts
-		if acc.AccountName == "imported" && balance.Total == 0 {
-			continue
+		account := &walletcore.Account{
+			Name:    acc.AccountName,
+			Number:  acc.AccountNumber,
+			Balance: balance,
+		}
+		accountsOverview = append(accountsOverview, account)
+	}
+
+	return c.hiddenAccounts.VisibleAccounts(accountsOverview), nil
+}
+
+func (c *WalletRPCClient) AccountDetails(requiredConfirmations int32) ([]*walletcore.AccountDetails, error) {
+	ctx := context.Background()
+	accounts, err := c.walletService.Accounts(ctx, &walletrpc.AccountsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching accounts: %s", err.Error())
+	}
+
+	// the derivation path is left empty if dcrwallet does not report the coin type
+	var coinType *uint32
+	if coinTypeResponse, err := c.walletService.CoinType(ctx, &walletrpc.CoinTypeRequest{}); err == nil {
+		coinType = &coinTypeResponse.CoinType
+	}
+
+	accountDetails := make([]*walletcore.AccountDetails, 0, len(accounts.Accounts))
+	for _, acc := range accounts.Accounts {
+		balance, err := c.AccountBalance(acc.AccountNumber, requiredConfirmations)
+		if err != nil {
+			return nil, err
 		}
 
 		account := &walletcore.Account{
@@ -62,20 +89,54 @@ func (c *WalletRPCClient) AccountsOverview(requiredConfirmations int32) ([]*wall
 			Number:  acc.AccountNumber,
 			Balance: balance,
 		}
-		accountsOverview = append(accountsOverview, account)
+		details := &walletcore.AccountDetails{
+			Account:          account,
+			Hidden:           c.hiddenAccounts.IsHidden(account),
+			ExternalKeyCount: acc.ExternalKeyCount,
+			InternalKeyCount: acc.InternalKeyCount,
+			ImportedKeyCount: acc.ImportedKeyCount,
+		}
+		if coinType != nil {
+			details.DerivationPath = walletcore.AccountDerivationPath(*coinType, acc.AccountNumber)
+		}
+		accountDetails = append(accountDetails, details)
+	}
+
+	return accountDetails, nil
+}
+
+func (c *WalletRPCClient) RenameAccount(accountNumber uint32, newName string) error {
+	req := &walletrpc.RenameAccountRequest{
+		AccountNumber: accountNumber,
+		NewName:       newName,
+	}
+
+	// dcrwallet sends an account notification for the new name, which is broadcast to event subscribers
+	if _, err := c.walletService.RenameAccount(context.Background(), req); err != nil {
+		return fmt.Errorf("error renaming account: %s", err.Error())
 	}
+	return nil
+}
 
-	return accountsOverview, nil
+func (c *WalletRPCClient) HideAccount(accountNumber uint32, hide bool) error {
+	return c.hiddenAccounts.SetHidden(accountNumber, hide)
 }
 
 func (c *WalletRPCClient) NextAccount(accountName string, passphrase string) (uint32, error) {
+	if c.IsWatchingOnlyWallet() {
+		return 0, walletcore.ErrWatchingOnlyWallet
+	}
+
 	req := &walletrpc.NextAccountRequest{
 		AccountName: accountName,
 		Passphrase:  []byte(passphrase),
 	}
 
 	nextAccount, err := c.walletService.NextAccount(context.Background(), req)
-	if err != nil {
+	if isWatchingOnlyError(err) {
+		c.setWatchingOnly()
+		return 0, walletcore.ErrWatchingOnlyWallet
+	} else if err != nil {
 		return 0, err
 	}
 
@@ -173,6 +234,22 @@ func (c *WalletRPCClient) GenerateNewAddress(account uint32) (string, error) {
 	return nextAddress.Address, nil
 }
 
+// ChangeAddress uses GAP_POLICY_WRAP like GenerateNewAddress, but derives the address from the internal branch of the account
+func (c *WalletRPCClient) ChangeAddress(account uint32) (string, error) {
+	req := &walletrpc.NextAddressRequest{
+		Account:   account,
+		GapPolicy: walletrpc.NextAddressRequest_GAP_POLICY_WRAP,
+		Kind:      walletrpc.NextAddressRequest_BIP0044_INTERNAL,
+	}
+
+	nextAddress, err := c.walletService.NextAddress(context.Background(), req)
+	if err != nil {
+		return "", fmt.Errorf("error generating change address: %s", err.Error())
+	}
+
+	return nextAddress.Address, nil
+}
+
 func (c *WalletRPCClient) UnspentOutputs(account uint32, targetAmount int64, requiredConfirmations int32) ([]*walletcore.UnspentOutput, error) {
 	utxoStream, err := c.unspentOutputStream(account, targetAmount, requiredConfirmations)
 	if err != nil {
@@ -219,42 +296,44 @@ func (c *WalletRPCClient) UnspentOutputs(account uint32, targetAmount int64, req
 		unspentOutputs = append(unspentOutputs, unspentOutput)
 	}
 
+	c.locks.MarkLocked(unspentOutputs)
+	c.labels.MarkUnspentOutputs(unspentOutputs)
 	return unspentOutputs, nil
 }
 
-func (c *WalletRPCClient) SendFromAccount(sourceAccount uint32, requiredConfirmations int32, destinations []txhelper.TransactionDestination, passphrase string) (string, error) {
-	// construct non-change outputs for all recipients
-	outputs := make([]*walletrpc.ConstructTransactionRequest_Output, len(destinations))
-	for i, destination := range destinations {
-		amountInAtom, err := txhelper.AmountToAtom(destination.Amount)
-		if err != nil {
-			return "", err
-		}
+func (c *WalletRPCClient) LockUnspentOutputs(outputKeys []string) error {
+	return c.locks.Lock(outputKeys)
+}
 
-		outputs[i] = &walletrpc.ConstructTransactionRequest_Output{
-			Destination: &walletrpc.ConstructTransactionRequest_OutputDestination{
-				Address: destination.Address,
-			},
-			Amount: amountInAtom,
-		}
-	}
+func (c *WalletRPCClient) UnlockUnspentOutputs(outputKeys []string) error {
+	return c.locks.Unlock(outputKeys)
+}
 
-	// construct transaction
-	constructRequest := &walletrpc.ConstructTransactionRequest{
-		SourceAccount:         sourceAccount,
-		NonChangeOutputs:      outputs,
-		RequiredConfirmations: requiredConfirmations,
-	}
+func (c *WalletRPCClient) LockedUnspentOutputs() ([]string, error) {
+	return c.locks.Keys(), nil
+}
 
-	constructResponse, err := c.walletService.ConstructTransaction(context.Background(), constructRequest)
-	if err != nil {
-		return "", fmt.Errorf("error constructing transaction: %s", err.Error())
-	}
+func (c *WalletRPCClient) SetLabel(labelType, ref, label string) error {
+	return c.labels.Set(&walletcore.Label{Type: labelType, Ref: ref, Label: label})
+}
 
-	return c.signAndPublishTransaction(constructResponse.UnsignedTransaction, passphrase)
+func (c *WalletRPCClient) Labels() ([]*walletcore.Label, error) {
+	return c.labels.All(), nil
+}
+
+func (c *WalletRPCClient) ImportLabels(labels []*walletcore.Label) error {
+	return c.labels.Import(labels)
 }
 
 func (c *WalletRPCClient) SendFromUTXOs(sourceAccount uint32, requiredConfirmations int32, utxoKeys []string, txDestinations []txhelper.TransactionDestination, changeDestinations []txhelper.TransactionDestination, passphrase string) (string, error) {
+	if c.IsWatchingOnlyWallet() {
+		return "", walletcore.ErrWatchingOnlyWallet
+	}
+
+	if err := c.locks.CheckUnlocked(utxoKeys); err != nil {
+		return "", err
+	}
+
 	// fetch all utxos in account to extract details for the utxos selected by user
 	// passing 0 as targetAmount to c.unspentOutputStream fetches ALL utxos in account
 	utxoStream, err := c.unspentOutputStream(sourceAccount, 0, requiredConfirmations)
@@ -314,10 +393,230 @@ func (c *WalletRPCClient) SendFromUTXOs(sourceAccount uint32, requiredConfirmati
 	return c.signAndPublishTransaction(txBuf.Bytes(), passphrase)
 }
 
-func (c *WalletRPCClient) TransactionHistory() ([]*walletcore.Transaction, error) {
-	req := &walletrpc.GetTransactionsRequest{}
+func (c *WalletRPCClient) EstimateTransaction(sourceAccount uint32, requiredConfirmations int32, destinations []txhelper.TransactionDestination,
+	utxoKeys []string, coinSelection string, feeRate dcrutil.Amount) (*walletcore.TransactionEstimate, error) {
+
+	// fetch all utxos in account, the inputs for the transaction are selected from them
+	utxos, err := c.UnspentOutputs(sourceAccount, 0, requiredConfirmations)
+	if err != nil {
+		return nil, err
+	}
+
+	return walletcore.NewTransactionEstimate(utxos, sourceAccount, requiredConfirmations, destinations, utxoKeys, coinSelection, feeRate)
+}
+
+func (c *WalletRPCClient) EstimateSweepTransaction(sourceAccount uint32, requiredConfirmations int32, destinationAddress string,
+	utxoKeys []string, feeRate dcrutil.Amount) (*walletcore.TransactionEstimate, error) {
+
+	utxos, err := c.UnspentOutputs(sourceAccount, 0, requiredConfirmations)
+	if err != nil {
+		return nil, err
+	}
+
+	return walletcore.NewSweepEstimate(utxos, sourceAccount, requiredConfirmations, destinationAddress, utxoKeys, feeRate)
+}
+
+func (c *WalletRPCClient) CreateUnsignedTransaction(sourceAccount uint32, requiredConfirmations int32, destinations []txhelper.TransactionDestination,
+	utxoKeys []string, changeDestinations []txhelper.TransactionDestination) (*walletcore.OfflineTransaction, error) {
+
+	accountInputs, err := c.offlineTxInputs(sourceAccount, requiredConfirmations)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(utxoKeys) == 0 && c.locks.HasLocks() {
+		// dcrwallet would select locked outputs, select the inputs here instead
+		utxoKeys, changeDestinations, err = walletcore.InputsExcludingLocked(c, sourceAccount, requiredConfirmations, destinations, walletcore.DefaultCoinSelection, walletcore.DefaultFeeRate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var unsignedTx *wire.MsgTx
+	if len(utxoKeys) == 0 {
+		// let dcrwallet select inputs and create the change output
+		serializedTx, err := c.constructTransaction(sourceAccount, requiredConfirmations, destinations)
+		if err != nil {
+			return nil, err
+		}
+
+		unsignedTx = &wire.MsgTx{}
+		if err = unsignedTx.Deserialize(bytes.NewReader(serializedTx)); err != nil {
+			return nil, fmt.Errorf("error decoding constructed transaction: %s", err.Error())
+		}
+	} else {
+		if err = c.locks.CheckUnlocked(utxoKeys); err != nil {
+			return nil, err
+		}
+
+		txInputs := make([]*wire.TxIn, len(utxoKeys))
+		for i, key := range utxoKeys {
+			input, ok := accountInputs[key]
+			if !ok {
+				return nil, fmt.Errorf("unspent output %s not found in account", key)
+			}
+			if txInputs[i], err = input.TxIn(); err != nil {
+				return nil, err
+			}
+		}
+
+		unsignedTx, err = txhelper.NewUnsignedTx(txInputs, destinations, changeDestinations)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	inputs := make([]*walletcore.OfflineTxInput, len(unsignedTx.TxIn))
+	for i, txIn := range unsignedTx.TxIn {
+		key := fmt.Sprintf("%s:%d", txIn.PreviousOutPoint.Hash.String(), txIn.PreviousOutPoint.Index)
+		input, ok := accountInputs[key]
+		if !ok {
+			return nil, fmt.Errorf("unspent output %s not found in account", key)
+		}
+		inputs[i] = input
+	}
+
+	return walletcore.NewOfflineTransaction(c.NetType(), sourceAccount, unsignedTx, inputs)
+}
+
+func (c *WalletRPCClient) SignTransaction(unsignedTx *walletcore.OfflineTransaction, passphrase string) (*walletcore.OfflineTransaction, error) {
+	if c.IsWatchingOnlyWallet() {
+		return nil, walletcore.ErrWatchingOnlyWallet
+	}
+
+	serializedTx, err := unsignedTx.SerializedTx()
+	if err != nil {
+		return nil, fmt.Errorf("error decoding transaction: %s", err.Error())
+	}
+
+	// pass the scripts of the outputs being spent, the signing wallet may not have seen them if it is not synced
+	additionalScripts := make([]*walletrpc.SignTransactionRequest_AdditionalScript, len(unsignedTx.Inputs))
+	for i, input := range unsignedTx.Inputs {
+		txIn, err := input.TxIn()
+		if err != nil {
+			return nil, err
+		}
+		pkScript, err := input.PkScriptBytes()
+		if err != nil {
+			return nil, err
+		}
+
+		additionalScripts[i] = &walletrpc.SignTransactionRequest_AdditionalScript{
+			TransactionHash: txIn.PreviousOutPoint.Hash[:],
+			OutputIndex:     txIn.PreviousOutPoint.Index,
+			Tree:            int32(txIn.PreviousOutPoint.Tree),
+			PkScript:        pkScript,
+		}
+	}
+
+	signResponse, err := c.walletService.SignTransaction(context.Background(), &walletrpc.SignTransactionRequest{
+		Passphrase:            []byte(passphrase),
+		SerializedTransaction: serializedTx,
+		AdditionalScripts:     additionalScripts,
+	})
+	if isWatchingOnlyError(err) {
+		c.setWatchingOnly()
+		return nil, walletcore.ErrWatchingOnlyWallet
+	} else if err != nil {
+		return nil, fmt.Errorf("error signing transaction: %s", err.Error())
+	}
+	if len(signResponse.UnsignedInputIndexes) > 0 {
+		return nil, fmt.Errorf("could not sign transaction inputs %v, the outputs they spend may not belong to this wallet",
+			signResponse.UnsignedInputIndexes)
+	}
+
+	var signedTx wire.MsgTx
+	if err = signedTx.Deserialize(bytes.NewReader(signResponse.Transaction)); err != nil {
+		return nil, fmt.Errorf("error decoding signed transaction: %s", err.Error())
+	}
+
+	return unsignedTx.Signed(&signedTx)
+}
+
+func (c *WalletRPCClient) PublishTransaction(signedTx *walletcore.OfflineTransaction) (string, error) {
+	serializedTx, err := signedTx.SerializedTx()
+	if err != nil {
+		return "", fmt.Errorf("error decoding transaction: %s", err.Error())
+	}
+
+	publishResponse, err := c.walletService.PublishTransaction(context.Background(), &walletrpc.PublishTransactionRequest{
+		SignedTransaction: serializedTx,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error publishing transaction: %s", err.Error())
+	}
+
+	transactionHash, err := chainhash.NewHash(publishResponse.TransactionHash)
+	if err != nil {
+		return "", fmt.Errorf("error parsing successful transaction hash: %s", err.Error())
+	}
+
+	return transactionHash.String(), nil
+}
+
+func (c *WalletRPCClient) SignMessage(address, message, passphrase string) (string, error) {
+	if c.IsWatchingOnlyWallet() {
+		return "", walletcore.ErrWatchingOnlyWallet
+	}
+
+	signResponse, err := c.walletService.SignMessage(context.Background(), &walletrpc.SignMessageRequest{
+		Address:    address,
+		Message:    message,
+		Passphrase: []byte(passphrase),
+	})
+	if isWatchingOnlyError(err) {
+		c.setWatchingOnly()
+		return "", walletcore.ErrWatchingOnlyWallet
+	} else if err != nil {
+		return "", fmt.Errorf("error signing message: %s", err.Error())
+	}
+
+	return base64.StdEncoding.EncodeToString(signResponse.Signature), nil
+}
+
+func (c *WalletRPCClient) VerifyMessage(address, message, signature string) (bool, error) {
+	signatureBytes, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false, fmt.Errorf("invalid signature, signatures are base64-encoded: %s", err.Error())
+	}
 
-	stream, err := c.walletService.GetTransactions(context.Background(), req)
+	verifyResponse, err := c.messageVerifier.VerifyMessage(context.Background(), &walletrpc.VerifyMessageRequest{
+		Address:   address,
+		Message:   message,
+		Signature: signatureBytes,
+	})
+	if err != nil {
+		return false, fmt.Errorf("error verifying message: %s", err.Error())
+	}
+	return verifyResponse.Valid, nil
+}
+
+func (c *WalletRPCClient) TransactionHistory(query *walletcore.TransactionHistoryQuery) ([]*walletcore.Transaction, error) {
+	ctx := context.Background()
+
+	// transactions are read newest first, from unmined transactions down to the first block,
+	// so dcrwallet can stop reading once it has sent the newest transactions that the query pages
+	req := &walletrpc.GetTransactionsRequest{
+		StartingBlockHeight:    -1,
+		EndingBlockHeight:      1,
+		TargetTransactionCount: int32(query.ScanLimit()),
+	}
+
+	if query != nil && (query.StartTime > 0 || query.EndTime > 0) {
+		startHeight, endHeight, err := c.historyBlockRange(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		if startHeight > 0 {
+			req.EndingBlockHeight = startHeight
+		}
+		if endHeight > 0 {
+			// unmined transactions are excluded when reading from a mined block
+			req.StartingBlockHeight = endHeight
+		}
+	}
+
+	stream, err := c.walletService.GetTransactions(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -349,14 +648,27 @@ func (c *WalletRPCClient) TransactionHistory() ([]*walletcore.Transaction, error
 		transactions = append(transactions, txs...)
 	}
 
-	// sort transactions by date (list newer first)
-	sort.SliceStable(transactions, func(i1, i2 int) bool {
-		return transactions[i1].Timestamp > transactions[i2].Timestamp
-	})
-
+	transactions = query.Apply(transactions)
+	c.labels.MarkTransactions(transactions)
 	return transactions, nil
 }
 
+// historyBlockRange returns the range of block heights that hold the transactions in the date range of `query`
+func (c *WalletRPCClient) historyBlockRange(ctx context.Context, query *walletcore.TransactionHistoryQuery) (startHeight, endHeight int32, err error) {
+	bestBlock, err := c.walletService.BestBlock(ctx, &walletrpc.BestBlockRequest{})
+	if err != nil {
+		return 0, 0, fmt.Errorf("error reading best block: %s", err.Error())
+	}
+
+	return query.BlockRange(int32(bestBlock.Height), func(height int32) (int64, error) {
+		blockInfo, err := c.walletService.BlockInfo(ctx, &walletrpc.BlockInfoRequest{BlockHeight: height})
+		if err != nil {
+			return 0, err
+		}
+		return blockInfo.Timestamp, nil
+	})
+}
+
 func (c *WalletRPCClient) GetTransaction(transactionHash string) (*walletcore.TransactionDetails, error) {
 	ctx := context.Background()
 	hash, err := chainhash.NewHashFromStr(transactionHash)
@@ -390,13 +702,15 @@ func (c *WalletRPCClient) GetTransaction(transactionHash string) (*walletcore.Tr
 		}
 	}
 
-	return &walletcore.TransactionDetails{
+	txDetails := &walletcore.TransactionDetails{
 		BlockHeight:   blockHeight,
 		Confirmations: getTxResponse.GetConfirmations(),
 		Transaction:   transaction,
 		Inputs:        decodedTx.Inputs,
 		Outputs:       decodedTx.Outputs,
-	}, nil
+	}
+	c.labels.MarkTransactionDetails(txDetails)
+	return txDetails, nil
 }
 
 func (c *WalletRPCClient) StakeInfo(ctx context.Context) (*walletcore.StakeInfo, error) {
@@ -420,7 +734,103 @@ func (c *WalletRPCClient) StakeInfo(ctx context.Context) (*walletcore.StakeInfo,
 	}, nil
 }
 
+func (c *WalletRPCClient) Tickets(ctx context.Context, filter *walletcore.TicketFilter) ([]*walletcore.Ticket, error) {
+	stream, err := c.walletService.GetTickets(ctx, &walletrpc.GetTicketsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting tickets: %s", err.Error())
+	}
+
+	var tickets []*walletcore.Ticket
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error getting tickets: %s", err.Error())
+		}
+
+		ticket, err := ticketFromDetails(res.Ticket, res.Block)
+		if err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, ticket)
+	}
+
+	return filter.Apply(tickets), nil
+}
+
+// ticketFromDetails converts ticket details returned by dcrwallet to a walletcore ticket, `block` is nil for unmined tickets
+func ticketFromDetails(details *walletrpc.TicketDetails, block *walletrpc.GetTicketsResponse_BlockDetails) (*walletcore.Ticket, error) {
+	ticketHash, err := chainhash.NewHash(details.Ticket.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("error reading ticket hash: %s", err.Error())
+	}
+
+	var account uint32
+	if len(details.Ticket.Debits) > 0 {
+		account = details.Ticket.Debits[0].PreviousAccount
+	}
+
+	purchaseHeight := int32(-1)
+	if block != nil {
+		purchaseHeight = block.Height
+	}
+
+	var spenderHash string
+	var spenderTx []byte
+	if details.Spender != nil {
+		hash, err := chainhash.NewHash(details.Spender.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("error reading ticket spender hash: %s", err.Error())
+		}
+		spenderHash = hash.String()
+		spenderTx = details.Spender.Transaction
+	}
+
+	// dcrwallet ticket statuses are upper case names of the walletcore ticket statuses
+	status := strings.ToLower(details.TicketStatus.String())
+
+	return walletcore.NewTicket(ticketHash.String(), account, purchaseHeight, status, details.Ticket.Transaction, spenderHash, spenderTx)
+}
+
+func (c *WalletRPCClient) RevokeTickets(ctx context.Context, passphrase string) ([]*walletcore.RevokedTicket, error) {
+	if c.IsWatchingOnlyWallet() {
+		return nil, walletcore.ErrWatchingOnlyWallet
+	}
+	return walletcore.RevokeMissedAndExpiredTickets(ctx, c, passphrase)
+}
+
+// RevokeTicket creates the revocation itself, dcrwallet can only be asked to revoke all missed and expired tickets at once
+func (c *WalletRPCClient) RevokeTicket(ctx context.Context, ticketHash, passphrase string) (string, error) {
+	if c.IsWatchingOnlyWallet() {
+		return "", walletcore.ErrWatchingOnlyWallet
+	}
+	if err := walletcore.CheckTicketRevokable(ctx, c, ticketHash); err != nil {
+		return "", err
+	}
+
+	hash, err := chainhash.NewHashFromStr(ticketHash)
+	if err != nil {
+		return "", fmt.Errorf("invalid ticket hash: %s", err.Error())
+	}
+	ticket, err := c.walletService.GetTransaction(ctx, &walletrpc.GetTransactionRequest{TransactionHash: hash[:]})
+	if err != nil {
+		return "", fmt.Errorf("error fetching ticket: %s", err.Error())
+	}
+
+	revocation, err := walletcore.NewRevocationTx(ticket.GetTransaction().GetTransaction(), walletcore.DefaultFeeRate)
+	if err != nil {
+		return "", err
+	}
+	return c.signAndPublishTransaction(revocation, passphrase)
+}
+
 func (c *WalletRPCClient) PurchaseTickets(ctx context.Context, request dcrlibwallet.PurchaseTicketsRequest) ([]string, error) {
+	if c.IsWatchingOnlyWallet() {
+		return nil, walletcore.ErrWatchingOnlyWallet
+	}
+
 	ticketPrice, err := c.walletService.TicketPrice(ctx, &walletrpc.TicketPriceRequest{})
 	if err != nil {
 		return nil, fmt.Errorf("could not determine ticket price: %s", err.Error())
@@ -436,6 +846,11 @@ func (c *WalletRPCClient) PurchaseTickets(ctx context.Context, request dcrlibwal
 			balance.Spendable, dcrutil.Amount(ticketPrice.TicketPrice))
 	}
 
+	if c.locks.HasLocks() {
+		// dcrwallet would select locked outputs for the tickets, buy them with inputs selected here instead
+		return walletcore.PurchaseTicketsExcludingLocked(c, request, dcrutil.Amount(ticketPrice.TicketPrice), c.signAndPublishTransaction)
+	}
+
 	response, err := c.walletService.PurchaseTickets(ctx, &walletrpc.PurchaseTicketsRequest{
 		Account:               request.Account,
 		Expiry:                request.Expiry,
@@ -449,7 +864,10 @@ func (c *WalletRPCClient) PurchaseTickets(ctx context.Context, request dcrlibwal
 		TicketFee:             request.TicketFee,
 		TxFee:                 request.TxFee,
 	})
-	if err != nil {
+	if isWatchingOnlyError(err) {
+		c.setWatchingOnly()
+		return nil, walletcore.ErrWatchingOnlyWallet
+	} else if err != nil {
 		return nil, fmt.Errorf("could not complete ticket(s) purchase, encountered an error:\n%s", err.Error())
 	}
 	ticketHashes := make([]string, len(response.GetTicketHashes()))
@@ -462,3 +880,17 @@ func (c *WalletRPCClient) PurchaseTickets(ctx context.Context, request dcrlibwal
 	}
 	return ticketHashes, nil
 }
+
+func (c *WalletRPCClient) IsWatchingOnlyWallet() bool {
+	c.stateMu.RLock()
+	defer c.stateMu.RUnlock()
+	return c.watchingOnly
+}
+
+// setWatchingOnly records that the wallet has no private keys.
+// It may be called from concurrent wallet operations, so access to watchingOnly is guarded by stateMu
+func (c *WalletRPCClient) setWatchingOnly() {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	c.watchingOnly = true
+}
@@ -2,11 +2,14 @@ package dcrwalletrpc
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/decred/dcrd/hdkeychain"
 	"github.com/decred/dcrwallet/rpc/walletrpc"
 	"github.com/decred/dcrwallet/walletseed"
 	"github.com/raedahgroup/godcr/app"
+	"github.com/raedahgroup/godcr/app/walletcore"
 	"google.golang.org/grpc/codes"
 )
 
@@ -47,7 +50,43 @@ func (c *WalletRPCClient) CreateWallet(passphrase, seed string) error {
 
 	// wallet will be opened if the create operation was successful
 	if err == nil {
-		c.walletOpen = true
+		c.setWalletOpen(true)
+	}
+
+	return err
+}
+
+func (c *WalletRPCClient) SaveUnconfirmedSeed(seed, passphrase string) error {
+	return c.seedBackup.Save(seed, passphrase)
+}
+
+func (c *WalletRPCClient) HasUnconfirmedSeed() bool {
+	return c.seedBackup.Pending()
+}
+
+func (c *WalletRPCClient) UnconfirmedSeed(passphrase string) (string, error) {
+	return c.seedBackup.Seed(passphrase)
+}
+
+func (c *WalletRPCClient) ConfirmSeedBackup() error {
+	return c.seedBackup.Confirm()
+}
+
+func (c *WalletRPCClient) CreateWatchingOnlyWallet(extendedPublicKey string) error {
+	if err := walletcore.ValidateExtendedPublicKey(extendedPublicKey, c.NetType()); err != nil {
+		return err
+	}
+
+	// use default public passphrase, same as OpenWallet
+	_, err := c.walletLoader.CreateWatchingOnlyWallet(context.Background(), &walletrpc.CreateWatchingOnlyWalletRequest{
+		ExtendedPubKey:   strings.TrimSpace(extendedPublicKey),
+		PublicPassphrase: []byte(app.DefaultPublicPassphrase),
+	})
+
+	// wallet will be opened if the create operation was successful
+	if err == nil {
+		c.setWatchingOnly()
+		c.setWalletOpen(true)
 	}
 
 	return err
@@ -55,16 +94,67 @@ func (c *WalletRPCClient) CreateWallet(passphrase, seed string) error {
 
 // ignore wallet already open errors, it could be that dcrwallet loaded the wallet when it was launched by the user
 // or godcr opened the wallet without closing it
-func (c *WalletRPCClient) OpenWallet() (err error) {
-	defer func() {
-		c.walletOpen = err == nil
-	}()
+func (c *WalletRPCClient) OpenWallet(publicPassphrase string) error {
+	if publicPassphrase == "" {
+		publicPassphrase = app.DefaultPublicPassphrase
+	}
+	_, err := c.walletLoader.OpenWallet(context.Background(), &walletrpc.OpenWalletRequest{
+		PublicPassphrase: []byte(publicPassphrase),
+	})
+	if isInvalidPassphraseError(err) {
+		c.setWalletOpen(false)
+		return app.ErrInvalidPublicPassphrase
+	} else if err != nil && !isRpcErrorCode(err, codes.AlreadyExists) {
+		c.setWalletOpen(false)
+		return err
+	}
+
+	c.detectWatchingOnly()
+	c.setWalletOpen(true)
+	return nil
+}
 
-	_, err = c.walletLoader.OpenWallet(context.Background(), &walletrpc.OpenWalletRequest{})
-	if isRpcErrorCode(err, codes.AlreadyExists) {
-		err = nil
+// detectWatchingOnly records if the open wallet has no private keys.
+// dcrwallet does not report if a wallet is watching-only, but it unlocks the wallet before signing a message
+// and fails with the Unimplemented code if the wallet is watching-only. Any other error means the wallet has private keys
+func (c *WalletRPCClient) detectWatchingOnly() {
+	_, err := c.walletService.SignMessage(context.Background(), &walletrpc.SignMessageRequest{})
+	if isWatchingOnlyError(err) {
+		c.setWatchingOnly()
 	}
-	return
+}
+
+func (c *WalletRPCClient) ChangePrivatePassphrase(oldPassphrase, newPassphrase string) error {
+	if c.IsWatchingOnlyWallet() {
+		return walletcore.ErrWatchingOnlyWallet
+	}
+
+	_, err := c.walletService.ChangePassphrase(context.Background(), &walletrpc.ChangePassphraseRequest{
+		Key:           walletrpc.ChangePassphraseRequest_PRIVATE,
+		OldPassphrase: []byte(oldPassphrase),
+		NewPassphrase: []byte(newPassphrase),
+	})
+	if isWatchingOnlyError(err) {
+		c.setWatchingOnly()
+		return walletcore.ErrWatchingOnlyWallet
+	} else if err != nil {
+		return fmt.Errorf("error changing private passphrase: %s", err.Error())
+	}
+
+	// the unconfirmed seed is encrypted with the private passphrase
+	return c.seedBackup.ChangePassphrase(oldPassphrase, newPassphrase)
+}
+
+func (c *WalletRPCClient) ChangePublicPassphrase(oldPassphrase, newPassphrase string) error {
+	_, err := c.walletService.ChangePassphrase(context.Background(), &walletrpc.ChangePassphraseRequest{
+		Key:           walletrpc.ChangePassphraseRequest_PUBLIC,
+		OldPassphrase: []byte(oldPassphrase),
+		NewPassphrase: []byte(newPassphrase),
+	})
+	if err != nil {
+		return fmt.Errorf("error changing public passphrase: %s", err.Error())
+	}
+	return nil
 }
 
 // don't actually close dcrwallet
@@ -73,11 +163,19 @@ func (c *WalletRPCClient) OpenWallet() (err error) {
 func (c *WalletRPCClient) CloseWallet() {}
 
 func (c *WalletRPCClient) IsWalletOpen() bool {
-	// for now, assume that the wallet's already open since we're connecting through dcrwallet daemon
-	// ideally, we'd have to use dcrwallet's WalletLoaderService to do this
+	c.stateMu.RLock()
+	defer c.stateMu.RUnlock()
 	return c.walletOpen
 }
 
+// setWalletOpen records if the wallet was opened by godcr or was already open in dcrwallet.
+// It is called from wallet operations while the open state is read by other goroutines, so access is guarded by stateMu
+func (c *WalletRPCClient) setWalletOpen(open bool) {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	c.walletOpen = open
+}
+
 func (c *WalletRPCClient) SyncBlockChain(listener *app.BlockChainSyncListener, showLog bool) error {
 	ctx := context.Background()
 
@@ -108,7 +206,8 @@ func (c *WalletRPCClient) SyncBlockChain(listener *app.BlockChainSyncListener, s
 	}
 
 	s := &spvSync{
-		listener:  listener,
+		// broadcast sync progress to event subscribers
+		listener:  c.events.SyncListener(listener),
 		netType:   c.NetType(),
 		client:    syncStream,
 		bestBlock: int64(bestBlock.Height),
@@ -0,0 +1,246 @@
+package walletmediums
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/raedahgroup/godcr/app"
+	"github.com/raedahgroup/godcr/app/walletcore"
+)
+
+// eventBufferSize is the number of events that can be queued for a subscriber before further events are dropped.
+// Dropped events are counted and reported to the subscriber with an app.DroppedEvent
+const eventBufferSize = 100
+
+// EventBroadcaster sends wallet events to all subscribers. The zero value is ready for use.
+// Wallet mediums use it to implement `WalletMiddleware.Subscribe`
+type EventBroadcaster struct {
+	mu sync.Mutex
+
+	// number of events dropped for each subscriber since a drop was last reported to the subscriber
+	subscribers map[chan app.WalletEvent]int
+
+	// block heights of mined transactions that have less than app.ConfirmationsTracked confirmations
+	confirmingTxs map[string]int32
+
+	// statuses of the wallet's tickets that were not voted or revoked when tickets were last read by BroadcastTicketStatusChanges
+	ticketStatuses map[string]string
+}
+
+// Subscribe registers a new subscriber and returns the channel on which events will be sent to the subscriber
+// The channel is closed and the subscriber removed when ctx is canceled
+func (broadcaster *EventBroadcaster) Subscribe(ctx context.Context) <-chan app.WalletEvent {
+	events := make(chan app.WalletEvent, eventBufferSize)
+
+	broadcaster.mu.Lock()
+	if broadcaster.subscribers == nil {
+		broadcaster.subscribers = make(map[chan app.WalletEvent]int)
+	}
+	broadcaster.subscribers[events] = 0
+	broadcaster.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		broadcaster.mu.Lock()
+		delete(broadcaster.subscribers, events)
+		close(events)
+		broadcaster.mu.Unlock()
+	}()
+
+	return events
+}
+
+// Broadcast sends `event` to all subscribers without blocking
+// The event is dropped for subscribers whose channel buffer is full and the drop is reported when the subscriber catches up
+func (broadcaster *EventBroadcaster) Broadcast(event app.WalletEvent) {
+	broadcaster.mu.Lock()
+	defer broadcaster.mu.Unlock()
+	broadcaster.broadcast(event)
+}
+
+// broadcast must be called with broadcaster.mu held
+func (broadcaster *EventBroadcaster) broadcast(event app.WalletEvent) {
+	for events, dropped := range broadcaster.subscribers {
+		if dropped > 0 {
+			select {
+			case events <- app.DroppedEvent{Count: dropped}:
+				dropped = 0
+			default:
+				broadcaster.subscribers[events] = dropped + 1
+				continue
+			}
+		}
+
+		select {
+		case events <- event:
+		default:
+			dropped++
+		}
+		broadcaster.subscribers[events] = dropped
+	}
+}
+
+// SyncListener returns a sync listener that broadcasts sync progress events before calling the functions of `listener`
+func (broadcaster *EventBroadcaster) SyncListener(listener *app.BlockChainSyncListener) *app.BlockChainSyncListener {
+	return &app.BlockChainSyncListener{
+		SyncStarted: func() {
+			broadcaster.Broadcast(app.SyncProgressEvent{Stage: app.SyncStageStarted})
+			listener.SyncStarted()
+		},
+		SyncEnded: func(err error) {
+			event := app.SyncProgressEvent{Stage: app.SyncStageFinished, Progress: 100}
+			if err != nil {
+				event.Error = err.Error()
+			}
+			broadcaster.Broadcast(event)
+			listener.SyncEnded(err)
+		},
+		OnHeadersFetched: func(percentageProgress int64) {
+			broadcaster.Broadcast(app.SyncProgressEvent{Stage: app.SyncStageFetchingHeaders, Progress: percentageProgress})
+			listener.OnHeadersFetched(percentageProgress)
+		},
+		OnDiscoveredAddress: func(state string) {
+			broadcaster.Broadcast(app.SyncProgressEvent{Stage: app.SyncStageDiscoveringAddress})
+			listener.OnDiscoveredAddress(state)
+		},
+		OnRescanningBlocks: func(percentageProgress int64) {
+			broadcaster.Broadcast(app.SyncProgressEvent{Stage: app.SyncStageRescanningBlocks, Progress: percentageProgress})
+			listener.OnRescanningBlocks(percentageProgress)
+		},
+	}
+}
+
+// BroadcastTransaction broadcasts a transaction event for `tx`
+// and a ticket status event if `tx` is a ticket purchase, vote or revocation
+func (broadcaster *EventBroadcaster) BroadcastTransaction(tx *walletcore.TransactionDetails, mined bool) {
+	event := app.TransactionEvent{
+		Transaction: tx.Transaction,
+		Mined:       mined,
+	}
+	if mined {
+		event.BlockHeight = tx.BlockHeight
+	}
+	broadcaster.Broadcast(event)
+
+	if mined && tx.BlockHeight > 0 {
+		broadcaster.mu.Lock()
+		if broadcaster.confirmingTxs == nil {
+			broadcaster.confirmingTxs = make(map[string]int32)
+		}
+		broadcaster.confirmingTxs[tx.Hash] = tx.BlockHeight
+		broadcaster.broadcast(app.ConfirmationEvent{
+			TransactionHash: tx.Hash,
+			BlockHeight:     tx.BlockHeight,
+			Confirmations:   1,
+		})
+		broadcaster.mu.Unlock()
+	}
+
+	if ticketEvent := ticketStatusEvent(tx, mined); ticketEvent != nil {
+		broadcaster.Broadcast(*ticketEvent)
+	}
+}
+
+// BroadcastNewBlock broadcasts `block` followed by confirmation events for mined wallet transactions
+// that have less than app.ConfirmationsTracked confirmations at the new block height
+func (broadcaster *EventBroadcaster) BroadcastNewBlock(block app.NewBlockEvent) {
+	broadcaster.mu.Lock()
+	defer broadcaster.mu.Unlock()
+
+	broadcaster.broadcast(block)
+
+	for txHash, txBlockHeight := range broadcaster.confirmingTxs {
+		confirmations := block.Height - txBlockHeight + 1
+		if confirmations > 1 {
+			broadcaster.broadcast(app.ConfirmationEvent{
+				TransactionHash: txHash,
+				BlockHeight:     txBlockHeight,
+				Confirmations:   confirmations,
+			})
+		}
+		if confirmations >= app.ConfirmationsTracked {
+			delete(broadcaster.confirmingTxs, txHash)
+		}
+	}
+}
+
+// ticketStatusesWithoutTx are the ticket statuses that tickets change to as blocks are mined, without a wallet transaction
+var ticketStatusesWithoutTx = map[string]bool{
+	walletcore.TicketStatusLive:    true,
+	walletcore.TicketStatusMissed:  true,
+	walletcore.TicketStatusExpired: true,
+}
+
+// BroadcastTicketStatusChanges reads the wallet's tickets that are not voted or revoked and broadcasts a ticket status event
+// for every ticket that became live, missed or expired since the tickets were last read. Other ticket status changes are
+// broadcast by BroadcastTransaction. Mediums call this once when they start receiving wallet notifications, to read
+// the initial ticket statuses, then for every new block
+func (broadcaster *EventBroadcaster) BroadcastTicketStatusChanges(ctx context.Context, wallet walletcore.Wallet) {
+	tickets, err := wallet.Tickets(ctx, &walletcore.TicketFilter{
+		Statuses: []string{
+			walletcore.TicketStatusUnmined,
+			walletcore.TicketStatusImmature,
+			walletcore.TicketStatusLive,
+			walletcore.TicketStatusMissed,
+			walletcore.TicketStatusExpired,
+		},
+	})
+	if err != nil {
+		return
+	}
+
+	ticketStatuses := make(map[string]string, len(tickets))
+	for _, ticket := range tickets {
+		ticketStatuses[ticket.Hash] = ticket.Status
+	}
+
+	broadcaster.mu.Lock()
+	defer broadcaster.mu.Unlock()
+
+	if broadcaster.ticketStatuses != nil {
+		for _, ticket := range tickets {
+			if !ticketStatusesWithoutTx[ticket.Status] || broadcaster.ticketStatuses[ticket.Hash] == ticket.Status {
+				continue
+			}
+			broadcaster.broadcast(app.TicketStatusEvent{TicketHash: ticket.Hash, Status: ticket.Status})
+		}
+	}
+	broadcaster.ticketStatuses = ticketStatuses
+}
+
+// ticketStatusEvent returns the ticket status change caused by a ticket purchase, vote or revocation transaction
+// returns nil for other transaction types
+func ticketStatusEvent(tx *walletcore.TransactionDetails, mined bool) *app.TicketStatusEvent {
+	// the ticket spent by a vote is the vote's second input, the first input is the stakebase
+	// the ticket spent by a revocation is the revocation's only input
+	var ticketInputIndex int
+	switch tx.Type {
+	case walletcore.TransactionTypeTicketPurchase:
+		status := walletcore.TicketStatusUnmined
+		if mined {
+			status = walletcore.TicketStatusImmature
+		}
+		return &app.TicketStatusEvent{TicketHash: tx.Hash, Status: status, TransactionHash: tx.Hash}
+
+	case walletcore.TransactionTypeVote:
+		ticketInputIndex = 1
+
+	case walletcore.TransactionTypeRevocation:
+		ticketInputIndex = 0
+
+	default:
+		return nil
+	}
+
+	if len(tx.Inputs) <= ticketInputIndex {
+		return nil
+	}
+	ticketHash := strings.Split(tx.Inputs[ticketInputIndex].PreviousOutpoint, ":")[0]
+
+	status := walletcore.TicketStatusVoted
+	if tx.Type == walletcore.TransactionTypeRevocation {
+		status = walletcore.TicketStatusRevoked
+	}
+	return &app.TicketStatusEvent{TicketHash: ticketHash, Status: status, TransactionHash: tx.Hash}
+}
@@ -0,0 +1,154 @@
+package walletmediums
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/raedahgroup/godcr/app"
+	"github.com/raedahgroup/godcr/app/walletcore"
+)
+
+// ticketsWallet is a walletcore.Wallet that only implements Tickets, returning `tickets` filtered by status
+type ticketsWallet struct {
+	walletcore.Wallet
+	tickets []*walletcore.Ticket
+}
+
+func (wallet *ticketsWallet) Tickets(ctx context.Context, filter *walletcore.TicketFilter) ([]*walletcore.Ticket, error) {
+	var tickets []*walletcore.Ticket
+	for _, ticket := range wallet.tickets {
+		for _, status := range filter.Statuses {
+			if ticket.Status == status {
+				tickets = append(tickets, ticket)
+				break
+			}
+		}
+	}
+	return tickets, nil
+}
+
+// receivedEvents returns the events queued on `events` without waiting for more
+func receivedEvents(events <-chan app.WalletEvent) []app.WalletEvent {
+	var received []app.WalletEvent
+	for {
+		select {
+		case event := <-events:
+			received = append(received, event)
+		default:
+			return received
+		}
+	}
+}
+
+func TestBroadcastTicketStatusChanges(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var broadcaster EventBroadcaster
+	events := broadcaster.Subscribe(ctx)
+
+	wallet := &ticketsWallet{tickets: []*walletcore.Ticket{
+		{Hash: "immature", Status: walletcore.TicketStatusImmature},
+		{Hash: "live", Status: walletcore.TicketStatusLive},
+		{Hash: "unmined", Status: walletcore.TicketStatusUnmined},
+	}}
+
+	// the first read only records the ticket statuses
+	broadcaster.BroadcastTicketStatusChanges(ctx, wallet)
+	if received := receivedEvents(events); len(received) != 0 {
+		t.Fatalf("expected no events for the initial ticket statuses, got %v", received)
+	}
+
+	wallet.tickets = []*walletcore.Ticket{
+		{Hash: "immature", Status: walletcore.TicketStatusLive},
+		{Hash: "live", Status: walletcore.TicketStatusMissed},
+		// purchase, vote and revocation status changes are broadcast with their transactions
+		{Hash: "unmined", Status: walletcore.TicketStatusImmature},
+		{Hash: "new", Status: walletcore.TicketStatusUnmined},
+	}
+	broadcaster.BroadcastTicketStatusChanges(ctx, wallet)
+
+	expected := []app.WalletEvent{
+		app.TicketStatusEvent{TicketHash: "immature", Status: walletcore.TicketStatusLive},
+		app.TicketStatusEvent{TicketHash: "live", Status: walletcore.TicketStatusMissed},
+	}
+	if received := receivedEvents(events); !reflect.DeepEqual(received, expected) {
+		t.Errorf("got events %v, expected %v", received, expected)
+	}
+
+	wallet.tickets = []*walletcore.Ticket{
+		{Hash: "immature", Status: walletcore.TicketStatusLive},
+		{Hash: "live", Status: walletcore.TicketStatusMissed},
+		{Hash: "unmined", Status: walletcore.TicketStatusImmature},
+	}
+	broadcaster.BroadcastTicketStatusChanges(ctx, wallet)
+	if received := receivedEvents(events); len(received) != 0 {
+		t.Errorf("expected no events for unchanged ticket statuses, got %v", received)
+	}
+}
+
+func TestBroadcastReportsDroppedEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var broadcaster EventBroadcaster
+	events := broadcaster.Subscribe(ctx)
+
+	for height := int32(1); height <= eventBufferSize+2; height++ {
+		broadcaster.Broadcast(app.NewBlockEvent{Height: height})
+	}
+	if received := receivedEvents(events); len(received) != eventBufferSize {
+		t.Fatalf("received %d events, expected the first %d", len(received), eventBufferSize)
+	}
+
+	broadcaster.Broadcast(app.NewBlockEvent{Height: eventBufferSize + 3})
+	expected := []app.WalletEvent{
+		app.DroppedEvent{Count: 2},
+		app.NewBlockEvent{Height: eventBufferSize + 3},
+	}
+	if received := receivedEvents(events); !reflect.DeepEqual(received, expected) {
+		t.Errorf("got events %v, expected %v", received, expected)
+	}
+}
+
+func TestBroadcastConfirmations(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var broadcaster EventBroadcaster
+	events := broadcaster.Subscribe(ctx)
+
+	tx := &walletcore.TransactionDetails{
+		BlockHeight: 10,
+		Transaction: &walletcore.Transaction{Hash: "mined", Type: walletcore.TransactionTypeRegular},
+	}
+	unminedTx := &walletcore.TransactionDetails{
+		BlockHeight: -1,
+		Transaction: &walletcore.Transaction{Hash: "unmined", Type: walletcore.TransactionTypeRegular},
+	}
+
+	broadcaster.BroadcastTransaction(unminedTx, false)
+	broadcaster.BroadcastTransaction(tx, true)
+	expected := []app.WalletEvent{
+		app.TransactionEvent{Transaction: unminedTx.Transaction},
+		app.TransactionEvent{Transaction: tx.Transaction, Mined: true, BlockHeight: 10},
+		app.ConfirmationEvent{TransactionHash: "mined", BlockHeight: 10, Confirmations: 1},
+	}
+	if received := receivedEvents(events); !reflect.DeepEqual(received, expected) {
+		t.Fatalf("got events %v, expected %v", received, expected)
+	}
+
+	// confirmation events are sent for every block until the transaction has app.ConfirmationsTracked confirmations
+	for height := int32(11); height <= 10+app.ConfirmationsTracked; height++ {
+		broadcaster.BroadcastNewBlock(app.NewBlockEvent{Height: height})
+		confirmations := height - 9
+		expected := []app.WalletEvent{app.NewBlockEvent{Height: height}}
+		if confirmations <= app.ConfirmationsTracked {
+			expected = append(expected, app.ConfirmationEvent{TransactionHash: "mined", BlockHeight: 10, Confirmations: confirmations})
+		}
+		if received := receivedEvents(events); !reflect.DeepEqual(received, expected) {
+			t.Errorf("block %d: got events %v, expected %v", height, received, expected)
+		}
+	}
+}
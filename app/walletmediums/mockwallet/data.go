@@ -0,0 +1,375 @@
+package mockwallet
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrec"
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/raedahgroup/dcrlibwallet/txhelper"
+	"github.com/raedahgroup/godcr/app"
+	"github.com/raedahgroup/godcr/app/walletcore"
+)
+
+const (
+	defaultAccountName   = "default"
+	importedAccountName  = "imported"
+	importedAccountIndex = 2147483647
+
+	// transaction types, as reported by dcrlibwallet and dcrwallet rpc
+	txTypeRegular        = "REGULAR"
+	txTypeTicketPurchase = "TICKET_PURCHASE"
+	txTypeVote           = "VOTE"
+	txTypeRevocation     = "REVOCATION"
+
+	// fee rate used for all transactions created by the mock wallet, same as dcrwallet's default relay fee
+	feeRatePerKb dcrutil.Amount = 1e4
+
+	// estimated serialize sizes for a P2PKH input and output, and for the other parts of a transaction
+	p2pkhInputSize  = 166
+	p2pkhOutputSize = 36
+	txOverheadSize  = 15
+
+	mockTicketPrice dcrutil.Amount = 95 * 1e8
+	mockVoteReward  dcrutil.Amount = 1.5 * 1e8
+)
+
+// populationStartTime is the time of the first fake transaction in a pre-populated mock wallet
+// it is constant so that the fake data remain the same across program runs
+var populationStartTime = time.Date(2019, time.January, 1, 9, 0, 0, 0, time.UTC)
+
+type account struct {
+	number    uint32
+	name      string
+	addresses []string
+}
+
+type utxo struct {
+	account     uint32
+	txHash      string
+	outputIndex uint32
+	amount      dcrutil.Amount
+	address     string
+	receiveTime int64
+}
+
+func (u *utxo) key() string {
+	return fmt.Sprintf("%s:%d", u.txHash, u.outputIndex)
+}
+
+type transaction struct {
+	hash        string
+	txType      string
+	direction   txhelper.TransactionDirection
+	amount      dcrutil.Amount
+	fee         dcrutil.Amount
+	size        int
+	timestamp   int64
+	blockHeight int32
+	inputs      []*txhelper.DecodedInput
+	outputs     []*txhelper.DecodedOutput
+
+	// debitAccounts holds the wallet accounts that the inputs of this transaction were spent from
+	debitAccounts []uint32
+}
+
+type ticket struct {
+	hash           string
+	account        uint32
+	price          dcrutil.Amount
+	purchaseHeight int32
+	status         string
+	spenderHash    string
+}
+
+// populate resets the wallet and fills it with fake accounts, transactions, unspent outputs and tickets
+func (mock *MockWallet) populate(privatePassphrase string) {
+	mock.reset(privatePassphrase)
+	mock.bestBlock = 320000
+
+	savings := mock.addAccount("savings")
+
+	startTime := populationStartTime.Unix()
+	mock.receive(0, 250*1e8, 319000, startTime)
+	mock.receive(0, 12.5*1e8, 319200, startTime+86400)
+	mock.receive(0, 3.2*1e8, 319650, startTime+2*86400)
+	mock.receive(savings, 500*1e8, 319100, startTime+3600)
+	mock.receive(savings, 0.75*1e8, 319999, startTime+3*86400)
+
+	// one voted, one missed and one live ticket purchased from the default account
+	votedTicket := mock.buyTicket(0, 319300, startTime+4*86400)
+	mock.voteTicket(votedTicket, 319500, startTime+5*86400)
+	missedTicket := mock.buyTicket(0, 319400, startTime+4*86400+600)
+	missedTicket.status = walletcore.TicketStatusMissed
+	mock.buyTicket(0, 319800, startTime+6*86400)
+
+	mock.send(0, mock.externalAddress("populate"), 20*1e8, 319900, startTime+7*86400)
+}
+
+// reset clears all wallet data, leaving an empty wallet with just the default and imported accounts
+func (mock *MockWallet) reset(privatePassphrase string) {
+	mock.walletExists = true
+	mock.privatePassphrase = privatePassphrase
+	mock.publicPassphrase = app.DefaultPublicPassphrase
+	mock.watchingOnly = false
+	mock.accounts = []*account{
+		{number: 0, name: defaultAccountName},
+		{number: importedAccountIndex, name: importedAccountName},
+	}
+	mock.utxos = nil
+	mock.transactions = nil
+	mock.tickets = nil
+}
+
+func (mock *MockWallet) addAccount(name string) uint32 {
+	var nextNumber uint32
+	for _, acc := range mock.accounts {
+		if acc.number != importedAccountIndex && acc.number >= nextNumber {
+			nextNumber = acc.number + 1
+		}
+	}
+
+	mock.accounts = append(mock.accounts, &account{number: nextNumber, name: name})
+	return nextNumber
+}
+
+// receive adds a mined transaction that pays `amount` to a new address in `accountNumber` from an external wallet
+func (mock *MockWallet) receive(accountNumber uint32, amount dcrutil.Amount, blockHeight int32, timestamp int64) {
+	txHash := mock.newHash()
+	address := mock.newAddress(accountNumber)
+	externalChange := dcrutil.Amount(2.5 * 1e8)
+	fee := estimateFee(1, 2)
+
+	tx := &transaction{
+		hash:        txHash,
+		txType:      txTypeRegular,
+		direction:   txhelper.TransactionDirectionReceived,
+		amount:      amount,
+		fee:         fee,
+		size:        estimateSize(1, 2),
+		timestamp:   timestamp,
+		blockHeight: blockHeight,
+		inputs: []*txhelper.DecodedInput{
+			{PreviousOutpoint: fmt.Sprintf("%s:0", mock.newHash()), AmountIn: int64(amount + externalChange + fee)},
+		},
+		outputs: []*txhelper.DecodedOutput{
+			mock.decodedOutput(address, amount),
+			mock.decodedOutput(mock.externalAddress(txHash), externalChange),
+		},
+	}
+	mock.transactions = append(mock.transactions, tx)
+
+	mock.utxos = append(mock.utxos, &utxo{
+		account:     accountNumber,
+		txHash:      txHash,
+		outputIndex: 0,
+		amount:      amount,
+		address:     address,
+		receiveTime: timestamp,
+	})
+}
+
+// send adds a transaction that spends `amount` from `accountNumber` to `address`, sending change back to the account
+func (mock *MockWallet) send(accountNumber uint32, address string, amount dcrutil.Amount, blockHeight int32, timestamp int64) {
+	inputs, _ := mock.selectInputs(accountNumber, walletcore.DefaultCoinSelection, feeRatePerKb, amount, 1, 0)
+	destinations := []txhelper.TransactionDestination{{Address: address, Amount: amount.ToCoin()}}
+
+	tx, _ := mock.createTransaction(mock.newHash(), accountNumber, inputs, destinations, true)
+	tx.blockHeight = blockHeight
+	tx.timestamp = timestamp
+	mock.setReceiveTime(tx.hash, timestamp)
+}
+
+// buyTicket adds a mined ticket purchase transaction that spends from `accountNumber`
+func (mock *MockWallet) buyTicket(accountNumber uint32, blockHeight int32, timestamp int64) *ticket {
+	inputs, _ := mock.selectInputs(accountNumber, walletcore.DefaultCoinSelection, feeRatePerKb, mockTicketPrice, 1, 0)
+	tkt, _ := mock.createTicket(accountNumber, inputs, "")
+	tkt.purchaseHeight = blockHeight
+	tkt.status = walletcore.TicketStatusLive
+
+	tx := mock.findTransaction(tkt.hash)
+	tx.blockHeight = blockHeight
+	tx.timestamp = timestamp
+	mock.setReceiveTime(tx.hash, timestamp)
+
+	return tkt
+}
+
+// voteTicket adds a vote transaction for `tkt`, returning the ticket price and vote reward to the ticket's account
+func (mock *MockWallet) voteTicket(tkt *ticket, blockHeight int32, timestamp int64) {
+	txHash := mock.newHash()
+	address := mock.newAddress(tkt.account)
+	returnAmount := tkt.price + mockVoteReward
+
+	tx := &transaction{
+		hash:        txHash,
+		txType:      txTypeVote,
+		direction:   txhelper.TransactionDirectionReceived,
+		amount:      returnAmount,
+		size:        estimateSize(2, 3),
+		timestamp:   timestamp,
+		blockHeight: blockHeight,
+		inputs: []*txhelper.DecodedInput{
+			{PreviousOutpoint: fmt.Sprintf("%s:0", mock.newHash()), AmountIn: int64(mockVoteReward)},
+			{PreviousOutpoint: fmt.Sprintf("%s:0", tkt.hash), AmountIn: int64(tkt.price)},
+		},
+		outputs: []*txhelper.DecodedOutput{
+			mock.decodedOutput(address, returnAmount),
+		},
+	}
+	mock.transactions = append(mock.transactions, tx)
+
+	mock.utxos = append(mock.utxos, &utxo{
+		account:     tkt.account,
+		txHash:      txHash,
+		outputIndex: 0,
+		amount:      returnAmount,
+		address:     address,
+		receiveTime: timestamp,
+	})
+
+	tkt.status = walletcore.TicketStatusVoted
+	tkt.spenderHash = txHash
+}
+
+// mineBlock extends the fake blockchain by one block, mining all unmined transactions and tickets into the new block
+// returns the hashes of the transactions that were mined
+// events for the new block, mined transactions, confirmations and matured tickets are sent to subscribers
+func (mock *MockWallet) mineBlock() (minedTxHashes []string) {
+	mock.bestBlock++
+
+	for _, tx := range mock.transactions {
+		if tx.blockHeight < 0 {
+			tx.blockHeight = mock.bestBlock
+			minedTxHashes = append(minedTxHashes, tx.hash)
+			mock.broadcastTransaction(tx)
+		}
+	}
+
+	ticketMaturity := int32(mock.activeNet.TicketMaturity)
+	for _, tkt := range mock.tickets {
+		switch {
+		case tkt.status == walletcore.TicketStatusUnmined:
+			tkt.purchaseHeight = mock.bestBlock
+			tkt.status = walletcore.TicketStatusImmature
+		case tkt.status == walletcore.TicketStatusImmature && mock.bestBlock-tkt.purchaseHeight > ticketMaturity:
+			tkt.status = walletcore.TicketStatusLive
+			mock.events.Broadcast(app.TicketStatusEvent{
+				TicketHash: tkt.hash,
+				Status:     tkt.status,
+			})
+		}
+	}
+
+	mock.events.BroadcastNewBlock(app.NewBlockEvent{
+		Height:    mock.bestBlock,
+		Timestamp: time.Now().Unix(),
+	})
+
+	return
+}
+
+func (mock *MockWallet) setReceiveTime(txHash string, timestamp int64) {
+	for _, u := range mock.utxos {
+		if u.txHash == txHash {
+			u.receiveTime = timestamp
+		}
+	}
+}
+
+func (mock *MockWallet) decodedOutput(address string, amount dcrutil.Amount) *txhelper.DecodedOutput {
+	addressInfo := &txhelper.AddressInfo{
+		Address: address,
+	}
+	if acc := mock.addressAccount(address); acc != nil {
+		addressInfo.IsMine = true
+		addressInfo.AccountName = acc.name
+		addressInfo.AccountNumber = acc.number
+	}
+
+	return &txhelper.DecodedOutput{
+		Value:      int64(amount),
+		ScriptType: "pubkeyhash",
+		Addresses:  []*txhelper.AddressInfo{addressInfo},
+	}
+}
+
+// newHash returns a unique fake transaction hash
+func (mock *MockWallet) newHash() string {
+	mock.hashesGenerated++
+	seed := fmt.Sprintf("godcr-mockwallet-tx-%d", mock.hashesGenerated)
+	return chainhash.HashH([]byte(seed)).String()
+}
+
+// newAddress derives the next fake P2PKH address for `accountNumber` and saves it to the account
+func (mock *MockWallet) newAddress(accountNumber uint32) string {
+	acc := mock.findAccount(accountNumber)
+	if acc == nil {
+		return ""
+	}
+
+	address := mock.deriveAddress(fmt.Sprintf("account-%d/%d", acc.number, len(acc.addresses)))
+	acc.addresses = append(acc.addresses, address)
+	return address
+}
+
+// externalAddress derives a fake P2PKH address that does not belong to any account in the wallet
+func (mock *MockWallet) externalAddress(seed string) string {
+	return mock.deriveAddress("external/" + seed)
+}
+
+func (mock *MockWallet) deriveAddress(seed string) string {
+	pkHash := chainhash.HashB([]byte("godcr-mockwallet-address/" + seed))[:20]
+	address, err := dcrutil.NewAddressPubKeyHash(pkHash, mock.activeNet, dcrec.STEcdsaSecp256k1)
+	if err != nil {
+		// only happens if pkHash is not 20 bytes long
+		panic(err)
+	}
+	return address.EncodeAddress()
+}
+
+func (mock *MockWallet) findAccount(accountNumber uint32) *account {
+	for _, acc := range mock.accounts {
+		if acc.number == accountNumber {
+			return acc
+		}
+	}
+	return nil
+}
+
+func (mock *MockWallet) addressAccount(address string) *account {
+	for _, acc := range mock.accounts {
+		for _, accountAddress := range acc.addresses {
+			if accountAddress == address {
+				return acc
+			}
+		}
+	}
+	return nil
+}
+
+func (mock *MockWallet) findTransaction(txHash string) *transaction {
+	for _, tx := range mock.transactions {
+		if tx.hash == txHash {
+			return tx
+		}
+	}
+	return nil
+}
+
+func (mock *MockWallet) confirmations(txHash string) int32 {
+	tx := mock.findTransaction(txHash)
+	if tx == nil || tx.blockHeight < 0 {
+		return 0
+	}
+	return mock.bestBlock - tx.blockHeight + 1
+}
+
+func estimateSize(numInputs, numOutputs int) int {
+	return txOverheadSize + numInputs*p2pkhInputSize + numOutputs*p2pkhOutputSize
+}
+
+func estimateFee(numInputs, numOutputs int) dcrutil.Amount {
+	return feeRatePerKb * dcrutil.Amount(estimateSize(numInputs, numOutputs)) / 1000
+}
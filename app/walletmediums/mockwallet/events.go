@@ -0,0 +1,21 @@
+package mockwallet
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/raedahgroup/godcr/app"
+)
+
+func (mock *MockWallet) Subscribe(ctx context.Context) (<-chan app.WalletEvent, error) {
+	if !mock.IsWalletOpen() {
+		return nil, fmt.Errorf("wallet is not open")
+	}
+	return mock.events.Subscribe(ctx), nil
+}
+
+// broadcastTransaction sends transaction and ticket status events for `tx` to subscribers
+// must be called with mock.mu held
+func (mock *MockWallet) broadcastTransaction(tx *transaction) {
+	mock.events.BroadcastTransaction(mock.transactionDetails(tx), tx.blockHeight >= 0)
+}
@@ -0,0 +1,72 @@
+package mockwallet
+
+import (
+	"sync"
+
+	"github.com/decred/dcrd/chaincfg"
+	"github.com/raedahgroup/godcr/app/walletcore"
+	"github.com/raedahgroup/godcr/app/walletmediums"
+)
+
+// DefaultPrivatePassphrase is the spending passphrase of the pre-populated wallet returned by `New`
+const DefaultPrivatePassphrase = "mockwallet"
+
+// MockWallet implements `WalletMiddleware` using in-memory fake accounts, unspent outputs, transactions and tickets
+// No dcrlibwallet instance or dcrwallet daemon is required, making it suitable for tests, CI runs and demos
+// Functions relating to operations that can be performed on a wallet are defined in `walletfunctions.go`
+// Other wallet-related functions are defined in `walletloader.go`
+// Wallet event subscriptions are handled in `events.go`
+type MockWallet struct {
+	mu        sync.RWMutex
+	activeNet *chaincfg.Params
+
+	walletExists      bool
+	walletOpen        bool
+	privatePassphrase string
+	publicPassphrase  string
+	watchingOnly      bool
+
+	bestBlock       int32
+	accounts        []*account
+	utxos           []*utxo
+	transactions    []*transaction
+	tickets         []*ticket
+	hashesGenerated int
+
+	// locked outputs, labels, hidden accounts and the unconfirmed seed are kept in memory only, like the rest of the mock wallet data
+	locks          *walletcore.OutputLocks
+	labels         *walletcore.Labels
+	hiddenAccounts *walletcore.HiddenAccounts
+	seedBackup     *walletcore.SeedBackup
+
+	events walletmediums.EventBroadcaster
+}
+
+// New creates an in-memory wallet for the specified network, pre-populated with fake wallet data
+// The wallet's spending passphrase is `DefaultPrivatePassphrase`
+func New(netType string) *MockWallet {
+	mock := NewEmpty(netType)
+	mock.populate(DefaultPrivatePassphrase)
+	return mock
+}
+
+// NewEmpty creates an in-memory mock for the specified network that has no wallet yet
+// A wallet must be created with CreateWallet or CreateWatchingOnlyWallet before it is used
+func NewEmpty(netType string) *MockWallet {
+	var activeNet *chaincfg.Params
+	if netType == "mainnet" {
+		activeNet = &chaincfg.MainNetParams
+	} else {
+		activeNet = &chaincfg.TestNet3Params
+	}
+
+	mock := &MockWallet{
+		activeNet: activeNet,
+	}
+	mock.locks, _ = walletcore.LoadOutputLocks("")
+	mock.labels, _ = walletcore.LoadLabels("")
+	mock.hiddenAccounts, _ = walletcore.LoadHiddenAccounts("")
+	mock.seedBackup, _ = walletcore.LoadSeedBackup("")
+
+	return mock
+}
@@ -0,0 +1,57 @@
+package mockwallet
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/raedahgroup/godcr/app"
+)
+
+// syncStepDuration is the delay between consecutive progress reports of a scripted sync
+var syncStepDuration = 200 * time.Millisecond
+
+type scriptedSync struct {
+	wallet   *MockWallet
+	listener *app.BlockChainSyncListener
+	showLog  bool
+}
+
+func (s *scriptedSync) logUpdate(format string, values ...interface{}) {
+	if s.showLog {
+		fmt.Printf(format+"\n", values...)
+	}
+}
+
+// run drives the sync listener through the same stages a real spv sync goes through:
+// fetching headers, discovering addresses and rescanning blocks
+func (s *scriptedSync) run() {
+	s.listener.SyncStarted()
+
+	s.logUpdate("Blockchain sync in progress. Start fetching headers (1/3)")
+	for progress := int64(0); progress <= 100; progress += 25 {
+		time.Sleep(syncStepDuration)
+		s.logUpdate("Blockchain sync in progress. Fetching headers (1/3): %d%%", progress)
+		s.listener.OnHeadersFetched(progress)
+	}
+	s.logUpdate("Blockchain sync in progress. Done fetching headers (1/3)")
+
+	s.logUpdate("Blockchain sync in progress. Start discovering addresses (2/3)")
+	s.listener.OnDiscoveredAddress("start")
+	time.Sleep(syncStepDuration)
+	s.listener.OnDiscoveredAddress("finish")
+	s.logUpdate("Blockchain sync in progress. Finished discovering addresses (2/3)")
+
+	s.logUpdate("Blockchain sync in progress. Start rescanning blocks (3/3)")
+	for progress := int64(0); progress <= 100; progress += 50 {
+		time.Sleep(syncStepDuration)
+		s.logUpdate("Blockchain sync in progress. Rescanning blocks (3/3): %d%%", progress)
+		s.listener.OnRescanningBlocks(progress)
+	}
+	s.logUpdate("Blockchain sync in progress. Done rescanning blocks (3/3)")
+
+	s.wallet.mu.Lock()
+	s.wallet.mineBlock()
+	s.wallet.mu.Unlock()
+
+	s.listener.SyncEnded(nil)
+}
@@ -0,0 +1,1071 @@
+package mockwallet
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/decred/dcrd/txscript"
+	"github.com/decred/dcrd/wire"
+	"github.com/raedahgroup/dcrlibwallet"
+	"github.com/raedahgroup/dcrlibwallet/addresshelper"
+	"github.com/raedahgroup/dcrlibwallet/txhelper"
+	"github.com/raedahgroup/godcr/app"
+	"github.com/raedahgroup/godcr/app/walletcore"
+)
+
+var errInvalidPassphrase = errors.New("invalid passphrase")
+
+// mockSignatureScript is set as the signature script of transaction inputs signed by the mock wallet
+const mockSignatureScript = "godcr-mockwallet-signature"
+
+func (mock *MockWallet) AccountBalance(accountNumber uint32, requiredConfirmations int32) (*walletcore.Balance, error) {
+	mock.mu.RLock()
+	defer mock.mu.RUnlock()
+
+	if mock.findAccount(accountNumber) == nil {
+		return nil, fmt.Errorf("error fetching balance for account: %d \n:account not found", accountNumber)
+	}
+
+	return mock.accountBalance(accountNumber, requiredConfirmations), nil
+}
+
+func (mock *MockWallet) AccountsOverview(requiredConfirmations int32) ([]*walletcore.Account, error) {
+	mock.mu.RLock()
+	defer mock.mu.RUnlock()
+
+	accountsOverview := make([]*walletcore.Account, 0, len(mock.accounts))
+
+	for _, acc := range mock.accounts {
+		accountsOverview = append(accountsOverview, &walletcore.Account{
+			Name:    acc.name,
+			Number:  acc.number,
+			Balance: mock.accountBalance(acc.number, requiredConfirmations),
+		})
+	}
+
+	return mock.hiddenAccounts.VisibleAccounts(accountsOverview), nil
+}
+
+func (mock *MockWallet) AccountDetails(requiredConfirmations int32) ([]*walletcore.AccountDetails, error) {
+	mock.mu.RLock()
+	defer mock.mu.RUnlock()
+
+	accountDetails := make([]*walletcore.AccountDetails, 0, len(mock.accounts))
+	for _, acc := range mock.accounts {
+		account := &walletcore.Account{
+			Name:    acc.name,
+			Number:  acc.number,
+			Balance: mock.accountBalance(acc.number, requiredConfirmations),
+		}
+
+		// the mock wallet only derives external addresses
+		accountDetails = append(accountDetails, &walletcore.AccountDetails{
+			Account:          account,
+			Hidden:           mock.hiddenAccounts.IsHidden(account),
+			ExternalKeyCount: uint32(len(acc.addresses)),
+			DerivationPath:   walletcore.AccountDerivationPath(mock.activeNet.SLIP0044CoinType, acc.number),
+		})
+	}
+
+	return accountDetails, nil
+}
+
+func (mock *MockWallet) RenameAccount(accountNumber uint32, newName string) error {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
+	if accountNumber == importedAccountIndex {
+		return fmt.Errorf("the imported account cannot be renamed")
+	}
+	if newName == "" || newName == importedAccountName {
+		return fmt.Errorf("invalid account name %q", newName)
+	}
+
+	var renamedAccount *account
+	for _, acc := range mock.accounts {
+		if acc.name == newName && acc.number != accountNumber {
+			return fmt.Errorf("account named %s already exists", newName)
+		}
+		if acc.number == accountNumber {
+			renamedAccount = acc
+		}
+	}
+	if renamedAccount == nil {
+		return fmt.Errorf("account %d not found", accountNumber)
+	}
+
+	renamedAccount.name = newName
+	mock.events.Broadcast(app.AccountEvent{
+		AccountNumber: accountNumber,
+		AccountName:   newName,
+	})
+	return nil
+}
+
+func (mock *MockWallet) HideAccount(accountNumber uint32, hide bool) error {
+	return mock.hiddenAccounts.SetHidden(accountNumber, hide)
+}
+
+func (mock *MockWallet) NextAccount(accountName string, passphrase string) (uint32, error) {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
+	if mock.watchingOnly {
+		return 0, walletcore.ErrWatchingOnlyWallet
+	}
+
+	if passphrase != mock.privatePassphrase {
+		return 0, errInvalidPassphrase
+	}
+
+	for _, acc := range mock.accounts {
+		if acc.name == accountName {
+			return 0, fmt.Errorf("account named %s already exists", accountName)
+		}
+	}
+
+	accountNumber := mock.addAccount(accountName)
+	mock.events.Broadcast(app.AccountEvent{
+		AccountNumber: accountNumber,
+		AccountName:   accountName,
+	})
+	return accountNumber, nil
+}
+
+func (mock *MockWallet) AccountNumber(accountName string) (uint32, error) {
+	mock.mu.RLock()
+	defer mock.mu.RUnlock()
+
+	for _, acc := range mock.accounts {
+		if acc.name == accountName {
+			return acc.number, nil
+		}
+	}
+
+	return 0, fmt.Errorf("account not found")
+}
+
+func (mock *MockWallet) AccountName(accountNumber uint32) (string, error) {
+	mock.mu.RLock()
+	defer mock.mu.RUnlock()
+
+	if acc := mock.findAccount(accountNumber); acc != nil {
+		return acc.name, nil
+	}
+
+	return "", fmt.Errorf("Account not found")
+}
+
+func (mock *MockWallet) AddressInfo(address string) (*txhelper.AddressInfo, error) {
+	mock.mu.RLock()
+	defer mock.mu.RUnlock()
+
+	addressInfo := &txhelper.AddressInfo{
+		Address: address,
+	}
+	if acc := mock.addressAccount(address); acc != nil {
+		addressInfo.IsMine = true
+		addressInfo.AccountNumber = acc.number
+		addressInfo.AccountName = acc.name
+	}
+
+	return addressInfo, nil
+}
+
+func (mock *MockWallet) ValidateAddress(address string) (bool, error) {
+	_, err := addresshelper.DecodeForNetwork(address, mock.activeNet)
+	return err == nil, nil
+}
+
+// ReceiveAddress returns the last address generated for `account` if it has not been used to receive funds
+func (mock *MockWallet) ReceiveAddress(account uint32) (string, error) {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
+	acc := mock.findAccount(account)
+	if acc == nil {
+		return "", fmt.Errorf("account not found")
+	}
+
+	if len(acc.addresses) > 0 {
+		lastAddress := acc.addresses[len(acc.addresses)-1]
+		if !mock.addressUsed(lastAddress) {
+			return lastAddress, nil
+		}
+	}
+
+	return mock.newAddress(account), nil
+}
+
+func (mock *MockWallet) GenerateNewAddress(account uint32) (string, error) {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
+	if mock.findAccount(account) == nil {
+		return "", fmt.Errorf("account not found")
+	}
+
+	return mock.newAddress(account), nil
+}
+
+// ChangeAddress returns a new address in the account, the mock wallet does not keep separate address branches
+func (mock *MockWallet) ChangeAddress(account uint32) (string, error) {
+	return mock.GenerateNewAddress(account)
+}
+
+func (mock *MockWallet) UnspentOutputs(account uint32, targetAmount int64, requiredConfirmations int32) ([]*walletcore.UnspentOutput, error) {
+	mock.mu.RLock()
+	defer mock.mu.RUnlock()
+
+	var unspentOutputs []*walletcore.UnspentOutput
+	var totalAmount int64
+
+	for _, u := range mock.accountUtxos(account, requiredConfirmations) {
+		unspentOutputs = append(unspentOutputs, &walletcore.UnspentOutput{
+			OutputKey:       u.key(),
+			TransactionHash: u.txHash,
+			OutputIndex:     u.outputIndex,
+			ReceiveTime:     u.receiveTime,
+			Amount:          u.amount,
+			Address:         u.address,
+			Confirmations:   mock.confirmations(u.txHash),
+			Locked:          mock.locks.IsLocked(u.key()),
+		})
+
+		totalAmount += int64(u.amount)
+		if targetAmount > 0 && totalAmount >= targetAmount {
+			break
+		}
+	}
+
+	mock.labels.MarkUnspentOutputs(unspentOutputs)
+	return unspentOutputs, nil
+}
+
+func (mock *MockWallet) LockUnspentOutputs(outputKeys []string) error {
+	return mock.locks.Lock(outputKeys)
+}
+
+func (mock *MockWallet) UnlockUnspentOutputs(outputKeys []string) error {
+	return mock.locks.Unlock(outputKeys)
+}
+
+func (mock *MockWallet) LockedUnspentOutputs() ([]string, error) {
+	return mock.locks.Keys(), nil
+}
+
+func (mock *MockWallet) SetLabel(labelType, ref, label string) error {
+	return mock.labels.Set(&walletcore.Label{Type: labelType, Ref: ref, Label: label})
+}
+
+func (mock *MockWallet) Labels() ([]*walletcore.Label, error) {
+	return mock.labels.All(), nil
+}
+
+func (mock *MockWallet) ImportLabels(labels []*walletcore.Label) error {
+	return mock.labels.Import(labels)
+}
+
+func (mock *MockWallet) SendFromUTXOs(sourceAccount uint32, requiredConfirmations int32, utxoKeys []string, txDestinations []txhelper.TransactionDestination, changeDestinations []txhelper.TransactionDestination, passphrase string) (string, error) {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
+	if mock.watchingOnly {
+		return "", walletcore.ErrWatchingOnlyWallet
+	}
+
+	if passphrase != mock.privatePassphrase {
+		return "", errInvalidPassphrase
+	}
+
+	if err := mock.locks.CheckUnlocked(utxoKeys); err != nil {
+		return "", err
+	}
+
+	// find user selected utxos among all utxos in account
+	inputs := make([]*utxo, 0, len(utxoKeys))
+	for _, u := range mock.accountUtxos(sourceAccount, requiredConfirmations) {
+		for _, key := range utxoKeys {
+			if u.key() == key {
+				inputs = append(inputs, u)
+				break
+			}
+		}
+	}
+	if len(inputs) != len(utxoKeys) {
+		return "", fmt.Errorf("some of the selected unspent outputs were not found in account")
+	}
+
+	destinations := make([]txhelper.TransactionDestination, 0, len(txDestinations)+len(changeDestinations))
+	destinations = append(destinations, txDestinations...)
+	destinations = append(destinations, changeDestinations...)
+
+	tx, err := mock.createTransaction(mock.newHash(), sourceAccount, inputs, destinations, false)
+	if err != nil {
+		return "", err
+	}
+
+	return tx.hash, nil
+}
+
+func (mock *MockWallet) EstimateTransaction(sourceAccount uint32, requiredConfirmations int32, destinations []txhelper.TransactionDestination,
+	utxoKeys []string, coinSelection string, feeRate dcrutil.Amount) (*walletcore.TransactionEstimate, error) {
+
+	// fetch all utxos in account, the inputs for the transaction are selected from them
+	utxos, err := mock.UnspentOutputs(sourceAccount, 0, requiredConfirmations)
+	if err != nil {
+		return nil, err
+	}
+
+	return walletcore.NewTransactionEstimate(utxos, sourceAccount, requiredConfirmations, destinations, utxoKeys, coinSelection, feeRate)
+}
+
+func (mock *MockWallet) EstimateSweepTransaction(sourceAccount uint32, requiredConfirmations int32, destinationAddress string,
+	utxoKeys []string, feeRate dcrutil.Amount) (*walletcore.TransactionEstimate, error) {
+
+	utxos, err := mock.UnspentOutputs(sourceAccount, 0, requiredConfirmations)
+	if err != nil {
+		return nil, err
+	}
+
+	return walletcore.NewSweepEstimate(utxos, sourceAccount, requiredConfirmations, destinationAddress, utxoKeys, feeRate)
+}
+
+func (mock *MockWallet) CreateUnsignedTransaction(sourceAccount uint32, requiredConfirmations int32, destinations []txhelper.TransactionDestination,
+	utxoKeys []string, changeDestinations []txhelper.TransactionDestination) (*walletcore.OfflineTransaction, error) {
+
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
+	var inputs []*utxo
+	if len(utxoKeys) == 0 {
+		var sendAmount dcrutil.Amount
+		for _, destination := range destinations {
+			amount, err := dcrutil.NewAmount(destination.Amount)
+			if err != nil {
+				return nil, err
+			}
+			sendAmount += amount
+		}
+
+		var err error
+		inputs, err = mock.selectInputs(sourceAccount, walletcore.DefaultCoinSelection, feeRatePerKb, sendAmount, len(destinations), requiredConfirmations)
+		if err != nil {
+			return nil, fmt.Errorf("error constructing transaction: %s", err.Error())
+		}
+
+		var totalInput dcrutil.Amount
+		for _, input := range inputs {
+			totalInput += input.amount
+		}
+
+		changeDestinations = nil
+		if change := totalInput - sendAmount - estimateFee(len(inputs), len(destinations)+1); change > 0 {
+			changeDestinations = []txhelper.TransactionDestination{{
+				Address: mock.newAddress(sourceAccount),
+				Amount:  change.ToCoin(),
+			}}
+		}
+	} else {
+		if err := mock.locks.CheckUnlocked(utxoKeys); err != nil {
+			return nil, err
+		}
+		for _, key := range utxoKeys {
+			input := mock.findUtxo(key)
+			if input == nil || input.account != sourceAccount {
+				return nil, fmt.Errorf("unspent output %s not found in account", key)
+			}
+			inputs = append(inputs, input)
+		}
+	}
+
+	txInputs := make([]*wire.TxIn, len(inputs))
+	offlineTxInputs := make([]*walletcore.OfflineTxInput, len(inputs))
+	for i, input := range inputs {
+		pkScript, err := payToAddrScript(input.address)
+		if err != nil {
+			return nil, err
+		}
+		offlineTxInputs[i] = walletcore.NewOfflineTxInput(&walletcore.UnspentOutput{
+			OutputKey: input.key(),
+			Amount:    input.amount,
+			Address:   input.address,
+		}, pkScript)
+
+		if txInputs[i], err = offlineTxInputs[i].TxIn(); err != nil {
+			return nil, err
+		}
+	}
+
+	unsignedTx, err := txhelper.NewUnsignedTx(txInputs, destinations, changeDestinations)
+	if err != nil {
+		return nil, err
+	}
+
+	return walletcore.NewOfflineTransaction(mock.NetType(), sourceAccount, unsignedTx, offlineTxInputs)
+}
+
+// SignTransaction sets a fake signature script on each input, provided the inputs spend outputs sent to addresses in the wallet
+// Wallets created by `New` share the same addresses, so a transaction created by one mock wallet can be signed by another
+func (mock *MockWallet) SignTransaction(unsignedTx *walletcore.OfflineTransaction, passphrase string) (*walletcore.OfflineTransaction, error) {
+	mock.mu.RLock()
+	defer mock.mu.RUnlock()
+
+	if mock.watchingOnly {
+		return nil, walletcore.ErrWatchingOnlyWallet
+	}
+	if passphrase != mock.privatePassphrase {
+		return nil, errInvalidPassphrase
+	}
+
+	msgTx, err := unsignedTx.MsgTx()
+	if err != nil {
+		return nil, err
+	}
+
+	signedTx := msgTx.Copy()
+	for i, input := range unsignedTx.Inputs {
+		if mock.addressAccount(input.Address) == nil {
+			return nil, fmt.Errorf("could not sign input %s, address %s does not belong to this wallet", input.OutputKey, input.Address)
+		}
+		signedTx.TxIn[i].SignatureScript = []byte(mockSignatureScript)
+	}
+
+	return unsignedTx.Signed(signedTx)
+}
+
+// SignMessage returns a fake signature derived from the address and message, provided the address belongs to the wallet.
+// Wallets created by `New` share the same addresses, so a message signed by one mock wallet can be verified by another
+func (mock *MockWallet) SignMessage(address, message, passphrase string) (string, error) {
+	mock.mu.RLock()
+	defer mock.mu.RUnlock()
+
+	if mock.watchingOnly {
+		return "", walletcore.ErrWatchingOnlyWallet
+	}
+	if passphrase != mock.privatePassphrase {
+		return "", errInvalidPassphrase
+	}
+	if mock.addressAccount(address) == nil {
+		return "", fmt.Errorf("address %s does not belong to this wallet", address)
+	}
+
+	return mockMessageSignature(address, message), nil
+}
+
+// VerifyMessage checks that `signature` is the fake signature SignMessage returns for the address and message
+func (mock *MockWallet) VerifyMessage(address, message, signature string) (bool, error) {
+	if _, err := addresshelper.DecodeForNetwork(address, mock.activeNet); err != nil {
+		return false, fmt.Errorf("invalid address: %s", err.Error())
+	}
+	if _, err := base64.StdEncoding.DecodeString(signature); err != nil {
+		return false, fmt.Errorf("invalid signature, signatures are base64-encoded: %s", err.Error())
+	}
+
+	return signature == mockMessageSignature(address, message), nil
+}
+
+func mockMessageSignature(address, message string) string {
+	hash := sha256.Sum256([]byte(mockSignatureScript + address + message))
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+// PublishTransaction adds the signed transaction to the wallet as an unmined transaction, spending its inputs
+func (mock *MockWallet) PublishTransaction(signedTx *walletcore.OfflineTransaction) (string, error) {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
+	msgTx, err := signedTx.MsgTx()
+	if err != nil {
+		return "", err
+	}
+
+	if len(msgTx.TxIn) == 0 {
+		return "", fmt.Errorf("transaction has no inputs")
+	}
+
+	inputs := make([]*utxo, len(msgTx.TxIn))
+	for i, txIn := range msgTx.TxIn {
+		if len(txIn.SignatureScript) == 0 {
+			return "", fmt.Errorf("transaction input %d is not signed", i)
+		}
+
+		key := fmt.Sprintf("%s:%d", txIn.PreviousOutPoint.Hash.String(), txIn.PreviousOutPoint.Index)
+		if inputs[i] = mock.findUtxo(key); inputs[i] == nil {
+			return "", fmt.Errorf("transaction input %s is not an unspent output of this wallet", key)
+		}
+	}
+
+	destinations := make([]txhelper.TransactionDestination, len(msgTx.TxOut))
+	for i, txOut := range msgTx.TxOut {
+		address, err := walletcore.GetAddressFromPkScript(mock.activeNet, txOut.PkScript)
+		if err != nil {
+			return "", fmt.Errorf("error reading output address: %s", err.Error())
+		}
+		destinations[i] = txhelper.TransactionDestination{
+			Address: address,
+			Amount:  dcrutil.Amount(txOut.Value).ToCoin(),
+		}
+	}
+
+	tx, err := mock.createTransaction(msgTx.TxHash().String(), inputs[0].account, inputs, destinations, false)
+	if err != nil {
+		return "", err
+	}
+
+	return tx.hash, nil
+}
+
+func (mock *MockWallet) TransactionHistory(query *walletcore.TransactionHistoryQuery) ([]*walletcore.Transaction, error) {
+	mock.mu.RLock()
+	defer mock.mu.RUnlock()
+
+	transactions := make([]*walletcore.Transaction, len(mock.transactions))
+	for i, tx := range mock.transactions {
+		transactions[i] = tx.walletcoreTransaction()
+		mock.addAccountChanges(tx, transactions[i])
+	}
+
+	transactions = query.Apply(transactions)
+	mock.labels.MarkTransactions(transactions)
+	return transactions, nil
+}
+
+func (mock *MockWallet) GetTransaction(transactionHash string) (*walletcore.TransactionDetails, error) {
+	mock.mu.RLock()
+	defer mock.mu.RUnlock()
+
+	tx := mock.findTransaction(transactionHash)
+	if tx == nil {
+		return nil, fmt.Errorf("transaction not found")
+	}
+
+	return mock.transactionDetails(tx), nil
+}
+
+func (mock *MockWallet) StakeInfo(ctx context.Context) (*walletcore.StakeInfo, error) {
+	mock.mu.RLock()
+	defer mock.mu.RUnlock()
+
+	stakeInfo := &walletcore.StakeInfo{
+		PoolSize: 40960,
+	}
+
+	for _, tkt := range mock.tickets {
+		switch tkt.status {
+		case walletcore.TicketStatusUnmined:
+			stakeInfo.OwnMempoolTix++
+		case walletcore.TicketStatusImmature:
+			stakeInfo.Immature++
+			stakeInfo.Unspent++
+		case walletcore.TicketStatusLive:
+			stakeInfo.Live++
+			stakeInfo.Unspent++
+		case walletcore.TicketStatusVoted:
+			stakeInfo.Voted++
+			stakeInfo.TotalSubsidy += int64(mockVoteReward)
+		case walletcore.TicketStatusMissed:
+			stakeInfo.Missed++
+		case walletcore.TicketStatusExpired:
+			stakeInfo.Expired++
+		case walletcore.TicketStatusRevoked:
+			stakeInfo.Revoked++
+		}
+	}
+	stakeInfo.AllMempoolTix = stakeInfo.OwnMempoolTix
+
+	return stakeInfo, nil
+}
+
+func (mock *MockWallet) Tickets(ctx context.Context, filter *walletcore.TicketFilter) ([]*walletcore.Ticket, error) {
+	mock.mu.RLock()
+	defer mock.mu.RUnlock()
+
+	tickets := make([]*walletcore.Ticket, len(mock.tickets))
+	for i, tkt := range mock.tickets {
+		tickets[i] = &walletcore.Ticket{
+			Hash:           tkt.hash,
+			Account:        tkt.account,
+			Price:          tkt.price,
+			Status:         tkt.status,
+			PurchaseHeight: tkt.purchaseHeight,
+		}
+
+		switch tkt.status {
+		case walletcore.TicketStatusVoted:
+			tickets[i].VoteHash = tkt.spenderHash
+			tickets[i].Reward = mockVoteReward
+		case walletcore.TicketStatusRevoked:
+			tickets[i].RevocationHash = tkt.spenderHash
+		}
+	}
+
+	return filter.Apply(tickets), nil
+}
+
+func (mock *MockWallet) RevokeTickets(ctx context.Context, passphrase string) ([]*walletcore.RevokedTicket, error) {
+	if mock.IsWatchingOnlyWallet() {
+		return nil, walletcore.ErrWatchingOnlyWallet
+	}
+	return walletcore.RevokeMissedAndExpiredTickets(ctx, mock, passphrase)
+}
+
+func (mock *MockWallet) RevokeTicket(ctx context.Context, ticketHash, passphrase string) (string, error) {
+	if err := walletcore.CheckTicketRevokable(ctx, mock, ticketHash); err != nil {
+		return "", err
+	}
+
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
+	if mock.watchingOnly {
+		return "", walletcore.ErrWatchingOnlyWallet
+	}
+	if passphrase != mock.privatePassphrase {
+		return "", errInvalidPassphrase
+	}
+
+	for _, tkt := range mock.tickets {
+		if tkt.hash == ticketHash {
+			return mock.revokeTicket(tkt)
+		}
+	}
+	return "", fmt.Errorf("ticket %s not found in wallet", ticketHash)
+}
+
+func (mock *MockWallet) PurchaseTickets(ctx context.Context, request dcrlibwallet.PurchaseTicketsRequest) ([]string, error) {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
+	if mock.watchingOnly {
+		return nil, walletcore.ErrWatchingOnlyWallet
+	}
+
+	balance := mock.accountBalance(request.Account, int32(request.RequiredConfirmations))
+	if balance.Spendable < mockTicketPrice*dcrutil.Amount(request.NumTickets) {
+		return nil, fmt.Errorf("insufficient funds: spendable account balance (%s) is less than ticket price %s",
+			balance.Spendable, mockTicketPrice)
+	}
+
+	if string(request.Passphrase) != mock.privatePassphrase {
+		return nil, fmt.Errorf("could not complete ticket(s) purchase, encountered an error:\n%s", errInvalidPassphrase.Error())
+	}
+
+	ticketHashes := make([]string, 0, request.NumTickets)
+	for i := uint32(0); i < request.NumTickets; i++ {
+		inputs, err := mock.selectInputs(request.Account, walletcore.DefaultCoinSelection, feeRatePerKb, mockTicketPrice, 1, int32(request.RequiredConfirmations))
+		if err != nil {
+			return ticketHashes, fmt.Errorf("could not complete ticket(s) purchase, encountered an error:\n%s", err.Error())
+		}
+
+		tkt, err := mock.createTicket(request.Account, inputs, request.TicketAddress)
+		if err != nil {
+			return ticketHashes, fmt.Errorf("could not complete ticket(s) purchase, encountered an error:\n%s", err.Error())
+		}
+		ticketHashes = append(ticketHashes, tkt.hash)
+	}
+
+	return ticketHashes, nil
+}
+
+// findUtxo returns the unspent output with the output key `key` in any account
+func (mock *MockWallet) findUtxo(key string) *utxo {
+	for _, u := range mock.utxos {
+		if u.key() == key {
+			return u
+		}
+	}
+	return nil
+}
+
+func payToAddrScript(address string) ([]byte, error) {
+	addr, err := dcrutil.DecodeAddress(address)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding address %s: %s", address, err.Error())
+	}
+	return txscript.PayToAddrScript(addr)
+}
+
+func (mock *MockWallet) IsWatchingOnlyWallet() bool {
+	mock.mu.RLock()
+	defer mock.mu.RUnlock()
+	return mock.watchingOnly
+}
+
+func (mock *MockWallet) transactionDetails(tx *transaction) *walletcore.TransactionDetails {
+	txDetails := &walletcore.TransactionDetails{
+		BlockHeight:   tx.blockHeight,
+		Confirmations: mock.confirmations(tx.hash),
+		Inputs:        tx.inputs,
+		Outputs:       tx.outputs,
+		Transaction:   tx.walletcoreTransaction(),
+	}
+	mock.labels.MarkTransactionDetails(txDetails)
+	return txDetails
+}
+
+func (tx *transaction) walletcoreTransaction() *walletcore.Transaction {
+	var feeRate dcrutil.Amount
+	if tx.size > 0 {
+		feeRate = tx.fee * 1000 / dcrutil.Amount(tx.size)
+	}
+
+	return &walletcore.Transaction{
+		Hash:          tx.hash,
+		Type:          tx.txType,
+		Amount:        tx.amount,
+		Fee:           tx.fee,
+		FeeRate:       feeRate,
+		Direction:     tx.direction,
+		Timestamp:     tx.timestamp,
+		FormattedTime: time.Unix(tx.timestamp, 0).Format("Mon Jan 2, 2006 3:04PM"),
+		Size:          tx.size,
+	}
+}
+
+// addAccountChanges sets the amount by which `tx` changed the balance of each wallet account it spends from or pays to.
+// Inputs spending outputs of other wallet transactions are debited from the account that owned the spent output.
+// If the spent output's transaction is not in the wallet's history, the input is debited from the account recorded when it was spent.
+func (mock *MockWallet) addAccountChanges(tx *transaction, walletTx *walletcore.Transaction) {
+	for i, input := range tx.inputs {
+		outpoint := strings.Split(input.PreviousOutpoint, ":")
+		if len(outpoint) != 2 {
+			continue
+		}
+
+		previousTx := mock.findTransaction(outpoint[0])
+		if previousTx == nil {
+			if i < len(tx.debitAccounts) {
+				walletTx.AddAccountChange(tx.debitAccounts[i], -dcrutil.Amount(input.AmountIn))
+			}
+			continue
+		}
+
+		outputIndex, err := strconv.Atoi(outpoint[1])
+		if err != nil || outputIndex >= len(previousTx.outputs) {
+			continue
+		}
+		for _, address := range previousTx.outputs[outputIndex].Addresses {
+			if address.IsMine {
+				walletTx.AddAccountChange(address.AccountNumber, -dcrutil.Amount(input.AmountIn))
+				break
+			}
+		}
+	}
+
+	for _, output := range tx.outputs {
+		for _, address := range output.Addresses {
+			if address.IsMine {
+				walletTx.AddAccountChange(address.AccountNumber, dcrutil.Amount(output.Value))
+				break
+			}
+		}
+	}
+}
+
+func (mock *MockWallet) accountBalance(accountNumber uint32, requiredConfirmations int32) *walletcore.Balance {
+	balance := &walletcore.Balance{}
+
+	for _, u := range mock.utxos {
+		if u.account != accountNumber {
+			continue
+		}
+
+		balance.Total += u.amount
+		if mock.confirmations(u.txHash) >= requiredConfirmations {
+			balance.Spendable += u.amount
+		} else {
+			balance.Unconfirmed += u.amount
+		}
+	}
+
+	for _, tkt := range mock.tickets {
+		if tkt.account != accountNumber {
+			continue
+		}
+
+		switch tkt.status {
+		case walletcore.TicketStatusUnmined, walletcore.TicketStatusImmature, walletcore.TicketStatusLive, walletcore.TicketStatusMissed, walletcore.TicketStatusExpired:
+			balance.Total += tkt.price
+			balance.LockedByTickets += tkt.price
+			balance.VotingAuthority += tkt.price
+		}
+	}
+
+	return balance
+}
+
+// accountUtxos returns the unspent outputs in `accountNumber` that have at least `requiredConfirmations` confirmations
+func (mock *MockWallet) accountUtxos(accountNumber uint32, requiredConfirmations int32) []*utxo {
+	var utxos []*utxo
+	for _, u := range mock.utxos {
+		if u.account == accountNumber && mock.confirmations(u.txHash) >= requiredConfirmations {
+			utxos = append(utxos, u)
+		}
+	}
+	return utxos
+}
+
+func (mock *MockWallet) addressUsed(address string) bool {
+	for _, tx := range mock.transactions {
+		for _, output := range tx.outputs {
+			for _, addressInfo := range output.Addresses {
+				if addressInfo.Address == address {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// selectInputs selects unlocked unspent outputs in `accountNumber` using the `coinSelection` strategy until they can pay `amount`
+// to `numOutputs` outputs, plus the transaction fee at `feeRate` and a change output.
+// The mock pays all fees at feeRatePerKb, so lower fee rates are raised to it
+func (mock *MockWallet) selectInputs(accountNumber uint32, coinSelection string, feeRate, amount dcrutil.Amount, numOutputs int,
+	requiredConfirmations int32) ([]*utxo, error) {
+
+	if feeRate < feeRatePerKb {
+		feeRate = feeRatePerKb
+	}
+
+	candidates := make(map[string]*utxo)
+	var unspentOutputs []*walletcore.UnspentOutput
+	for _, u := range mock.accountUtxos(accountNumber, requiredConfirmations) {
+		if mock.locks.IsLocked(u.key()) {
+			continue
+		}
+		candidates[u.key()] = u
+		unspentOutputs = append(unspentOutputs, &walletcore.UnspentOutput{
+			OutputKey:   u.key(),
+			Amount:      u.amount,
+			Address:     u.address,
+			ReceiveTime: u.receiveTime,
+		})
+	}
+
+	selection, err := walletcore.SelectCoins(coinSelection, unspentOutputs, walletcore.SelectionTarget{
+		SendAmount: amount,
+		FeeRate:    feeRate,
+		NumOutputs: numOutputs + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make([]*utxo, len(selection))
+	for i, unspentOutput := range selection {
+		selected[i] = candidates[unspentOutput.OutputKey]
+	}
+	return selected, nil
+}
+
+// createTransaction adds an unmined transaction with hash `hash` that spends `inputs` to `destinations`
+// If `sendChangeToAccount` is true, the change left after paying `destinations` and the fee is sent to a new address in `sourceAccount`
+// Otherwise, whatever amount is left after paying `destinations` is used as fee
+func (mock *MockWallet) createTransaction(hash string, sourceAccount uint32, inputs []*utxo, destinations []txhelper.TransactionDestination,
+	sendChangeToAccount bool) (*transaction, error) {
+
+	var totalInput, totalOutput, externalOutput dcrutil.Amount
+	for _, input := range inputs {
+		totalInput += input.amount
+	}
+
+	outputAmounts := make([]dcrutil.Amount, len(destinations))
+	for i, destination := range destinations {
+		amount, err := dcrutil.NewAmount(destination.Amount)
+		if err != nil {
+			return nil, err
+		}
+		outputAmounts[i] = amount
+		totalOutput += amount
+
+		if mock.addressAccount(destination.Address) == nil {
+			externalOutput += amount
+		}
+	}
+
+	var fee dcrutil.Amount
+	numOutputs := len(destinations)
+	if sendChangeToAccount {
+		fee = estimateFee(len(inputs), numOutputs+1)
+		change := totalInput - totalOutput - fee
+		if change < 0 {
+			return nil, fmt.Errorf("insufficient balance: inputs total %s, %s is required", totalInput, totalOutput+fee)
+		}
+		if change > 0 {
+			changeDestination := txhelper.TransactionDestination{Address: mock.newAddress(sourceAccount), Amount: change.ToCoin()}
+			destinations = append(destinations[:len(destinations):len(destinations)], changeDestination)
+			outputAmounts = append(outputAmounts, change)
+			numOutputs++
+		}
+	} else {
+		fee = totalInput - totalOutput
+		if fee < 0 {
+			return nil, fmt.Errorf("insufficient balance: inputs total %s, %s is required", totalInput, totalOutput)
+		}
+	}
+
+	tx := &transaction{
+		hash:        hash,
+		txType:      txTypeRegular,
+		direction:   txhelper.TransactionDirectionSent,
+		amount:      externalOutput,
+		fee:         fee,
+		size:        estimateSize(len(inputs), numOutputs),
+		timestamp:   time.Now().Unix(),
+		blockHeight: -1,
+	}
+	if externalOutput == 0 {
+		// transferred internally, the only real amount spent was transaction fee
+		tx.direction = txhelper.TransactionDirectionTransferred
+		tx.amount = fee
+	}
+
+	mock.spendUtxos(tx, inputs)
+
+	for i, destination := range destinations {
+		tx.outputs = append(tx.outputs, mock.decodedOutput(destination.Address, outputAmounts[i]))
+
+		if acc := mock.addressAccount(destination.Address); acc != nil {
+			mock.utxos = append(mock.utxos, &utxo{
+				account:     acc.number,
+				txHash:      tx.hash,
+				outputIndex: uint32(i),
+				amount:      outputAmounts[i],
+				address:     destination.Address,
+				receiveTime: tx.timestamp,
+			})
+		}
+	}
+
+	mock.transactions = append(mock.transactions, tx)
+	mock.broadcastTransaction(tx)
+	return tx, nil
+}
+
+// createTicket adds an unmined ticket purchase transaction that spends `inputs`
+// The ticket's voting rights are given to `ticketAddress` or to a new address in `sourceAccount` if `ticketAddress` is empty
+func (mock *MockWallet) createTicket(sourceAccount uint32, inputs []*utxo, ticketAddress string) (*ticket, error) {
+	var totalInput dcrutil.Amount
+	for _, input := range inputs {
+		totalInput += input.amount
+	}
+
+	fee := estimateFee(len(inputs), 2)
+	change := totalInput - mockTicketPrice - fee
+	if change < 0 {
+		return nil, fmt.Errorf("insufficient balance: inputs total %s, %s is required", totalInput, mockTicketPrice+fee)
+	}
+
+	if ticketAddress == "" {
+		ticketAddress = mock.newAddress(sourceAccount)
+	}
+	changeAddress := mock.newAddress(sourceAccount)
+
+	tx := &transaction{
+		hash:        mock.newHash(),
+		txType:      txTypeTicketPurchase,
+		direction:   txhelper.TransactionDirectionSent,
+		amount:      mockTicketPrice,
+		fee:         fee,
+		size:        estimateSize(len(inputs), 2),
+		timestamp:   time.Now().Unix(),
+		blockHeight: -1,
+		outputs: []*txhelper.DecodedOutput{
+			mock.decodedOutput(ticketAddress, mockTicketPrice),
+			mock.decodedOutput(changeAddress, change),
+		},
+	}
+	mock.spendUtxos(tx, inputs)
+	mock.transactions = append(mock.transactions, tx)
+
+	mock.utxos = append(mock.utxos, &utxo{
+		account:     sourceAccount,
+		txHash:      tx.hash,
+		outputIndex: 1,
+		amount:      change,
+		address:     changeAddress,
+		receiveTime: tx.timestamp,
+	})
+
+	tkt := &ticket{
+		hash:           tx.hash,
+		account:        sourceAccount,
+		price:          mockTicketPrice,
+		purchaseHeight: -1,
+		status:         walletcore.TicketStatusUnmined,
+	}
+	mock.tickets = append(mock.tickets, tkt)
+	mock.broadcastTransaction(tx)
+
+	return tkt, nil
+}
+
+// revokeTicket adds an unmined revocation transaction for `tkt`, returning the ticket price less the fee to the ticket's account
+func (mock *MockWallet) revokeTicket(tkt *ticket) (string, error) {
+	fee := estimateFee(1, 1)
+	returnAmount := tkt.price - fee
+	if returnAmount <= 0 {
+		return "", fmt.Errorf("ticket value cannot pay the revocation fee of %s", fee)
+	}
+	address := mock.newAddress(tkt.account)
+
+	tx := &transaction{
+		hash:        mock.newHash(),
+		txType:      txTypeRevocation,
+		direction:   txhelper.TransactionDirectionReceived,
+		amount:      returnAmount,
+		fee:         fee,
+		size:        estimateSize(1, 1),
+		timestamp:   time.Now().Unix(),
+		blockHeight: -1,
+		inputs: []*txhelper.DecodedInput{
+			{PreviousOutpoint: fmt.Sprintf("%s:0", tkt.hash), AmountIn: int64(tkt.price)},
+		},
+		outputs: []*txhelper.DecodedOutput{
+			mock.decodedOutput(address, returnAmount),
+		},
+	}
+	mock.transactions = append(mock.transactions, tx)
+
+	mock.utxos = append(mock.utxos, &utxo{
+		account:     tkt.account,
+		txHash:      tx.hash,
+		outputIndex: 0,
+		amount:      returnAmount,
+		address:     address,
+		receiveTime: tx.timestamp,
+	})
+
+	tkt.status = walletcore.TicketStatusRevoked
+	tkt.spenderHash = tx.hash
+	mock.broadcastTransaction(tx)
+
+	return tx.hash, nil
+}
+
+// spendUtxos removes `inputs` from the wallet's unspent outputs and records them as inputs of `tx`
+func (mock *MockWallet) spendUtxos(tx *transaction, inputs []*utxo) {
+	for _, input := range inputs {
+		tx.inputs = append(tx.inputs, &txhelper.DecodedInput{
+			PreviousOutpoint: input.key(),
+			AmountIn:         int64(input.amount),
+		})
+		tx.debitAccounts = append(tx.debitAccounts, input.account)
+
+		for i, u := range mock.utxos {
+			if u == input {
+				mock.utxos = append(mock.utxos[:i], mock.utxos[i+1:]...)
+				break
+			}
+		}
+	}
+}
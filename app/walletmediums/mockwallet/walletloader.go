@@ -0,0 +1,169 @@
+package mockwallet
+
+import (
+	"fmt"
+
+	"github.com/decred/dcrd/hdkeychain"
+	"github.com/decred/dcrwallet/walletseed"
+	"github.com/raedahgroup/godcr/app"
+	"github.com/raedahgroup/godcr/app/walletcore"
+)
+
+func (mock *MockWallet) NetType() string {
+	if mock.activeNet.Name == "mainnet" {
+		return "mainnet"
+	}
+	return "testnet"
+}
+
+func (mock *MockWallet) WalletExists() (bool, error) {
+	mock.mu.RLock()
+	defer mock.mu.RUnlock()
+	return mock.walletExists, nil
+}
+
+func (mock *MockWallet) GenerateNewWalletSeed() (string, error) {
+	seed, err := hdkeychain.GenerateSeed(hdkeychain.RecommendedSeedLen)
+	if err != nil {
+		return "", err
+	}
+
+	return walletseed.EncodeMnemonic(seed), nil
+}
+
+// CreateWallet replaces the pre-populated fake wallet data with an empty wallet, provided no wallet exists
+// The seed is only validated, no keys are derived from it
+func (mock *MockWallet) CreateWallet(passphrase, seed string) error {
+	if _, err := walletseed.DecodeUserInput(seed); err != nil {
+		return err
+	}
+
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
+	if mock.walletExists {
+		return fmt.Errorf("wallet already exists")
+	}
+
+	mock.reset(passphrase)
+
+	// wallet is opened after it is created
+	mock.walletOpen = true
+	return nil
+}
+
+func (mock *MockWallet) SaveUnconfirmedSeed(seed, passphrase string) error {
+	return mock.seedBackup.Save(seed, passphrase)
+}
+
+func (mock *MockWallet) HasUnconfirmedSeed() bool {
+	return mock.seedBackup.Pending()
+}
+
+func (mock *MockWallet) UnconfirmedSeed(passphrase string) (string, error) {
+	return mock.seedBackup.Seed(passphrase)
+}
+
+func (mock *MockWallet) ConfirmSeedBackup() error {
+	return mock.seedBackup.Confirm()
+}
+
+// CreateWatchingOnlyWallet replaces the pre-populated fake wallet data with an empty watch-only wallet, provided no wallet exists
+// The extended public key is only validated, no addresses are derived from it
+func (mock *MockWallet) CreateWatchingOnlyWallet(extendedPublicKey string) error {
+	if err := walletcore.ValidateExtendedPublicKey(extendedPublicKey, mock.NetType()); err != nil {
+		return err
+	}
+
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
+	if mock.walletExists {
+		return fmt.Errorf("wallet already exists")
+	}
+
+	mock.reset("")
+	mock.watchingOnly = true
+
+	// wallet is opened after it is created
+	mock.walletOpen = true
+	return nil
+}
+
+func (mock *MockWallet) OpenWallet(publicPassphrase string) error {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
+	if !mock.walletExists {
+		return fmt.Errorf("Wallet does not exist. Please create a wallet first")
+	}
+
+	if publicPassphrase == "" {
+		publicPassphrase = app.DefaultPublicPassphrase
+	}
+	if publicPassphrase != mock.publicPassphrase {
+		return app.ErrInvalidPublicPassphrase
+	}
+
+	mock.walletOpen = true
+	return nil
+}
+
+func (mock *MockWallet) ChangePrivatePassphrase(oldPassphrase, newPassphrase string) error {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
+	if mock.watchingOnly {
+		return walletcore.ErrWatchingOnlyWallet
+	}
+	if oldPassphrase != mock.privatePassphrase {
+		return errInvalidPassphrase
+	}
+
+	mock.privatePassphrase = newPassphrase
+
+	// the unconfirmed seed is encrypted with the private passphrase
+	return mock.seedBackup.ChangePassphrase(oldPassphrase, newPassphrase)
+}
+
+func (mock *MockWallet) ChangePublicPassphrase(oldPassphrase, newPassphrase string) error {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
+	if oldPassphrase != mock.publicPassphrase {
+		return errInvalidPassphrase
+	}
+
+	mock.publicPassphrase = newPassphrase
+	return nil
+}
+
+// CloseWallet does nothing beyond marking the wallet as closed, all wallet data is lost when the program exits
+func (mock *MockWallet) CloseWallet() {
+	mock.mu.Lock()
+	mock.walletOpen = false
+	mock.mu.Unlock()
+}
+
+func (mock *MockWallet) IsWalletOpen() bool {
+	mock.mu.RLock()
+	defer mock.mu.RUnlock()
+	return mock.walletOpen
+}
+
+// SyncBlockChain runs a scripted sync in the background, reporting progress for each sync stage to `listener`
+// The sync mines a new fake block containing any transactions created since the last sync
+func (mock *MockWallet) SyncBlockChain(listener *app.BlockChainSyncListener, showLog bool) error {
+	if !mock.IsWalletOpen() {
+		return fmt.Errorf("wallet is not open")
+	}
+
+	s := &scriptedSync{
+		wallet:   mock,
+		listener: mock.events.SyncListener(listener),
+		showLog:  showLog,
+	}
+
+	go s.run()
+	return nil
+}
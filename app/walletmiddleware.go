@@ -1,22 +1,53 @@
 package app
 
-import "github.com/raedahgroup/godcr/app/walletcore"
+import (
+	"context"
+	"errors"
+
+	"github.com/raedahgroup/godcr/app/walletcore"
+)
+
+// DefaultPublicPassphrase is the public passphrase set for wallets created by godcr.
+// Wallets created elsewhere may use a different public passphrase, which must be passed to OpenWallet
+const DefaultPublicPassphrase = "public"
+
+// ErrInvalidPublicPassphrase is returned by OpenWallet if the wallet's public passphrase is not the one provided.
+// Frontends should ask the user for the wallet's public passphrase and call OpenWallet again
+var ErrInvalidPublicPassphrase = errors.New("invalid public passphrase, the wallet uses a custom public passphrase")
 
 // WalletMiddleware defines key functions for interacting with a decred wallet
 // These functions are implemented by the different mediums that provide access to a decred wallet
 type WalletMiddleware interface {
-	NetType() string
-
 	WalletExists() (bool, error)
 
 	GenerateNewWalletSeed() (string, error)
 
 	CreateWallet(passphrase, seed string) error
 
+	// SaveUnconfirmedSeed keeps the seed of a newly created wallet, encrypted with the wallet's private passphrase,
+	// so that it can be displayed again with UnconfirmedSeed until the user confirms they backed it up with ConfirmSeedBackup
+	SaveUnconfirmedSeed(seed, passphrase string) error
+
+	// HasUnconfirmedSeed returns true if the wallet seed is kept because the user has not confirmed backing it up
+	HasUnconfirmedSeed() bool
+
+	// UnconfirmedSeed returns the seed kept by SaveUnconfirmedSeed, decrypted with the wallet's private passphrase.
+	// walletcore.ErrNoUnconfirmedSeed is returned if the seed's backup was confirmed or the seed was never kept
+	UnconfirmedSeed(passphrase string) (string, error)
+
+	// ConfirmSeedBackup deletes the seed kept by SaveUnconfirmedSeed, after which the seed cannot be displayed again
+	ConfirmSeedBackup() error
+
+	// CreateWatchingOnlyWallet creates a wallet that has no private keys from an account extended public key.
+	// Such wallets can show balances, transaction history and unspent outputs and generate receive addresses,
+	// but operations that require signing fail with walletcore.ErrWatchingOnlyWallet
+	CreateWatchingOnlyWallet(extendedPublicKey string) error
+
 	SyncBlockChain(listener *BlockChainSyncListener, showLog bool) error
 
-	// todo some wallets may not use default public passphrase, in such cases request public passphrase from user to use in OpenWallet
-	OpenWallet() error
+	// OpenWallet opens the wallet with `publicPassphrase`, or with DefaultPublicPassphrase if `publicPassphrase` is empty.
+	// ErrInvalidPublicPassphrase is returned if the wallet uses a different public passphrase
+	OpenWallet(publicPassphrase string) error
 
 	// CloseWallet is triggered whenever the godcr program is about to be terminated
 	// Usually such termination attempts are halted to allow this function perform shutdown and cleanup operations
@@ -24,6 +55,21 @@ type WalletMiddleware interface {
 
 	IsWalletOpen() bool
 
+	// ChangePrivatePassphrase changes the passphrase used to sign transactions and messages.
+	// Watch-only wallets have no private passphrase, walletcore.ErrWatchingOnlyWallet is returned for them
+	ChangePrivatePassphrase(oldPassphrase, newPassphrase string) error
+
+	// ChangePublicPassphrase changes the passphrase used to open the wallet.
+	// Setting a new public passphrase other than DefaultPublicPassphrase requires it to be entered whenever the wallet is opened
+	ChangePublicPassphrase(oldPassphrase, newPassphrase string) error
+
+	// Subscribe returns a channel on which wallet events such as sync progress, new blocks, wallet transactions,
+	// confirmations, ticket status changes and account changes are sent. The channel is closed when ctx is canceled.
+	// Events are typed, use a type switch on the received app.WalletEvent to handle specific events.
+	// Events are dropped for subscribers that do not receive from the channel fast enough,
+	// a DroppedEvent with the number of dropped events is sent once the subscriber catches up.
+	Subscribe(ctx context.Context) (<-chan WalletEvent, error)
+
 	walletcore.Wallet
 }
 
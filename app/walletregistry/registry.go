@@ -0,0 +1,212 @@
+package walletregistry
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/raedahgroup/godcr/app"
+	"github.com/raedahgroup/godcr/app/config"
+	"github.com/raedahgroup/godcr/app/walletcore"
+	"github.com/raedahgroup/godcr/app/walletmediums"
+	"github.com/raedahgroup/godcr/app/walletmediums/dcrlibwallet"
+	"github.com/raedahgroup/godcr/app/walletmediums/dcrwalletrpc"
+	"github.com/raedahgroup/godcr/app/walletmediums/mockwallet"
+)
+
+// Registry holds connections to the wallets defined by wallet profiles and implements `app.WalletMiddleware`
+// by forwarding calls to the currently selected wallet, so that frontends can switch wallets at runtime
+// Wallet functions that are forwarded to the selected wallet are defined in `walletfunctions.go`
+type Registry struct {
+	ctx      context.Context
+	profiles []*config.WalletProfile
+
+	mu             sync.RWMutex
+	wallets        map[string]app.WalletMiddleware
+	currentProfile *config.WalletProfile
+	currentWallet  app.WalletMiddleware
+
+	// events from the selected wallet are forwarded to subscribers of the registry
+	// so subscriptions continue to work after the selected wallet is switched
+	events         walletmediums.EventBroadcaster
+	stopForwarding context.CancelFunc
+}
+
+// New creates a registry for the wallets defined by `profiles` and connects to the wallet of the profile named `selectedProfile`
+// The default wallet profile is selected if `selectedProfile` is empty
+// ctx is used for connecting to dcrwallet rpc and for the lifetime of event subscriptions
+func New(ctx context.Context, profiles []*config.WalletProfile, selectedProfile string) (*Registry, error) {
+	registry := &Registry{
+		ctx:      ctx,
+		profiles: profiles,
+		wallets:  make(map[string]app.WalletMiddleware),
+	}
+
+	if selectedProfile == "" {
+		selectedProfile = config.DefaultWalletProfileName
+	}
+	profile := registry.profile(selectedProfile)
+	if profile == nil {
+		return nil, fmt.Errorf("no wallet profile named %s", selectedProfile)
+	}
+
+	wallet, err := registry.connect(profile)
+	if err != nil {
+		return nil, err
+	}
+	registry.currentProfile = profile
+	registry.currentWallet = wallet
+
+	return registry, nil
+}
+
+// Connect opens a connection to the wallet of `profile` using the medium selected by the profile
+// default medium is dcrlibwallet, alternatives are dcrwalletrpc and an in-memory mock wallet
+func Connect(ctx context.Context, profile *config.WalletProfile) (app.WalletMiddleware, error) {
+	if profile.UseMockWallet && profile.EmptyMockWallet {
+		return mockwallet.NewEmpty(profile.NetType()), nil
+	}
+	if profile.UseMockWallet {
+		return mockwallet.New(profile.NetType()), nil
+	}
+
+	locks, err := walletcore.LoadOutputLocks(profile.LockedOutputsFile())
+	if err != nil {
+		return nil, err
+	}
+	labels, err := walletcore.LoadLabels(profile.LabelsFile())
+	if err != nil {
+		return nil, err
+	}
+	hiddenAccounts, err := walletcore.LoadHiddenAccounts(profile.HiddenAccountsFile())
+	if err != nil {
+		return nil, err
+	}
+	seedBackup, err := walletcore.LoadSeedBackup(profile.SeedBackupFile())
+	if err != nil {
+		return nil, err
+	}
+
+	if !profile.UseWalletRPC {
+		return dcrlibwallet.New(profile.AppDataDir, profile.NetType(), locks, labels, hiddenAccounts, seedBackup), nil
+	}
+
+	walletMiddleware, err := dcrwalletrpc.New(ctx, profile.WalletRPCServer, profile.WalletRPCCert, profile.NoWalletRPCTLS,
+		locks, labels, hiddenAccounts, seedBackup)
+	if err != nil {
+		return nil, fmt.Errorf("Connect to dcrwallet rpc failed: %s", err.Error())
+	}
+	return walletMiddleware, nil
+}
+
+// Profiles returns all wallet profiles known to the registry
+func (registry *Registry) Profiles() []*config.WalletProfile {
+	return registry.profiles
+}
+
+// CurrentProfile returns the profile of the selected wallet
+func (registry *Registry) CurrentProfile() *config.WalletProfile {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	return registry.currentProfile
+}
+
+// SwitchWallet selects the wallet of the profile named `profileName`, connecting to the wallet if not already connected
+// The wallet is opened with the default public passphrase if it exists and is not open.
+// Frontends should open the wallet with the user's public passphrase if it is still not open, then sync the blockchain for the newly selected wallet
+func (registry *Registry) SwitchWallet(profileName string) error {
+	profile := registry.profile(profileName)
+	if profile == nil {
+		return fmt.Errorf("no wallet profile named %s", profileName)
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	wallet, err := registry.connect(profile)
+	if err != nil {
+		return err
+	}
+
+	walletExists, err := wallet.WalletExists()
+	if err != nil {
+		return fmt.Errorf("error checking %s wallet: %s", profile.Name, err.Error())
+	}
+	if walletExists && !wallet.IsWalletOpen() {
+		// wallets with a custom public passphrase are selected without being opened,
+		// frontends ask the user for the public passphrase when they find the wallet is not open
+		err = wallet.OpenWallet("")
+		if err != nil && err != app.ErrInvalidPublicPassphrase {
+			return fmt.Errorf("error opening %s wallet: %s", profile.Name, err.Error())
+		}
+	}
+
+	registry.currentProfile = profile
+	registry.currentWallet = wallet
+	if wallet.IsWalletOpen() {
+		registry.forwardEvents(wallet)
+	} else {
+		registry.stopForwardingEvents()
+	}
+
+	return nil
+}
+
+// connect returns the connection to the wallet of `profile`, connecting to the wallet if not already connected
+// must be called with registry.mu held or before the registry is used
+func (registry *Registry) connect(profile *config.WalletProfile) (app.WalletMiddleware, error) {
+	if wallet, connected := registry.wallets[profile.Name]; connected {
+		return wallet, nil
+	}
+
+	wallet, err := Connect(registry.ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+	registry.wallets[profile.Name] = wallet
+	return wallet, nil
+}
+
+func (registry *Registry) profile(name string) *config.WalletProfile {
+	for _, profile := range registry.profiles {
+		if profile.Name == name {
+			return profile
+		}
+	}
+	return nil
+}
+
+// wallet returns the selected wallet
+func (registry *Registry) wallet() app.WalletMiddleware {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	return registry.currentWallet
+}
+
+// forwardEvents stops forwarding events from the previously selected wallet and starts forwarding events from `wallet`
+// must be called with registry.mu held
+func (registry *Registry) forwardEvents(wallet app.WalletMiddleware) {
+	registry.stopForwardingEvents()
+
+	ctx, cancel := context.WithCancel(registry.ctx)
+	events, err := wallet.Subscribe(ctx)
+	if err != nil {
+		cancel()
+		return
+	}
+	registry.stopForwarding = cancel
+
+	go func() {
+		for event := range events {
+			registry.events.Broadcast(event)
+		}
+	}()
+}
+
+// must be called with registry.mu held
+func (registry *Registry) stopForwardingEvents() {
+	if registry.stopForwarding != nil {
+		registry.stopForwarding()
+		registry.stopForwarding = nil
+	}
+}
@@ -0,0 +1,254 @@
+package walletregistry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/raedahgroup/dcrlibwallet"
+	"github.com/raedahgroup/dcrlibwallet/txhelper"
+	"github.com/raedahgroup/godcr/app"
+	"github.com/raedahgroup/godcr/app/walletcore"
+)
+
+func (registry *Registry) NetType() string {
+	return registry.wallet().NetType()
+}
+
+func (registry *Registry) WalletExists() (bool, error) {
+	return registry.wallet().WalletExists()
+}
+
+func (registry *Registry) GenerateNewWalletSeed() (string, error) {
+	return registry.wallet().GenerateNewWalletSeed()
+}
+
+func (registry *Registry) CreateWallet(passphrase, seed string) error {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	err := registry.currentWallet.CreateWallet(passphrase, seed)
+	if err == nil {
+		registry.forwardEvents(registry.currentWallet)
+	}
+	return err
+}
+
+func (registry *Registry) CreateWatchingOnlyWallet(extendedPublicKey string) error {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	err := registry.currentWallet.CreateWatchingOnlyWallet(extendedPublicKey)
+	if err == nil {
+		registry.forwardEvents(registry.currentWallet)
+	}
+	return err
+}
+
+func (registry *Registry) SaveUnconfirmedSeed(seed, passphrase string) error {
+	return registry.wallet().SaveUnconfirmedSeed(seed, passphrase)
+}
+
+func (registry *Registry) HasUnconfirmedSeed() bool {
+	return registry.wallet().HasUnconfirmedSeed()
+}
+
+func (registry *Registry) UnconfirmedSeed(passphrase string) (string, error) {
+	return registry.wallet().UnconfirmedSeed(passphrase)
+}
+
+func (registry *Registry) ConfirmSeedBackup() error {
+	return registry.wallet().ConfirmSeedBackup()
+}
+
+func (registry *Registry) SyncBlockChain(listener *app.BlockChainSyncListener, showLog bool) error {
+	return registry.wallet().SyncBlockChain(listener, showLog)
+}
+
+func (registry *Registry) OpenWallet(publicPassphrase string) error {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	err := registry.currentWallet.OpenWallet(publicPassphrase)
+	if err == nil {
+		registry.forwardEvents(registry.currentWallet)
+	}
+	return err
+}
+
+// CloseWallet closes all wallets connected through the registry, not just the selected wallet
+func (registry *Registry) CloseWallet() {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	registry.stopForwardingEvents()
+	for _, wallet := range registry.wallets {
+		wallet.CloseWallet()
+	}
+}
+
+func (registry *Registry) IsWalletOpen() bool {
+	return registry.wallet().IsWalletOpen()
+}
+
+func (registry *Registry) ChangePrivatePassphrase(oldPassphrase, newPassphrase string) error {
+	return registry.wallet().ChangePrivatePassphrase(oldPassphrase, newPassphrase)
+}
+
+func (registry *Registry) ChangePublicPassphrase(oldPassphrase, newPassphrase string) error {
+	return registry.wallet().ChangePublicPassphrase(oldPassphrase, newPassphrase)
+}
+
+func (registry *Registry) Subscribe(ctx context.Context) (<-chan app.WalletEvent, error) {
+	if !registry.IsWalletOpen() {
+		return nil, fmt.Errorf("wallet is not open")
+	}
+	return registry.events.Subscribe(ctx), nil
+}
+
+func (registry *Registry) AccountBalance(accountNumber uint32, requiredConfirmations int32) (*walletcore.Balance, error) {
+	return registry.wallet().AccountBalance(accountNumber, requiredConfirmations)
+}
+
+func (registry *Registry) AccountsOverview(requiredConfirmations int32) ([]*walletcore.Account, error) {
+	return registry.wallet().AccountsOverview(requiredConfirmations)
+}
+
+func (registry *Registry) AccountDetails(requiredConfirmations int32) ([]*walletcore.AccountDetails, error) {
+	return registry.wallet().AccountDetails(requiredConfirmations)
+}
+
+func (registry *Registry) RenameAccount(accountNumber uint32, newName string) error {
+	return registry.wallet().RenameAccount(accountNumber, newName)
+}
+
+func (registry *Registry) HideAccount(accountNumber uint32, hide bool) error {
+	return registry.wallet().HideAccount(accountNumber, hide)
+}
+
+func (registry *Registry) NextAccount(accountName string, passphrase string) (uint32, error) {
+	return registry.wallet().NextAccount(accountName, passphrase)
+}
+
+func (registry *Registry) AccountNumber(accountName string) (uint32, error) {
+	return registry.wallet().AccountNumber(accountName)
+}
+
+func (registry *Registry) AccountName(accountNumber uint32) (string, error) {
+	return registry.wallet().AccountName(accountNumber)
+}
+
+func (registry *Registry) AddressInfo(address string) (*txhelper.AddressInfo, error) {
+	return registry.wallet().AddressInfo(address)
+}
+
+func (registry *Registry) ValidateAddress(address string) (bool, error) {
+	return registry.wallet().ValidateAddress(address)
+}
+
+func (registry *Registry) ReceiveAddress(account uint32) (string, error) {
+	return registry.wallet().ReceiveAddress(account)
+}
+
+func (registry *Registry) GenerateNewAddress(account uint32) (string, error) {
+	return registry.wallet().GenerateNewAddress(account)
+}
+
+func (registry *Registry) ChangeAddress(account uint32) (string, error) {
+	return registry.wallet().ChangeAddress(account)
+}
+
+func (registry *Registry) UnspentOutputs(account uint32, targetAmount int64, requiredConfirmations int32) ([]*walletcore.UnspentOutput, error) {
+	return registry.wallet().UnspentOutputs(account, targetAmount, requiredConfirmations)
+}
+
+func (registry *Registry) LockUnspentOutputs(outputKeys []string) error {
+	return registry.wallet().LockUnspentOutputs(outputKeys)
+}
+
+func (registry *Registry) UnlockUnspentOutputs(outputKeys []string) error {
+	return registry.wallet().UnlockUnspentOutputs(outputKeys)
+}
+
+func (registry *Registry) LockedUnspentOutputs() ([]string, error) {
+	return registry.wallet().LockedUnspentOutputs()
+}
+
+func (registry *Registry) SetLabel(labelType, ref, label string) error {
+	return registry.wallet().SetLabel(labelType, ref, label)
+}
+
+func (registry *Registry) Labels() ([]*walletcore.Label, error) {
+	return registry.wallet().Labels()
+}
+
+func (registry *Registry) ImportLabels(labels []*walletcore.Label) error {
+	return registry.wallet().ImportLabels(labels)
+}
+
+func (registry *Registry) SendFromUTXOs(sourceAccount uint32, requiredConfirmations int32, utxoKeys []string, txDestinations []txhelper.TransactionDestination, changeDestinations []txhelper.TransactionDestination, passphrase string) (string, error) {
+	return registry.wallet().SendFromUTXOs(sourceAccount, requiredConfirmations, utxoKeys, txDestinations, changeDestinations, passphrase)
+}
+
+func (registry *Registry) EstimateTransaction(sourceAccount uint32, requiredConfirmations int32, destinations []txhelper.TransactionDestination,
+	utxoKeys []string, coinSelection string, feeRate dcrutil.Amount) (*walletcore.TransactionEstimate, error) {
+	return registry.wallet().EstimateTransaction(sourceAccount, requiredConfirmations, destinations, utxoKeys, coinSelection, feeRate)
+}
+
+func (registry *Registry) EstimateSweepTransaction(sourceAccount uint32, requiredConfirmations int32, destinationAddress string,
+	utxoKeys []string, feeRate dcrutil.Amount) (*walletcore.TransactionEstimate, error) {
+	return registry.wallet().EstimateSweepTransaction(sourceAccount, requiredConfirmations, destinationAddress, utxoKeys, feeRate)
+}
+
+func (registry *Registry) CreateUnsignedTransaction(sourceAccount uint32, requiredConfirmations int32, destinations []txhelper.TransactionDestination,
+	utxoKeys []string, changeDestinations []txhelper.TransactionDestination) (*walletcore.OfflineTransaction, error) {
+	return registry.wallet().CreateUnsignedTransaction(sourceAccount, requiredConfirmations, destinations, utxoKeys, changeDestinations)
+}
+
+func (registry *Registry) SignTransaction(unsignedTx *walletcore.OfflineTransaction, passphrase string) (*walletcore.OfflineTransaction, error) {
+	return registry.wallet().SignTransaction(unsignedTx, passphrase)
+}
+
+func (registry *Registry) PublishTransaction(signedTx *walletcore.OfflineTransaction) (string, error) {
+	return registry.wallet().PublishTransaction(signedTx)
+}
+
+func (registry *Registry) SignMessage(address, message, passphrase string) (string, error) {
+	return registry.wallet().SignMessage(address, message, passphrase)
+}
+
+func (registry *Registry) VerifyMessage(address, message, signature string) (bool, error) {
+	return registry.wallet().VerifyMessage(address, message, signature)
+}
+
+func (registry *Registry) TransactionHistory(query *walletcore.TransactionHistoryQuery) ([]*walletcore.Transaction, error) {
+	return registry.wallet().TransactionHistory(query)
+}
+
+func (registry *Registry) GetTransaction(transactionHash string) (*walletcore.TransactionDetails, error) {
+	return registry.wallet().GetTransaction(transactionHash)
+}
+
+func (registry *Registry) StakeInfo(ctx context.Context) (*walletcore.StakeInfo, error) {
+	return registry.wallet().StakeInfo(ctx)
+}
+
+func (registry *Registry) Tickets(ctx context.Context, filter *walletcore.TicketFilter) ([]*walletcore.Ticket, error) {
+	return registry.wallet().Tickets(ctx, filter)
+}
+
+func (registry *Registry) RevokeTickets(ctx context.Context, passphrase string) ([]*walletcore.RevokedTicket, error) {
+	return registry.wallet().RevokeTickets(ctx, passphrase)
+}
+
+func (registry *Registry) RevokeTicket(ctx context.Context, ticketHash, passphrase string) (string, error) {
+	return registry.wallet().RevokeTicket(ctx, ticketHash, passphrase)
+}
+
+func (registry *Registry) PurchaseTickets(ctx context.Context, request dcrlibwallet.PurchaseTicketsRequest) ([]string, error) {
+	return registry.wallet().PurchaseTickets(ctx, request)
+}
+
+func (registry *Registry) IsWatchingOnlyWallet() bool {
+	return registry.wallet().IsWatchingOnlyWallet()
+}
@@ -0,0 +1,201 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/raedahgroup/godcr/app/walletcore"
+	"github.com/raedahgroup/godcr/cli/termio"
+)
+
+// AccountsCommand groups the commands for creating, viewing and managing the accounts of the wallet.
+type AccountsCommand struct {
+	commanderStub
+	Create CreateAccountCommand `command:"create" description:"Create a new account"`
+	List   ListAccountsCommand  `command:"list" description:"List all accounts with their balances, address counts and derivation paths, including hidden accounts"`
+	Show   ShowAccountCommand   `command:"show" description:"Show the details of an account"`
+	Rename RenameAccountCommand `command:"rename" description:"Rename an account"`
+	Hide   HideAccountCommand   `command:"hide" description:"Hide an account from balance, send and receive account lists"`
+	Unhide UnhideAccountCommand `command:"unhide" description:"Show a hidden account in balance, send and receive account lists again"`
+}
+
+// accountArg identifies an account by its name or number
+type accountArg struct {
+	Account string `positional-arg-name:"account name or number" required:"yes"`
+}
+
+// ListAccountsCommand lists the details of all accounts.
+type ListAccountsCommand struct {
+	commanderStub
+}
+
+// Run runs the `accounts list` command.
+func (l ListAccountsCommand) Run(ctx context.Context, wallet walletcore.Wallet) error {
+	accounts, err := wallet.AccountDetails(walletcore.DefaultRequiredConfirmations)
+	if err != nil {
+		return err
+	}
+
+	columns := []string{
+		"Number", "Name", "Total (DCR)", "Spendable (DCR)", "Receive Addresses",
+		"Change Addresses", "Imported Keys", "Derivation Path", "Hidden",
+	}
+	rows := make([][]interface{}, len(accounts))
+	for i, account := range accounts {
+		rows[i] = []interface{}{
+			account.Number,
+			account.Name,
+			account.Balance.Total,
+			account.Balance.Spendable,
+			account.ExternalKeyCount,
+			account.InternalKeyCount,
+			account.ImportedKeyCount,
+			derivationPathText(account),
+			account.Hidden,
+		}
+	}
+
+	if !termio.IsTableOutput() {
+		return termio.PrintFormattedResult(accounts, columns, rows)
+	}
+
+	termio.PrintTabularResult(termio.TabWriter(os.Stdout), columns, rows)
+	return nil
+}
+
+// ShowAccountCommand shows the details of one account.
+type ShowAccountCommand struct {
+	commanderStub
+	Args accountArg `positional-args:"yes"`
+}
+
+// Run runs the `accounts show` command.
+func (s ShowAccountCommand) Run(ctx context.Context, wallet walletcore.Wallet) error {
+	account, err := findAccount(wallet, s.Args.Account)
+	if err != nil {
+		return err
+	}
+
+	if !termio.IsTableOutput() {
+		columns := []string{"Number", "Name", "Total (DCR)", "Spendable (DCR)", "Derivation Path", "Hidden"}
+		rows := [][]interface{}{{
+			account.Number, account.Name, account.Balance.Total, account.Balance.Spendable, derivationPathText(account), account.Hidden,
+		}}
+		return termio.PrintFormattedResult(account, columns, rows)
+	}
+
+	output := strings.Builder{}
+	output.WriteString(fmt.Sprintf("Name\t%s\n", account.Name))
+	output.WriteString(fmt.Sprintf("Number\t%d\n", account.Number))
+	output.WriteString(fmt.Sprintf("Total\t%s\n", account.Balance.Total))
+	output.WriteString(fmt.Sprintf("Spendable\t%s\n", account.Balance.Spendable))
+	output.WriteString(fmt.Sprintf("Locked By Tickets\t%s\n", account.Balance.LockedByTickets))
+	output.WriteString(fmt.Sprintf("Voting Authority\t%s\n", account.Balance.VotingAuthority))
+	output.WriteString(fmt.Sprintf("Unconfirmed\t%s\n", account.Balance.Unconfirmed))
+	output.WriteString(fmt.Sprintf("Receive Addresses\t%d\n", account.ExternalKeyCount))
+	output.WriteString(fmt.Sprintf("Change Addresses\t%d\n", account.InternalKeyCount))
+	output.WriteString(fmt.Sprintf("Imported Keys\t%d\n", account.ImportedKeyCount))
+	output.WriteString(fmt.Sprintf("Derivation Path\t%s\n", derivationPathText(account)))
+	output.WriteString(fmt.Sprintf("Hidden\t%t", account.Hidden))
+	termio.PrintStringResult(output.String())
+	return nil
+}
+
+// RenameAccountCommand renames an account.
+type RenameAccountCommand struct {
+	commanderStub
+	Args struct {
+		Account string `positional-arg-name:"account name or number" required:"yes"`
+		NewName string `positional-arg-name:"new name" required:"yes"`
+	} `positional-args:"yes"`
+}
+
+// Run runs the `accounts rename` command.
+func (r RenameAccountCommand) Run(ctx context.Context, wallet walletcore.Wallet) error {
+	account, err := findAccount(wallet, r.Args.Account)
+	if err != nil {
+		return err
+	}
+
+	if err = wallet.RenameAccount(account.Number, r.Args.NewName); err != nil {
+		return err
+	}
+
+	fmt.Printf("Account %s renamed to %s\n", account.Name, r.Args.NewName)
+	return nil
+}
+
+// HideAccountCommand hides an account from account lists.
+type HideAccountCommand struct {
+	commanderStub
+	Args accountArg `positional-args:"yes"`
+}
+
+// Run runs the `accounts hide` command.
+func (h HideAccountCommand) Run(ctx context.Context, wallet walletcore.Wallet) error {
+	return setAccountHidden(wallet, h.Args.Account, true)
+}
+
+// UnhideAccountCommand shows a hidden account in account lists again.
+type UnhideAccountCommand struct {
+	commanderStub
+	Args accountArg `positional-args:"yes"`
+}
+
+// Run runs the `accounts unhide` command.
+func (u UnhideAccountCommand) Run(ctx context.Context, wallet walletcore.Wallet) error {
+	return setAccountHidden(wallet, u.Args.Account, false)
+}
+
+func setAccountHidden(wallet walletcore.Wallet, accountArg string, hide bool) error {
+	account, err := findAccount(wallet, accountArg)
+	if err != nil {
+		return err
+	}
+
+	if err = wallet.HideAccount(account.Number, hide); err != nil {
+		return err
+	}
+
+	if hide {
+		fmt.Printf("Account %s is now hidden, show it again with `accounts unhide %d`\n", account.Name, account.Number)
+	} else {
+		fmt.Printf("Account %s is no longer hidden\n", account.Name)
+	}
+	return nil
+}
+
+// findAccount returns the details of the account whose name or number is `accountArg`, preferring a match by name
+func findAccount(wallet walletcore.Wallet, accountArg string) (*walletcore.AccountDetails, error) {
+	accounts, err := wallet.AccountDetails(walletcore.DefaultRequiredConfirmations)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, account := range accounts {
+		if account.Name == accountArg {
+			return account, nil
+		}
+	}
+	if accountNumber, err := strconv.ParseUint(accountArg, 10, 32); err == nil {
+		for _, account := range accounts {
+			if account.Number == uint32(accountNumber) {
+				return account, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no account named or numbered %s", accountArg)
+}
+
+func derivationPathText(account *walletcore.AccountDetails) string {
+	if account.DerivationPath != "" {
+		return account.DerivationPath
+	}
+	if account.Number == walletcore.ImportedAccountNumber {
+		return "imported keys"
+	}
+	return "unknown"
+}
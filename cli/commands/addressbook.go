@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/raedahgroup/godcr/app"
+	"github.com/raedahgroup/godcr/app/addressbook"
+	"github.com/raedahgroup/godcr/cli/termio"
+)
+
+// AddressBookCommand groups the commands for managing the contacts in the address book of the wallet's network.
+// Contacts can be used as send destinations, e.g. --to=@alice:1.5
+type AddressBookCommand struct {
+	commanderStub
+	List   ListContactsCommand  `command:"list" description:"List the contacts in the address book"`
+	Add    AddContactCommand    `command:"add" description:"Add a contact to the address book"`
+	Edit   EditContactCommand   `command:"edit" description:"Change the name or address of a contact"`
+	Remove RemoveContactCommand `command:"remove" description:"Remove a contact from the address book"`
+}
+
+// ListContactsCommand prints the name and address of each contact.
+type ListContactsCommand struct {
+	commanderStub
+}
+
+// Run runs the `addressbook list` command.
+func (l ListContactsCommand) Run(ctx context.Context, walletMiddleware app.WalletMiddleware) error {
+	book, err := addressbook.Open(walletMiddleware.NetType())
+	if err != nil {
+		return err
+	}
+
+	contacts := book.Contacts()
+	rows := make([][]interface{}, len(contacts))
+	for i, contact := range contacts {
+		rows[i] = []interface{}{contact.Name, contact.Address}
+	}
+
+	columns := []string{"Name", "Address"}
+	if !termio.IsTableOutput() {
+		return termio.PrintFormattedResult(contacts, columns, rows)
+	}
+
+	if len(contacts) == 0 {
+		fmt.Println("The address book is empty, add contacts with `addressbook add <name> <address>`")
+		return nil
+	}
+
+	termio.PrintTabularResult(termio.TabWriter(os.Stdout), columns, rows)
+	return nil
+}
+
+// AddContactCommand saves a new contact to the address book.
+type AddContactCommand struct {
+	commanderStub
+	Args struct {
+		Name    string `positional-arg-name:"name" required:"yes"`
+		Address string `positional-arg-name:"address" required:"yes"`
+	} `positional-args:"yes"`
+}
+
+// Run runs the `addressbook add` command.
+func (a AddContactCommand) Run(ctx context.Context, walletMiddleware app.WalletMiddleware) error {
+	book, err := addressbook.Open(walletMiddleware.NetType())
+	if err != nil {
+		return err
+	}
+
+	if err = book.Add(a.Args.Name, a.Args.Address); err != nil {
+		return fmt.Errorf("error adding contact: %s", err.Error())
+	}
+
+	fmt.Printf("Contact %s added, send to it with --to=%s%s:<amount>\n", a.Args.Name, addressbook.ContactPrefix, a.Args.Name)
+	return nil
+}
+
+// EditContactCommand changes the name or address of a contact.
+type EditContactCommand struct {
+	commanderStub
+	Name    string `long:"name" description:"New name for the contact"`
+	Address string `long:"address" description:"New address for the contact"`
+	Args    struct {
+		Name string `positional-arg-name:"name" required:"yes"`
+	} `positional-args:"yes"`
+}
+
+// Run runs the `addressbook edit` command.
+func (e EditContactCommand) Run(ctx context.Context, walletMiddleware app.WalletMiddleware) error {
+	if e.Name == "" && e.Address == "" {
+		return fmt.Errorf("set a new name with --name or a new address with --address")
+	}
+
+	book, err := addressbook.Open(walletMiddleware.NetType())
+	if err != nil {
+		return err
+	}
+
+	if err = book.Edit(e.Args.Name, e.Name, e.Address); err != nil {
+		return fmt.Errorf("error editing contact: %s", err.Error())
+	}
+
+	fmt.Printf("Contact %s updated\n", e.Args.Name)
+	return nil
+}
+
+// RemoveContactCommand removes a contact from the address book.
+type RemoveContactCommand struct {
+	commanderStub
+	Args struct {
+		Name string `positional-arg-name:"name" required:"yes"`
+	} `positional-args:"yes"`
+}
+
+// Run runs the `addressbook remove` command.
+func (r RemoveContactCommand) Run(ctx context.Context, walletMiddleware app.WalletMiddleware) error {
+	book, err := addressbook.Open(walletMiddleware.NetType())
+	if err != nil {
+		return err
+	}
+
+	if err = book.Remove(r.Args.Name); err != nil {
+		return fmt.Errorf("error removing contact: %s", err.Error())
+	}
+
+	fmt.Printf("Contact %s removed\n", r.Args.Name)
+	return nil
+}
@@ -22,6 +22,11 @@ func (balanceCommand BalanceCommand) Run(ctx context.Context, wallet walletcore.
 		return err
 	}
 
+	if !termio.IsTableOutput() {
+		columns, rows := detailedBalanceTable(accounts)
+		return termio.PrintFormattedResult(accounts, columns, rows)
+	}
+
 	if balanceCommand.Detailed {
 		showDetailedBalance(accounts)
 	} else {
@@ -32,7 +37,12 @@ func (balanceCommand BalanceCommand) Run(ctx context.Context, wallet walletcore.
 }
 
 func showDetailedBalance(accountBalances []*walletcore.Account) {
-	columns := []string{
+	columns, rows := detailedBalanceTable(accountBalances)
+	termio.PrintTabularResult(termio.StdoutWriter, columns, rows)
+}
+
+func detailedBalanceTable(accountBalances []*walletcore.Account) (columns []string, rows [][]interface{}) {
+	columns = []string{
 		"Account",
 		"Total",
 		"Spendable",
@@ -40,7 +50,7 @@ func showDetailedBalance(accountBalances []*walletcore.Account) {
 		"Voting Authority",
 		"Unconfirmed",
 	}
-	rows := make([][]interface{}, len(accountBalances))
+	rows = make([][]interface{}, len(accountBalances))
 	for i, account := range accountBalances {
 		rows[i] = []interface{}{
 			account.Name,
@@ -51,8 +61,7 @@ func showDetailedBalance(accountBalances []*walletcore.Account) {
 			account.Balance.Unconfirmed,
 		}
 	}
-
-	termio.PrintTabularResult(termio.StdoutWriter, columns, rows)
+	return
 }
 
 func showBalanceSummary(accounts []*walletcore.Account) {
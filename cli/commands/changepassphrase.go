@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/raedahgroup/godcr/app"
+	"github.com/raedahgroup/godcr/app/walletcore"
+	"github.com/raedahgroup/godcr/cli/termio/terminalprompt"
+	"github.com/raedahgroup/godcr/cli/walletloader"
+)
+
+// PassphraseCommand changes the spending passphrase of the wallet, or its public passphrase if --public is set.
+// It is run as `changepassphrase`.
+type PassphraseCommand struct {
+	commanderStub
+	Public bool `long:"public" description:"Change the public passphrase used to open the wallet instead of the spending passphrase"`
+}
+
+// Run opens the wallet, asks for the current and new passphrases and changes the passphrase.
+func (c PassphraseCommand) Run(ctx context.Context, walletMiddleware app.WalletMiddleware) error {
+	walletExists, err := walletloader.OpenWallet(ctx, walletMiddleware)
+	if err != nil || !walletExists {
+		return err
+	}
+
+	passphraseName := "spending passphrase"
+	if c.Public {
+		passphraseName = "public passphrase"
+	} else if walletMiddleware.IsWatchingOnlyWallet() {
+		// watch-only wallets have no spending passphrase, fail before asking for passphrases
+		return walletcore.ErrWatchingOnlyWallet
+	}
+
+	oldPassphrase, err := terminalprompt.RequestInputSecure(fmt.Sprintf("Current %s", passphraseName), terminalprompt.EmptyValidator)
+	if err != nil {
+		return fmt.Errorf("error receiving input: %s", err.Error())
+	}
+	newPassphrase, err := terminalprompt.RequestInputSecure(fmt.Sprintf("New %s", passphraseName), terminalprompt.EmptyValidator)
+	if err != nil {
+		return fmt.Errorf("error receiving input: %s", err.Error())
+	}
+	confirmPassphrase, err := terminalprompt.RequestInputSecure(fmt.Sprintf("Confirm new %s", passphraseName), terminalprompt.EmptyValidator)
+	if err != nil {
+		return fmt.Errorf("error receiving input: %s", err.Error())
+	}
+	if newPassphrase != confirmPassphrase {
+		return fmt.Errorf("passphrases do not match")
+	}
+
+	if c.Public {
+		err = walletMiddleware.ChangePublicPassphrase(oldPassphrase, newPassphrase)
+	} else {
+		err = walletMiddleware.ChangePrivatePassphrase(oldPassphrase, newPassphrase)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("The %s was changed\n", passphraseName)
+	if c.Public && newPassphrase != app.DefaultPublicPassphrase {
+		fmt.Println("You will be asked for the new public passphrase whenever the wallet is opened")
+	}
+	return nil
+}
@@ -9,14 +9,32 @@ import (
 // AvailableCommands defines thoroughly-tested commands and options available on the cli
 type AvailableCommands struct {
 	CreateWallet    CreateWalletCommand    `command:"createwallet" description:"Creates a new decred testnet or mainnet wallet" long-description:"Creates a new decred testnet or mainnet wallet. A wallet seed will be generated for the new wallet which must be stored securely. You'll also be asked to set a password for the wallet"`
+	RestoreWallet   RestoreWalletCommand   `command:"restorewallet" description:"Restores a decred testnet or mainnet wallet from its seed" long-description:"Restores an existing decred wallet from its 33-word seed or hex seed. You'll be asked to set a new password for the restored wallet. The blockchain is synced afterwards to find the wallet's addresses and transactions"`
+	CreateWatchOnly CreateWatchOnlyCommand `command:"createwatchonly" description:"Creates a watch-only wallet from an account extended public key" long-description:"Creates a wallet that has no private keys from an account extended public key (xpub). A watch-only wallet can show balances, transaction history and unspent outputs and generate receive addresses, but cannot send funds or purchase tickets"`
+	SeedBackup      SeedBackupCommand      `command:"seedbackup" description:"Display the seed of a new wallet again and verify your backup of it" long-description:"If you skipped verifying your seed backup when the wallet was created, godcr keeps the seed encrypted with your spending passphrase. This command displays it after asking for the spending passphrase, then asks for some of the seed words. Once the backup is verified, the seed is deleted and cannot be displayed again"`
+	Passphrase      PassphraseCommand      `command:"changepassphrase" description:"Change the spending passphrase or, with --public, the public passphrase of the wallet" long-description:"Asks for the current passphrase and the new passphrase. The spending passphrase is used to send funds, purchase tickets and sign messages. The public passphrase is used to open the wallet; if it is not the default, godcr asks for it whenever the wallet is opened"`
 	Balance         BalanceCommand         `command:"balance" description:"Show total balance for each account in wallet" long-description:"Also shows spendable balance if different from total balance"`
+	Accounts        AccountsCommand        `command:"accounts" description:"Create, list, show, rename, hide or unhide wallet accounts" long-description:"Shows the balance, receive and change address counts and key derivation path of each account, including accounts hidden from the balance, send and receive account lists. Hidden accounts are saved for each wallet profile in godcr's app data directory. The imported account is hidden while its balance is zero"`
 	Send            SendCommand            `command:"send" description:"Send a transaction"`
 	Receive         ReceiveCommand         `command:"receive" description:"Show your address to receive funds"`
+	CreateTx        CreateTxCommand        `command:"createtx" description:"Create an unsigned transaction and save it to a file" long-description:"Creates a transaction without signing it and saves it to a file. Watch-only wallets can create unsigned transactions. Sign the transaction with signtx on a wallet that holds the private keys, then publish it with broadcasttx"`
+	SignTx          SignTxCommand          `command:"signtx" description:"Sign a transaction created with createtx without publishing it" long-description:"Signs an unsigned transaction saved by createtx and saves the signed transaction to a file. The wallet does not need to be synced, so this can be done on an air-gapped machine"`
+	BroadcastTx     BroadcastTxCommand     `command:"broadcasttx" description:"Publish a transaction signed with signtx"`
+	SignMessage     SignMessageCommand     `command:"signmessage" description:"Sign a message with the private key of a wallet address" long-description:"Proves that you own an address by signing a message with its private key. The base64 signature can be checked by anyone with verifymessage, dcrctl or another decred wallet"`
+	VerifyMessage   VerifyMessageCommand   `command:"verifymessage" description:"Check that a message was signed with the private key of an address" long-description:"Checks a base64 signature created with signmessage, dcrctl or another decred wallet. The address does not need to belong to this wallet. Exits with an error if the signature is not valid"`
 	History         HistoryCommand         `command:"history" description:"Show your transaction history"`
+	Export          ExportCommand          `command:"export" description:"Export your transaction history as csv or ofx" long-description:"Export your transaction history as csv or ofx for use in accounting software. Use the history filter options to export transactions for a particular period"`
 	ShowTransaction ShowTransactionCommand `command:"showtransaction" description:"Show details of a transaction"`
 	Help            HelpCommand            `command:"help" description:"Show general application help. Run help <command-name> to get help message for a specific command"`
 	StakeInfo       StakeInfoCommand       `command:"stakeinfo" description:"Show information about the wallet stakes, tickets and their statuses"`
+	Tickets         TicketsCommand         `command:"tickets" description:"List the wallet tickets with their status, price, purchase height, vote and reward" long-description:"Lists tickets newest first, unmined tickets being the newest. Use --status and --account to show only some tickets"`
 	PurchaseTickets PurchaseTicketsCommand `command:"purchasetickets" description:"Purchase one or more tickets"`
+	RevokeTickets   RevokeTicketsCommand   `command:"revoketickets" description:"Revoke missed and expired tickets to recover their funds" long-description:"Revokes the tickets whose hashes are given, or all missed and expired tickets if no hashes are given, and lists the revoked tickets with the hashes of their revocations. The ticket price, less the revocation fee, is returned to the addresses that purchased the ticket"`
+	Watch           WatchCommand           `command:"watch" description:"Sync the blockchain and print wallet activity as it happens" long-description:"Keeps running after the blockchain is synced, printing a line for each new block, wallet transaction, confirmation milestone, ticket status change and account change. Use --output=json to print events as json lines and --exec to run a command for each event"`
+	AddressBook     AddressBookCommand     `command:"addressbook" description:"List, add, edit or remove address book contacts" long-description:"Save the addresses you send to under a name and send to them with --to=@name:<amount>. History and transaction details show contact names next to their addresses. Mainnet and testnet wallets have separate address books, saved in godcr's app data directory"`
+	Label           LabelCommand           `command:"label" description:"Set, list, export or import labels for transactions, addresses and outputs" long-description:"Labels are notes about why a payment was made or where funds came from. They are shown in history, transaction details, unspent output lists and history exports. Labels are saved for each wallet profile in godcr's app data directory, in the BIP-329 json lines format that export and import also use"`
+	Utxo            UtxoCommand            `command:"utxo" description:"Lock, unlock or list locked unspent outputs" long-description:"Locked unspent outputs are not spent by send, sendcustom, createtx or purchasetickets until they are unlocked. Identify outputs by their key, txhash:index. Locks are saved in godcr's app data directory"`
+	Wallets         WalletsCommand         `command:"wallets" description:"List, add or remove the wallet profiles set in the config file" long-description:"Manage named wallet profiles, allowing godcr to work with several wallets. Use the global --wallet=<name> option to open the wallet of a profile"`
 }
 
 // ExperimentalCommands defines experimental commands and options available on the cli
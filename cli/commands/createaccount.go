@@ -5,8 +5,10 @@ import (
 	"fmt"
 
 	"github.com/raedahgroup/godcr/app/walletcore"
+	"github.com/raedahgroup/godcr/cli/termio"
 )
 
+// CreateAccountCommand creates a new account in the wallet, it is run as `accounts create`.
 type CreateAccountCommand struct {
 	commanderStub
 	Args CreateAccountArgs `positional-args:"yes"`
@@ -16,16 +18,29 @@ type CreateAccountArgs struct {
 }
 
 func (c CreateAccountCommand) Run(ctx context.Context, wallet walletcore.Wallet) error {
+	// watch-only wallets cannot sign, fail before asking for a passphrase
+	if wallet.IsWatchingOnlyWallet() {
+		return walletcore.ErrWatchingOnlyWallet
+	}
+
 	passphrase, err := getWalletPassphrase()
 	if err != nil {
 		return err
 	}
 
-	_, err = wallet.NextAccount(c.Args.AccountName, passphrase)
+	accountNumber, err := wallet.NextAccount(c.Args.AccountName, passphrase)
 	if err != nil {
 		return err
 	}
 
+	if !termio.IsTableOutput() {
+		result := &walletcore.Account{
+			Name:   c.Args.AccountName,
+			Number: accountNumber,
+		}
+		return termio.PrintFormattedResult(result, []string{"Name", "Number"}, [][]interface{}{{result.Name, result.Number}})
+	}
+
 	fmt.Println("Account created successfully")
 	return nil
 }
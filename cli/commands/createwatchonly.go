@@ -0,0 +1,21 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/raedahgroup/godcr/app"
+	"github.com/raedahgroup/godcr/cli/walletloader"
+)
+
+type CreateWatchOnlyCommand struct {
+	commanderStub
+	Args struct {
+		ExtendedPublicKey string `positional-arg-name:"extended-public-key"`
+	} `positional-args:"yes"`
+}
+
+func (c CreateWatchOnlyCommand) Run(ctx context.Context, walletMiddleware app.WalletMiddleware) error {
+	// any errors encountered are printed to terminal directly, no need to return the error to parser
+	walletloader.CreateWatchingOnlyWallet(ctx, walletMiddleware, c.Args.ExtendedPublicKey)
+	return nil
+}
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/raedahgroup/godcr/app/export"
+	"github.com/raedahgroup/godcr/app/walletcore"
+)
+
+// ExportCommand exports transaction history to a file for use in accounting software.
+type ExportCommand struct {
+	commanderStub
+	Format     string `long:"format" description:"Format of the exported history" choice:"csv" choice:"ofx" default:"csv"`
+	OutputFile string `short:"f" long:"file" description:"Path of the file to write the exported history to. The history is printed to stdout if not set"`
+	HistoryFilterOptions
+}
+
+// Run runs the `export` command.
+func (exportCommand ExportCommand) Run(ctx context.Context, wallet walletcore.Wallet) error {
+	query, err := exportCommand.HistoryFilterOptions.historyQuery(wallet)
+	if err != nil {
+		return err
+	}
+
+	var output io.Writer = os.Stdout
+	if exportCommand.OutputFile != "" {
+		file, err := os.Create(exportCommand.OutputFile)
+		if err != nil {
+			return fmt.Errorf("error creating export file: %s", err.Error())
+		}
+		defer file.Close()
+		output = file
+	}
+
+	err = export.TransactionHistory(wallet, query, exportCommand.Format, output)
+	if err != nil {
+		return err
+	}
+
+	if exportCommand.OutputFile != "" {
+		fmt.Fprintf(os.Stderr, "Transaction history exported to %s\n", exportCommand.OutputFile)
+	}
+	return nil
+}
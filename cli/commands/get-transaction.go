@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/decred/dcrd/dcrutil"
+	"github.com/raedahgroup/godcr/app/addressbook"
 	"github.com/raedahgroup/godcr/app/walletcore"
 	"github.com/raedahgroup/godcr/cli/termio"
 )
@@ -27,6 +28,28 @@ func (showTxCommand ShowTransactionCommand) Run(ctx context.Context, wallet wall
 		return err
 	}
 
+	book, err := addressbook.Open(wallet.NetType())
+	if err != nil {
+		return err
+	}
+
+	if !termio.IsTableOutput() {
+		columns := []string{
+			"Hash", "Confirmations", "Block Height", "Type", "Direction", "Amount (DCR)",
+			"Date", "Size", "Fee (DCR)", "Rate (DCR/kB)", "Label",
+		}
+		rows := [][]interface{}{{
+			transaction.Hash, transaction.Confirmations, transaction.BlockHeight, transaction.Type,
+			transaction.Direction, transaction.Amount, transaction.FormattedTime, transaction.Size,
+			transaction.Fee, transaction.FeeRate, transaction.Label,
+		}}
+		result := struct {
+			*walletcore.TransactionDetails
+			Contacts []string `json:"contacts,omitempty"`
+		}{transaction, book.OutputContacts(transaction.Outputs)}
+		return termio.PrintFormattedResult(result, columns, rows)
+	}
+
 	basicOutput := "Hash\t%s\n" +
 		"Confirmations\t%d\n" +
 		"Included in block\t%d\n" +
@@ -50,6 +73,13 @@ func (showTxCommand ShowTransactionCommand) Run(ctx context.Context, wallet wall
 		transaction.Fee,
 		transaction.FeeRate)
 
+	if transaction.Label != "" {
+		basicOutput += fmt.Sprintf("Label\t%s\n", transaction.Label)
+	}
+	if contacts := book.OutputContacts(transaction.Outputs); len(contacts) > 0 {
+		basicOutput += fmt.Sprintf("Contacts\t%s\n", strings.Join(contacts, ", "))
+	}
+
 	if showTxCommand.Detailed {
 		detailedOutput := strings.Builder{}
 		detailedOutput.WriteString("General Info\n")
@@ -59,7 +89,7 @@ func (showTxCommand ShowTransactionCommand) Run(ctx context.Context, wallet wall
 			detailedOutput.WriteString(fmt.Sprintf("%s\t%s\n", dcrutil.Amount(input.AmountIn).String(), input.PreviousOutpoint))
 		}
 		detailedOutput.WriteString("\nOutputs\n")
-		for _, out := range transaction.Outputs {
+		for index, out := range transaction.Outputs {
 			if len(out.Addresses) == 0 {
 				detailedOutput.WriteString(fmt.Sprintf("%s\t (no address)\n", dcrutil.Amount(out.Value).String()))
 				continue
@@ -70,6 +100,12 @@ func (showTxCommand ShowTransactionCommand) Run(ctx context.Context, wallet wall
 				accountName := address.AccountName
 				if !address.IsMine {
 					accountName = "external"
+					if contactName := book.NameForAddress(address.Address); contactName != "" {
+						accountName = "contact " + contactName
+					}
+				}
+				if label := transaction.OutputLabels[index]; label != "" {
+					accountName += ", " + label
 				}
 				detailedOutput.WriteString(fmt.Sprintf("\t%s (%s)\n", address.Address, accountName))
 			}
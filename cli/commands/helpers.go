@@ -3,6 +3,7 @@ package commands
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"math/rand"
 	"sort"
 	"strconv"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/decred/dcrd/dcrutil"
 	"github.com/raedahgroup/dcrlibwallet/txhelper"
+	"github.com/raedahgroup/godcr/app/addressbook"
 	"github.com/raedahgroup/godcr/app/walletcore"
 	"github.com/raedahgroup/godcr/cli/termio/terminalprompt"
 )
@@ -72,13 +74,8 @@ func getSendTxDestinations(wallet walletcore.Wallet) (destinations []txhelper.Tr
 			return errors.New("You did not specify an address. Try again.")
 		}
 
-		isValid, err := wallet.ValidateAddress(address)
-		if err != nil {
-			return fmt.Errorf("error validating address: %s", err.Error())
-		}
-
-		if !isValid {
-			return errors.New("That is not a valid address. Try again.")
+		if _, err := resolveAddress(wallet, address); err != nil {
+			return fmt.Errorf("%s. Try again.", err.Error())
 		}
 		return nil
 	}
@@ -86,20 +83,25 @@ func getSendTxDestinations(wallet walletcore.Wallet) (destinations []txhelper.Tr
 	sendAmountAddressMap := make(map[string]float64)
 
 	for {
-		label := "Destination Address"
+		label := "Destination Address or @contact"
 		if index > 0 {
-			label = fmt.Sprintf("Destination Address %d (or blank to continue)", index+1)
+			label = fmt.Sprintf("Destination Address %d or @contact (or blank to continue)", index+1)
 		}
 
-		destinationAddress, err := terminalprompt.RequestInput(label, validateAddressInput)
+		destination, err := terminalprompt.RequestInput(label, validateAddressInput)
 		if err != nil {
 			return nil, 0, fmt.Errorf("error receiving input: %s", err.Error())
 		}
 
-		if destinationAddress == "" {
+		if destination == "" {
 			break
 		}
 
+		destinationAddress, err := resolveAddress(wallet, destination)
+		if err != nil {
+			return nil, 0, err
+		}
+
 		if _, addressExists := sendAmountAddressMap[destinationAddress]; addressExists {
 			promptMessage := fmt.Sprintf("The address %s has already been added. Do you want to change the amount?", destinationAddress)
 			changeAmountConfirmed, err := terminalprompt.RequestYesNoConfirmation(promptMessage, "N")
@@ -127,6 +129,61 @@ func getSendTxDestinations(wallet walletcore.Wallet) (destinations []txhelper.Tr
 	return
 }
 
+// parseSendTxDestinations parses destinations passed to the send commands as address:amount or @contact:amount values
+func parseSendTxDestinations(wallet walletcore.Wallet, values []string) (destinations []txhelper.TransactionDestination, sendAmountTotal float64, err error) {
+	addedAddresses := make(map[string]bool)
+
+	for _, value := range values {
+		separatorIndex := strings.LastIndex(value, ":")
+		if separatorIndex == -1 {
+			return nil, 0, fmt.Errorf("invalid destination %s, use address:amount or @contact:amount", value)
+		}
+		address, amountStr := value[:separatorIndex], value[separatorIndex+1:]
+
+		address, err := resolveAddress(wallet, address)
+		if err != nil {
+			return nil, 0, err
+		}
+		if addedAddresses[address] {
+			return nil, 0, fmt.Errorf("the address %s was specified more than once", address)
+		}
+
+		amount, err := strconv.ParseFloat(amountStr, 64)
+		if err != nil || amount <= 0 {
+			return nil, 0, fmt.Errorf("invalid amount for destination %s: %s", address, amountStr)
+		}
+
+		addedAddresses[address] = true
+		destinations = append(destinations, txhelper.TransactionDestination{Address: address, Amount: amount})
+		sendAmountTotal += amount
+	}
+	return
+}
+
+// resolveAddress returns the address of the address book contact if `destination` is a contact name such as @alice,
+// otherwise `destination` is returned. An error is returned if the address is not valid
+func resolveAddress(wallet walletcore.Wallet, destination string) (string, error) {
+	address := destination
+	if strings.HasPrefix(destination, addressbook.ContactPrefix) {
+		book, err := addressbook.Open(wallet.NetType())
+		if err != nil {
+			return "", err
+		}
+		if address, err = book.ResolveAddress(destination); err != nil {
+			return "", err
+		}
+	}
+
+	isValid, err := wallet.ValidateAddress(address)
+	if err != nil {
+		return "", fmt.Errorf("error validating address: %s", err.Error())
+	}
+	if !isValid {
+		return "", fmt.Errorf("invalid destination address: %s", address)
+	}
+	return address, nil
+}
+
 // getSendAmount fetches the amout of DCRs to send from the user.
 func getSendAmount() (float64, error) {
 	var amount float64
@@ -154,47 +211,51 @@ func getSendAmount() (float64, error) {
 }
 
 // getChangeOutputDestinations fetches the amount to be sent to each change address
+// the change amount is what is left after paying the fee for the transaction at `feeRate`
 func getChangeOutputDestinations(wallet walletcore.Wallet, totalInputAmount float64, sourceAccount uint32,
-	nUtxoSelection int, sendDestinations []txhelper.TransactionDestination) ([]txhelper.TransactionDestination, error) {
+	nUtxoSelection int, sendDestinations []txhelper.TransactionDestination, feeRate dcrutil.Amount) ([]txhelper.TransactionDestination, error) {
 
 	useRandomChangeAmounts, err := terminalprompt.RequestYesNoConfirmation("Use random amounts for the change outputs?", "y")
 	if err != nil {
 		return nil, fmt.Errorf("error reading your response: %s", err.Error())
 	}
 
+	if useRandomChangeAmounts {
+		nChangeOutputs, err := terminalprompt.RequestNumberInput("How many change outputs would you like to use?", 1)
+		if err != nil {
+			return nil, err
+		}
+		return getChangeDestinationsWithRandomAmounts(wallet, totalInputAmount, sourceAccount,
+			nUtxoSelection, sendDestinations, nChangeOutputs, feeRate)
+	}
+
 	amountInAtom, err := txhelper.AmountToAtom(totalInputAmount)
 	if err != nil {
 		return nil, err
 	}
-
-	if useRandomChangeAmounts {
-		return getChangeDestinationsWithRandomAmounts(wallet, amountInAtom, sourceAccount,
-			nUtxoSelection, sendDestinations)
-	} else {
-		return getChangeDestinationsFromUser(wallet, amountInAtom, sourceAccount,
-			nUtxoSelection, sendDestinations)
-	}
+	return getChangeDestinationsFromUser(wallet, amountInAtom, sourceAccount,
+		nUtxoSelection, sendDestinations, feeRate)
 }
 
-// getChangeDestinationsWithRandomAmounts generates change destination(s) based on the number of change address the user want
-func getChangeDestinationsWithRandomAmounts(wallet walletcore.Wallet, amountInAtom int64, sourceAccount uint32,
-	nUtxoSelection int, sendDestinations []txhelper.TransactionDestination) (changeOutputDestinations []txhelper.TransactionDestination, err error) {
+// getChangeDestinationsWithRandomAmounts generates `nChangeOutputs` change destination(s), splitting the change amount randomly among them
+func getChangeDestinationsWithRandomAmounts(wallet walletcore.Wallet, totalInputAmount float64, sourceAccount uint32, nUtxoSelection int,
+	sendDestinations []txhelper.TransactionDestination, nChangeOutputs int, feeRate dcrutil.Amount) (changeOutputDestinations []txhelper.TransactionDestination, err error) {
 
-	nChangeOutputs, err := terminalprompt.RequestNumberInput("How many change outputs would you like to use?", 1)
+	amountInAtom, err := txhelper.AmountToAtom(totalInputAmount)
 	if err != nil {
-		return
+		return nil, err
 	}
 
 	var changeAddresses []string
 	for i := 0; i < nChangeOutputs; i++ {
-		address, err := wallet.GenerateNewAddress(sourceAccount)
+		address, err := wallet.ChangeAddress(sourceAccount)
 		if err != nil {
 			return nil, fmt.Errorf("error generating address: %s", err.Error())
 		}
 		changeAddresses = append(changeAddresses, address)
 	}
 
-	changeAmount, err := txhelper.EstimateChange(nUtxoSelection, amountInAtom, sendDestinations, changeAddresses)
+	changeAmount, err := walletcore.EstimateChange(nUtxoSelection, dcrutil.Amount(amountInAtom), sendDestinations, len(changeAddresses), feeRate)
 	if err != nil {
 		return nil, fmt.Errorf("error in getting change amount: %s", err.Error())
 	}
@@ -224,23 +285,24 @@ func getChangeDestinationsWithRandomAmounts(wallet walletcore.Wallet, amountInAt
 }
 
 // getChangeDestinationsFromUser fetches change destination from the user progressively until the total available change amount is covered
-func getChangeDestinationsFromUser(wallet walletcore.Wallet, amountInAtom int64, sourceAccount uint32, nUtxoSelection int, sendDestinations []txhelper.TransactionDestination) ([]txhelper.TransactionDestination, error) {
+func getChangeDestinationsFromUser(wallet walletcore.Wallet, amountInAtom int64, sourceAccount uint32, nUtxoSelection int,
+	sendDestinations []txhelper.TransactionDestination, feeRate dcrutil.Amount) ([]txhelper.TransactionDestination, error) {
 	var changeOutputDestinations []txhelper.TransactionDestination
 	var changeAddresses []string
 	var amountAssigned int64
 
 	var index int
 	for {
-		address, err := wallet.GenerateNewAddress(sourceAccount)
+		address, err := wallet.ChangeAddress(sourceAccount)
 		if err != nil {
 			return nil, fmt.Errorf("error in generating address: %s", err.Error())
 		}
 		changeAddresses = append(changeAddresses, address)
-		totalChangeAmount, err := txhelper.EstimateChange(nUtxoSelection, amountInAtom, sendDestinations, changeAddresses)
+		totalChangeAmount, err := walletcore.EstimateChange(nUtxoSelection, dcrutil.Amount(amountInAtom), sendDestinations, len(changeAddresses), feeRate)
 		if err != nil {
 			return nil, err
 		}
-		defaultAmount := dcrutil.Amount(totalChangeAmount - amountAssigned).ToCoin()
+		defaultAmount := (totalChangeAmount - dcrutil.Amount(amountAssigned)).ToCoin()
 
 		prompt := fmt.Sprintf("[%d]: Change Amount (DCR) (default: %f)", index+1, defaultAmount)
 		changeAmount, err := getChangeAmount(prompt)
@@ -308,6 +370,41 @@ func getWalletPassphrase() (string, error) {
 	return result, nil
 }
 
+// readPassphraseFile reads the wallet passphrase from the first line of the file at `path`
+func readPassphraseFile(path string) (string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("error reading passphrase file: %s", err.Error())
+	}
+
+	passphrase := strings.SplitN(string(content), "\n", 2)[0]
+	return strings.TrimRight(passphrase, "\r"), nil
+}
+
+// findUtxos returns the unspent outputs from `utxos` that match the provided output keys and the total amount in the outputs
+// An error is returned if any of the outputs is locked
+func findUtxos(utxos []*walletcore.UnspentOutput, outputKeys []string) (selectedUtxos []*walletcore.UnspentOutput, totalAmountSelected float64, err error) {
+	for _, key := range outputKeys {
+		var found bool
+		for _, utxo := range utxos {
+			if utxo.OutputKey == key {
+				if utxo.Locked {
+					return nil, 0, fmt.Errorf("unspent output %s is locked, unlock it with `utxo unlock %s` to spend it", key, key)
+				}
+				selectedUtxos = append(selectedUtxos, utxo)
+				totalAmountSelected += utxo.Amount.ToCoin()
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, 0, fmt.Errorf("unspent output %s not found in the selected account", key)
+		}
+	}
+	return
+}
+
 // getUtxosForNewTransaction fetches unspent transaction outputs to be used in a transaction.
 func getUtxosForNewTransaction(utxos []*walletcore.UnspentOutput, sendAmount float64) (selectedUtxos []*walletcore.UnspentOutput, totalAmountSelected float64, err error) {
 	var removeWhiteSpace = func(str string) string {
@@ -366,6 +463,9 @@ func getUtxosForNewTransaction(utxos []*walletcore.UnspentOutput, sendAmount flo
 		totalAmountSelected = 0
 		for _, n := range selection {
 			utxo := utxos[n]
+			if utxo.Locked {
+				return fmt.Errorf("Invalid selection. Input %d is locked, unlock it with `utxo unlock %s` to spend it", n+1, utxo.OutputKey)
+			}
 			totalAmountSelected += dcrutil.Amount(utxo.Amount).ToCoin()
 			selectedUtxos = append(selectedUtxos, utxo)
 		}
@@ -384,6 +484,12 @@ func getUtxosForNewTransaction(utxos []*walletcore.UnspentOutput, sendAmount flo
 	for index, utxo := range utxos {
 		date := time.Unix(utxo.ReceiveTime, 0).Format("Mon Jan 2, 2006 3:04PM")
 		options[index] = fmt.Sprintf("%s (%s) \t %s \t %d confirmation(s)", utxo.Address, utxo.Amount.String(), date, utxo.Confirmations)
+		if utxo.Label != "" {
+			options[index] += fmt.Sprintf(" \t %q", utxo.Label)
+		}
+		if utxo.Locked {
+			options[index] += " \t (locked)"
+		}
 	}
 
 	_, err = terminalprompt.RequestSelection("Select input(s) (e.g 1-4,6)", options, validateUtxoSelection)
@@ -393,34 +499,3 @@ func getUtxosForNewTransaction(utxos []*walletcore.UnspentOutput, sendAmount flo
 	}
 	return selectedUtxos, totalAmountSelected, nil
 }
-
-// bestSizedInput returns the smallest output or the least consecutive combination of
-// outputs that can handle a transaction of the supplied sendAmountTotal from the utxos
-func bestSizedInput(utxos []*walletcore.UnspentOutput, sendAmountTotal float64) ([]*walletcore.UnspentOutput, float64) {
-	sort.Slice(utxos, func(i, j int) bool {
-		return utxos[i].Amount < utxos[j].Amount
-	})
-	for _, utxo := range utxos {
-		if utxo.Amount.ToCoin() > sendAmountTotal {
-			return []*walletcore.UnspentOutput{utxo}, utxo.Amount.ToCoin()
-		}
-	}
-	for noOfPairs := 2; noOfPairs <= len(utxos); noOfPairs++ {
-		for i := 0; i < len(utxos); i++ {
-			var accumulatedAmount float64
-			var result []*walletcore.UnspentOutput
-			for j := i; j < i+noOfPairs && j < len(utxos); j++ {
-				result = append(result, utxos[j])
-				accumulatedAmount += utxos[j].Amount.ToCoin()
-				if accumulatedAmount >= sendAmountTotal {
-					return result, accumulatedAmount
-				}
-			}
-		}
-	}
-	var totalInputAmount float64
-	for _, utxo := range utxos {
-		totalInputAmount += utxo.Amount.ToCoin()
-	}
-	return utxos, sendAmountTotal
-}
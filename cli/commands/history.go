@@ -2,7 +2,11 @@ package commands
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/raedahgroup/godcr/app/addressbook"
 	"github.com/raedahgroup/godcr/app/walletcore"
 	"github.com/raedahgroup/godcr/cli/termio"
 )
@@ -10,11 +14,47 @@ import (
 // HistoryCommand enables the user view their transaction history.
 type HistoryCommand struct {
 	commanderStub
+	HistoryFilterOptions
+}
+
+// HistoryFilterOptions holds flags for filtering, sorting and paging transaction history
+type HistoryFilterOptions struct {
+	Accounts   []string `long:"account" description:"Only show transactions to or from this account. Repeat to include multiple accounts"`
+	Directions []string `long:"direction" description:"Only show transactions in this direction. Repeat to include multiple directions" choice:"sent" choice:"received" choice:"transferred"`
+	Types      []string `long:"type" description:"Only show transactions of this type. Repeat to include multiple types" choice:"regular" choice:"coinbase" choice:"ticket" choice:"vote" choice:"revoke"`
+	Since      string   `long:"since" description:"Only show transactions made on or after this date (YYYY-MM-DD)"`
+	Until      string   `long:"until" description:"Only show transactions made on or before this date (YYYY-MM-DD)"`
+	MinAmount  float64  `long:"min-amount" description:"Only show transactions of at least this amount in DCR"`
+	MaxAmount  float64  `long:"max-amount" description:"Only show transactions of at most this amount in DCR"`
+	Sort       string   `long:"sort" description:"Order in which transactions are listed" choice:"newest" choice:"oldest" choice:"largest" choice:"smallest" default:"newest"`
+	Offset     int      `long:"offset" description:"Number of transactions to skip"`
+	Limit      int      `long:"limit" description:"Maximum number of transactions to show. 0 shows all transactions"`
+}
+
+// historyRow is a transaction with the names of the address book contacts it paid
+type historyRow struct {
+	*walletcore.Transaction
+	Contacts []string `json:"contacts,omitempty"`
 }
 
 // Run runs the `history` command.
 func (h HistoryCommand) Run(ctx context.Context, wallet walletcore.Wallet) error {
-	transactions, err := wallet.TransactionHistory()
+	query, err := h.HistoryFilterOptions.historyQuery(wallet)
+	if err != nil {
+		return err
+	}
+
+	transactions, err := wallet.TransactionHistory(query)
+	if err != nil {
+		return err
+	}
+
+	// the wallet applies the offset and limit, so contacts are only looked up for the transactions shown
+	book, err := addressbook.Open(wallet.NetType())
+	if err != nil {
+		return err
+	}
+	transactionContacts, err := book.TransactionContacts(wallet, transactions)
 	if err != nil {
 		return err
 	}
@@ -25,19 +65,87 @@ func (h HistoryCommand) Run(ctx context.Context, wallet walletcore.Wallet) error
 		"Direction",
 		"Hash",
 		"Type",
+		"Contacts",
+		"Label",
 	}
 	rows := make([][]interface{}, len(transactions))
+	historyRows := make([]*historyRow, len(transactions))
 
 	for i, tx := range transactions {
+		contacts := transactionContacts[tx.Hash]
 		rows[i] = []interface{}{
 			tx.FormattedTime,
 			tx.Amount,
 			tx.Direction,
 			tx.Hash,
 			tx.Type,
+			strings.Join(contacts, ", "),
+			tx.Label,
 		}
+		historyRows[i] = &historyRow{Transaction: tx, Contacts: contacts}
+	}
+
+	if !termio.IsTableOutput() {
+		return termio.PrintFormattedResult(historyRows, columns, rows)
 	}
 
 	termio.PrintTabularResult(termio.StdoutWriter, columns, rows)
 	return nil
 }
+
+// historyQuery converts the history filter flags to a walletcore.TransactionHistoryQuery
+func (options HistoryFilterOptions) historyQuery(wallet walletcore.Wallet) (*walletcore.TransactionHistoryQuery, error) {
+	query := &walletcore.TransactionHistoryQuery{
+		SortOrder: options.Sort,
+		Offset:    options.Offset,
+		Limit:     options.Limit,
+	}
+
+	for _, accountName := range options.Accounts {
+		accountNumber, err := wallet.AccountNumber(accountName)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching account number for %s: %s", accountName, err.Error())
+		}
+		query.Accounts = append(query.Accounts, accountNumber)
+	}
+
+	for _, directionName := range options.Directions {
+		direction, err := walletcore.ParseTransactionDirection(directionName)
+		if err != nil {
+			return nil, err
+		}
+		query.Directions = append(query.Directions, direction)
+	}
+
+	for _, typeName := range options.Types {
+		txType, err := walletcore.ParseTransactionType(typeName)
+		if err != nil {
+			return nil, err
+		}
+		query.Types = append(query.Types, txType)
+	}
+
+	var err error
+	if options.Since != "" {
+		if query.StartTime, err = walletcore.ParseHistoryDate(options.Since, false); err != nil {
+			return nil, err
+		}
+	}
+	if options.Until != "" {
+		if query.EndTime, err = walletcore.ParseHistoryDate(options.Until, true); err != nil {
+			return nil, err
+		}
+	}
+
+	if query.MinAmount, err = dcrutil.NewAmount(options.MinAmount); err != nil {
+		return nil, fmt.Errorf("invalid minimum amount: %s", err.Error())
+	}
+	if query.MaxAmount, err = dcrutil.NewAmount(options.MaxAmount); err != nil {
+		return nil, fmt.Errorf("invalid maximum amount: %s", err.Error())
+	}
+
+	if err = query.Validate(); err != nil {
+		return nil, err
+	}
+	return query, nil
+}
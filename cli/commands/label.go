@@ -0,0 +1,170 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/raedahgroup/godcr/app/walletcore"
+	"github.com/raedahgroup/godcr/cli/termio"
+)
+
+// LabelCommand groups the commands for labelling transactions, addresses and unspent outputs.
+// Labels are shown in history, transaction details, unspent output lists and history exports
+type LabelCommand struct {
+	commanderStub
+	Set    SetLabelCommand     `command:"set" description:"Set the label of a transaction, address or output"`
+	Remove RemoveLabelCommand  `command:"remove" description:"Remove the label of a transaction, address or output"`
+	List   ListLabelsCommand   `command:"list" description:"List all labels"`
+	Export ExportLabelsCommand `command:"export" description:"Export all labels in BIP-329 json lines format"`
+	Import ImportLabelsCommand `command:"import" description:"Import labels from a BIP-329 json lines file"`
+}
+
+// labelRefArgs identify the labelled record by its type and ref: a transaction hash, an address or an output key (txhash:index)
+type labelRefArgs struct {
+	Type string `positional-arg-name:"tx|addr|output" required:"yes"`
+	Ref  string `positional-arg-name:"hash|address|txhash:index" required:"yes"`
+}
+
+// SetLabelCommand saves the label of a transaction, address or output.
+type SetLabelCommand struct {
+	commanderStub
+	Args struct {
+		Type  string `positional-arg-name:"tx|addr|output" required:"yes"`
+		Ref   string `positional-arg-name:"hash|address|txhash:index" required:"yes"`
+		Label string `positional-arg-name:"label" required:"yes"`
+	} `positional-args:"yes"`
+}
+
+// Run runs the `label set` command.
+func (s SetLabelCommand) Run(ctx context.Context, wallet walletcore.Wallet) error {
+	if s.Args.Label == "" {
+		return fmt.Errorf("label cannot be empty, use `label remove` to remove a label")
+	}
+
+	if err := wallet.SetLabel(s.Args.Type, s.Args.Ref, s.Args.Label); err != nil {
+		return fmt.Errorf("error setting label: %s", err.Error())
+	}
+
+	fmt.Printf("Label set for %s %s\n", s.Args.Type, s.Args.Ref)
+	return nil
+}
+
+// RemoveLabelCommand removes the label of a transaction, address or output.
+type RemoveLabelCommand struct {
+	commanderStub
+	Args labelRefArgs `positional-args:"yes"`
+}
+
+// Run runs the `label remove` command.
+func (r RemoveLabelCommand) Run(ctx context.Context, wallet walletcore.Wallet) error {
+	if err := wallet.SetLabel(r.Args.Type, r.Args.Ref, ""); err != nil {
+		return fmt.Errorf("error removing label: %s", err.Error())
+	}
+
+	fmt.Printf("Label removed for %s %s\n", r.Args.Type, r.Args.Ref)
+	return nil
+}
+
+// ListLabelsCommand prints all labels, optionally only those of one type.
+type ListLabelsCommand struct {
+	commanderStub
+	Type string `long:"type" description:"Only list labels of this type" choice:"tx" choice:"addr" choice:"output"`
+}
+
+// Run runs the `label list` command.
+func (l ListLabelsCommand) Run(ctx context.Context, wallet walletcore.Wallet) error {
+	allLabels, err := wallet.Labels()
+	if err != nil {
+		return fmt.Errorf("error fetching labels: %s", err.Error())
+	}
+
+	labels := make([]*walletcore.Label, 0, len(allLabels))
+	rows := make([][]interface{}, 0, len(allLabels))
+	for _, label := range allLabels {
+		if l.Type != "" && label.Type != l.Type {
+			continue
+		}
+		labels = append(labels, label)
+		rows = append(rows, []interface{}{label.Type, label.Ref, label.Label})
+	}
+
+	columns := []string{"Type", "Ref", "Label"}
+	if !termio.IsTableOutput() {
+		return termio.PrintFormattedResult(labels, columns, rows)
+	}
+
+	if len(labels) == 0 {
+		fmt.Println("No labels found, add labels with `label set <type> <ref> <label>`")
+		return nil
+	}
+
+	termio.PrintTabularResult(termio.TabWriter(os.Stdout), columns, rows)
+	return nil
+}
+
+// ExportLabelsCommand writes all labels in BIP-329 format, for backup or for use in other wallets.
+type ExportLabelsCommand struct {
+	commanderStub
+	OutputFile string `short:"f" long:"file" description:"Path of the file to write the labels to. The labels are printed to stdout if not set"`
+}
+
+// Run runs the `label export` command.
+func (e ExportLabelsCommand) Run(ctx context.Context, wallet walletcore.Wallet) error {
+	labels, err := wallet.Labels()
+	if err != nil {
+		return fmt.Errorf("error fetching labels: %s", err.Error())
+	}
+
+	var output io.Writer = os.Stdout
+	if e.OutputFile != "" {
+		file, err := os.Create(e.OutputFile)
+		if err != nil {
+			return fmt.Errorf("error creating export file: %s", err.Error())
+		}
+		defer file.Close()
+		output = file
+	}
+
+	if err = walletcore.WriteLabels(labels, output); err != nil {
+		return err
+	}
+
+	if e.OutputFile != "" {
+		fmt.Fprintf(os.Stderr, "%d label(s) exported to %s\n", len(labels), e.OutputFile)
+	}
+	return nil
+}
+
+// ImportLabelsCommand reads labels from a BIP-329 file, replacing existing labels for the same records.
+type ImportLabelsCommand struct {
+	commanderStub
+	Args struct {
+		File string `positional-arg-name:"file" required:"yes"`
+	} `positional-args:"yes"`
+}
+
+// Run runs the `label import` command.
+func (i ImportLabelsCommand) Run(ctx context.Context, wallet walletcore.Wallet) error {
+	file, err := os.Open(i.Args.File)
+	if err != nil {
+		return fmt.Errorf("error opening labels file: %s", err.Error())
+	}
+	defer file.Close()
+
+	labels, skipped, err := walletcore.ReadLabels(file)
+	if err != nil {
+		return err
+	}
+
+	if err = wallet.ImportLabels(labels); err != nil {
+		return fmt.Errorf("error importing labels: %s", err.Error())
+	}
+
+	fmt.Printf("Imported %d label(s)\n", len(labels))
+	if skipped > 0 {
+		fmt.Printf("Skipped %d record(s) of types godcr does not label\n", skipped)
+	}
+	return nil
+}
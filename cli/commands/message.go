@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/raedahgroup/godcr/app/walletcore"
+	"github.com/raedahgroup/godcr/cli/termio"
+)
+
+// SignMessageCommand signs a message with the private key of a wallet address to prove ownership of the address.
+type SignMessageCommand struct {
+	commanderStub
+	PassphraseFile string `long:"passphrase-file" description:"Path to a file containing the spending passphrase"`
+	Args           struct {
+		Address string `positional-arg-name:"address" required:"yes"`
+		Message string `positional-arg-name:"message" required:"yes"`
+	} `positional-args:"yes"`
+}
+
+// Run runs the `signmessage` command.
+func (s SignMessageCommand) Run(ctx context.Context, wallet walletcore.Wallet) error {
+	// watch-only wallets cannot sign, fail before asking for a passphrase
+	if wallet.IsWatchingOnlyWallet() {
+		return walletcore.ErrWatchingOnlyWallet
+	}
+
+	addressInfo, err := wallet.AddressInfo(s.Args.Address)
+	if err != nil {
+		return err
+	}
+	if !addressInfo.IsMine {
+		return fmt.Errorf("address %s does not belong to this wallet", s.Args.Address)
+	}
+
+	var passphrase string
+	if s.PassphraseFile != "" {
+		passphrase, err = readPassphraseFile(s.PassphraseFile)
+	} else {
+		passphrase, err = getWalletPassphrase()
+	}
+	if err != nil {
+		return err
+	}
+
+	signature, err := wallet.SignMessage(s.Args.Address, s.Args.Message, passphrase)
+	if err != nil {
+		return err
+	}
+
+	if !termio.IsTableOutput() {
+		result := map[string]string{
+			"address":   s.Args.Address,
+			"message":   s.Args.Message,
+			"signature": signature,
+		}
+		return termio.PrintFormattedResult(result, []string{"Address", "Message", "Signature"},
+			[][]interface{}{{s.Args.Address, s.Args.Message, signature}})
+	}
+
+	fmt.Println(signature)
+	return nil
+}
+
+// VerifyMessageCommand checks that a message was signed with the private key of an address.
+type VerifyMessageCommand struct {
+	commanderStub
+	Args struct {
+		Address   string `positional-arg-name:"address" required:"yes"`
+		Message   string `positional-arg-name:"message" required:"yes"`
+		Signature string `positional-arg-name:"signature" required:"yes"`
+	} `positional-args:"yes"`
+}
+
+// Run runs the `verifymessage` command.
+// An error is returned if the signature is not valid, so scripts can rely on the exit status.
+func (v VerifyMessageCommand) Run(ctx context.Context, wallet walletcore.Wallet) error {
+	valid, err := wallet.VerifyMessage(v.Args.Address, v.Args.Message, v.Args.Signature)
+	if err != nil {
+		return err
+	}
+
+	if !termio.IsTableOutput() {
+		result := map[string]interface{}{
+			"address": v.Args.Address,
+			"message": v.Args.Message,
+			"valid":   valid,
+		}
+		if err = termio.PrintFormattedResult(result, []string{"Address", "Message", "Valid"},
+			[][]interface{}{{v.Args.Address, v.Args.Message, valid}}); err != nil {
+			return err
+		}
+	} else if valid {
+		fmt.Println("The signature is valid")
+	}
+
+	if !valid {
+		return errors.New("the signature is not valid for this address and message")
+	}
+	return nil
+}
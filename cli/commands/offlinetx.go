@@ -0,0 +1,230 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/raedahgroup/dcrlibwallet/txhelper"
+	"github.com/raedahgroup/godcr/app/walletcore"
+	"github.com/raedahgroup/godcr/cli/termio"
+	"github.com/raedahgroup/godcr/cli/termio/terminalprompt"
+)
+
+// CreateTxCommand creates an unsigned transaction and saves it to a file, to be signed with `signtx`.
+// Watch-only wallets can create unsigned transactions.
+type CreateTxCommand struct {
+	commanderStub
+	SpendUnconfirmed bool     `short:"u" long:"spendunconfirmed" description:"Use unconfirmed outputs for the transaction"`
+	SourceAccount    string   `long:"from" description:"Name of the account to send from"`
+	Destinations     []string `long:"to" description:"Destination address and amount in DCR as address:amount, or @contact:amount to send to an address book contact. Repeat to send to multiple addresses"`
+	Utxos            []string `long:"utxo" description:"Unspent output to spend as txhash:index. Repeat to spend multiple outputs. Inputs are selected automatically if not set"`
+	FeeRate          float64  `long:"feerate" description:"Fee rate in DCR/kB. Defaults to 0.0001 DCR/kB"`
+	Args             struct {
+		File string `positional-arg-name:"unsigned-tx-file" required:"yes"`
+	} `positional-args:"yes"`
+}
+
+// Run creates the unsigned transaction and writes it to the transaction file.
+func (c CreateTxCommand) Run(ctx context.Context, wallet walletcore.Wallet) error {
+	var requiredConfirmations int32 = walletcore.DefaultRequiredConfirmations
+	if c.SpendUnconfirmed {
+		requiredConfirmations = 0
+	}
+
+	feeRate, err := walletcore.ParseFeeRate(c.FeeRate)
+	if err != nil {
+		return err
+	}
+
+	var sourceAccount uint32
+	if c.SourceAccount != "" {
+		sourceAccount, err = wallet.AccountNumber(c.SourceAccount)
+		if err != nil {
+			return fmt.Errorf("error fetching account number: %s", err.Error())
+		}
+	} else {
+		sourceAccount, err = selectAccount(wallet)
+		if err != nil {
+			return err
+		}
+	}
+
+	var sendDestinations []txhelper.TransactionDestination
+	if len(c.Destinations) > 0 {
+		sendDestinations, _, err = parseSendTxDestinations(wallet, c.Destinations)
+	} else {
+		sendDestinations, _, err = getSendTxDestinations(wallet)
+	}
+	if err != nil {
+		return err
+	}
+
+	// the inputs, change and fee are estimated the same way as for `send`, so the change is only created if there is any
+	estimate, err := wallet.EstimateTransaction(sourceAccount, requiredConfirmations, sendDestinations, c.Utxos, walletcore.DefaultCoinSelection, feeRate)
+	if err != nil {
+		return err
+	}
+
+	unsignedTx, err := walletcore.CreateEstimatedTransaction(wallet, estimate)
+	if err != nil {
+		return err
+	}
+
+	if err = walletcore.WriteOfflineTransaction(c.Args.File, unsignedTx); err != nil {
+		return err
+	}
+
+	if err = printOfflineTransaction(unsignedTx); err != nil {
+		return err
+	}
+	if termio.IsTableOutput() {
+		fmt.Printf("Unsigned transaction saved to %s. Sign it with `godcr signtx` on a wallet that holds the private keys\n", c.Args.File)
+	}
+	return nil
+}
+
+// SignTxCommand signs an unsigned transaction created with `createtx` without publishing it.
+// The wallet does not need to be synced, so this can be run on an air-gapped machine.
+type SignTxCommand struct {
+	commanderStub
+	PassphraseFile   string `long:"passphrase-file" description:"Path to a file containing the spending passphrase"`
+	SkipConfirmation bool   `short:"y" long:"yes" description:"Sign the transaction without asking for confirmation"`
+	Args             struct {
+		UnsignedTxFile string `positional-arg-name:"unsigned-tx-file" required:"yes"`
+		SignedTxFile   string `positional-arg-name:"signed-tx-file" required:"yes"`
+	} `positional-args:"yes"`
+}
+
+// Run reads the unsigned transaction, signs it and writes the signed transaction to a file.
+func (s SignTxCommand) Run(ctx context.Context, wallet walletcore.Wallet) error {
+	// watch-only wallets cannot sign, fail before asking for a passphrase
+	if wallet.IsWatchingOnlyWallet() {
+		return walletcore.ErrWatchingOnlyWallet
+	}
+
+	unsignedTx, err := walletcore.ReadOfflineTransaction(s.Args.UnsignedTxFile)
+	if err != nil {
+		return err
+	}
+	if err = unsignedTx.Validate(walletcore.OfflineTransactionUnsigned, wallet.NetType()); err != nil {
+		return err
+	}
+
+	if !s.SkipConfirmation {
+		if err = printOfflineTransaction(unsignedTx); err != nil {
+			return err
+		}
+		if err = confirmOfflineTransaction("Do you want to sign it?"); err != nil {
+			return err
+		}
+	}
+
+	var passphrase string
+	if s.PassphraseFile != "" {
+		passphrase, err = readPassphraseFile(s.PassphraseFile)
+	} else {
+		passphrase, err = getWalletPassphrase()
+	}
+	if err != nil {
+		return err
+	}
+
+	signedTx, err := wallet.SignTransaction(unsignedTx, passphrase)
+	if err != nil {
+		return err
+	}
+
+	if err = walletcore.WriteOfflineTransaction(s.Args.SignedTxFile, signedTx); err != nil {
+		return err
+	}
+
+	if termio.IsTableOutput() {
+		fmt.Printf("Signed transaction saved to %s. Publish it with `godcr broadcasttx` on a synced wallet\n", s.Args.SignedTxFile)
+		return nil
+	}
+
+	hash, _ := signedTx.Hash()
+	result := map[string]string{"hash": hash, "file": s.Args.SignedTxFile}
+	return termio.PrintFormattedResult(result, []string{"Hash", "File"}, [][]interface{}{{hash, s.Args.SignedTxFile}})
+}
+
+// BroadcastTxCommand publishes a transaction signed with `signtx`.
+type BroadcastTxCommand struct {
+	commanderStub
+	SkipConfirmation bool `short:"y" long:"yes" description:"Broadcast the transaction without asking for confirmation"`
+	Args             struct {
+		SignedTxFile string `positional-arg-name:"signed-tx-file" required:"yes"`
+	} `positional-args:"yes"`
+}
+
+// Run reads the signed transaction and publishes it.
+func (b BroadcastTxCommand) Run(ctx context.Context, wallet walletcore.Wallet) error {
+	signedTx, err := walletcore.ReadOfflineTransaction(b.Args.SignedTxFile)
+	if err != nil {
+		return err
+	}
+	if err = signedTx.Validate(walletcore.OfflineTransactionSigned, wallet.NetType()); err != nil {
+		return err
+	}
+
+	if !b.SkipConfirmation {
+		if err = printOfflineTransaction(signedTx); err != nil {
+			return err
+		}
+		if err = confirmOfflineTransaction("Do you want to broadcast it?"); err != nil {
+			return err
+		}
+	}
+
+	txHash, err := wallet.PublishTransaction(signedTx)
+	if err != nil {
+		return err
+	}
+
+	if !termio.IsTableOutput() {
+		result := map[string]string{"hash": txHash}
+		return termio.PrintFormattedResult(result, []string{"Hash"}, [][]interface{}{{txHash}})
+	}
+
+	fmt.Println("Sent txid", txHash)
+	return nil
+}
+
+// printOfflineTransaction prints the inputs, outputs and fee of an offline transaction
+func printOfflineTransaction(offlineTx *walletcore.OfflineTransaction) error {
+	hash, err := offlineTx.Hash()
+	if err != nil {
+		return err
+	}
+
+	columns := []string{"Type", "Address", "Amount"}
+	rows := make([][]interface{}, 0, len(offlineTx.Inputs)+len(offlineTx.Outputs)+1)
+	for _, input := range offlineTx.Inputs {
+		rows = append(rows, []interface{}{"input", input.Address, input.Amount})
+	}
+	for _, output := range offlineTx.Outputs {
+		rows = append(rows, []interface{}{"output", output.Address, output.Amount})
+	}
+	rows = append(rows, []interface{}{"fee", "", offlineTx.Fee})
+
+	if !termio.IsTableOutput() {
+		return termio.PrintFormattedResult(offlineTx, columns, rows)
+	}
+
+	fmt.Printf("%s transaction %s on %s\n", offlineTx.State, hash, offlineTx.Network)
+	termio.PrintTabularResult(termio.TabWriter(os.Stdout), columns, rows)
+	return nil
+}
+
+func confirmOfflineTransaction(prompt string) error {
+	confirmed, err := terminalprompt.RequestYesNoConfirmation(prompt, "")
+	if err != nil {
+		return fmt.Errorf("error reading your response: %s", err.Error())
+	}
+	if !confirmed {
+		return errors.New("transaction canceled")
+	}
+	return nil
+}
@@ -24,6 +24,11 @@ type PurchaseTicketsCommand struct {
 }
 
 func (ptc PurchaseTicketsCommand) Run(ctx context.Context, wallet walletcore.Wallet) error {
+	// watch-only wallets cannot sign, fail before asking for a passphrase
+	if wallet.IsWatchingOnlyWallet() {
+		return walletcore.ErrWatchingOnlyWallet
+	}
+
 	passphrase, err := getWalletPassphrase()
 	if err != nil {
 		return err
@@ -53,6 +58,15 @@ func (ptc PurchaseTicketsCommand) Run(ctx context.Context, wallet walletcore.Wal
 	if len(tickets) == 0 {
 		return fmt.Errorf("no ticket was purchased")
 	}
+
+	if !termio.IsTableOutput() {
+		rows := make([][]interface{}, len(tickets))
+		for i, ticketHash := range tickets {
+			rows[i] = []interface{}{ticketHash}
+		}
+		return termio.PrintFormattedResult(map[string][]string{"ticket_hashes": tickets}, []string{"Ticket Hash"}, rows)
+	}
+
 	output := fmt.Sprintf("You have purchased %d ticket(s)\n%s", len(tickets), strings.Join(tickets, "\n"))
 	termio.PrintStringResult(output)
 
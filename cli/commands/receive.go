@@ -6,6 +6,7 @@ import (
 	"os"
 
 	"github.com/raedahgroup/godcr/app/walletcore"
+	"github.com/raedahgroup/godcr/cli/termio"
 	"github.com/raedahgroup/godcr/cli/termio/terminalprompt"
 	qrcode "github.com/skip2/go-qrcode"
 )
@@ -45,6 +46,15 @@ func (receiveCommand ReceiveCommand) Run(ctx context.Context, wallet walletcore.
 		return err
 	}
 
+	// machine-readable output has no use for the qr code prompt
+	if !termio.IsTableOutput() {
+		result := map[string]interface{}{
+			"account": accountNumber,
+			"address": receiveAddress,
+		}
+		return termio.PrintFormattedResult(result, []string{"Account", "Address"}, [][]interface{}{{accountNumber, receiveAddress}})
+	}
+
 	// Print out address as string
 	fmt.Println(receiveAddress)
 
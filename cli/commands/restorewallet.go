@@ -0,0 +1,18 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/raedahgroup/godcr/app"
+	"github.com/raedahgroup/godcr/cli/walletloader"
+)
+
+type RestoreWalletCommand struct {
+	commanderStub
+}
+
+func (r RestoreWalletCommand) Run(ctx context.Context, walletMiddleware app.WalletMiddleware) error {
+	// any errors encountered are printed to terminal directly, no need to return the error to parser
+	walletloader.RestoreWallet(ctx, walletMiddleware)
+	return nil
+}
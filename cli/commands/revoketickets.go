@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/raedahgroup/godcr/app/walletcore"
+	"github.com/raedahgroup/godcr/cli/termio"
+)
+
+// RevokeTicketsCommand revokes missed and expired tickets, returning the ticket price to the ticket owners.
+type RevokeTicketsCommand struct {
+	commanderStub
+	PassphraseFile string `long:"passphrase-file" description:"Path to a file containing the spending passphrase"`
+	Args           struct {
+		TicketHashes []string `positional-arg-name:"ticket-hash"`
+	} `positional-args:"yes"`
+}
+
+// Run revokes the tickets whose hashes are given as arguments, or all missed and expired tickets if no hashes are given,
+// and reports which tickets were revoked.
+func (r RevokeTicketsCommand) Run(ctx context.Context, wallet walletcore.Wallet) error {
+	// watch-only wallets cannot sign revocations, fail before asking for a passphrase
+	if wallet.IsWatchingOnlyWallet() {
+		return walletcore.ErrWatchingOnlyWallet
+	}
+
+	ticketHashes := r.Args.TicketHashes
+	if len(ticketHashes) == 0 {
+		tickets, err := wallet.Tickets(ctx, &walletcore.TicketFilter{
+			Statuses: []string{walletcore.TicketStatusMissed, walletcore.TicketStatusExpired},
+		})
+		if err != nil {
+			return err
+		}
+		if len(tickets) == 0 {
+			termio.PrintStringResult("no missed or expired tickets to revoke")
+			return nil
+		}
+	} else {
+		for _, ticketHash := range ticketHashes {
+			if err := walletcore.CheckTicketRevokable(ctx, wallet, ticketHash); err != nil {
+				return err
+			}
+		}
+	}
+
+	var passphrase string
+	var err error
+	if r.PassphraseFile != "" {
+		passphrase, err = readPassphraseFile(r.PassphraseFile)
+	} else {
+		passphrase, err = getWalletPassphrase()
+	}
+	if err != nil {
+		return err
+	}
+
+	var revoked []*walletcore.RevokedTicket
+	if len(ticketHashes) == 0 {
+		revoked, err = wallet.RevokeTickets(ctx, passphrase)
+	} else {
+		for _, ticketHash := range ticketHashes {
+			var revocationHash string
+			revocationHash, err = wallet.RevokeTicket(ctx, ticketHash, passphrase)
+			if err != nil {
+				err = fmt.Errorf("error revoking ticket %s: %s", ticketHash, err.Error())
+				break
+			}
+			revoked = append(revoked, &walletcore.RevokedTicket{TicketHash: ticketHash, RevocationHash: revocationHash})
+		}
+	}
+
+	// report the tickets revoked before any error
+	if len(revoked) > 0 {
+		columns := []string{"Ticket Hash", "Revocation Hash"}
+		rows := make([][]interface{}, len(revoked))
+		for i, ticket := range revoked {
+			rows[i] = []interface{}{ticket.TicketHash, ticket.RevocationHash}
+		}
+
+		if !termio.IsTableOutput() {
+			if printErr := termio.PrintFormattedResult(revoked, columns, rows); printErr != nil {
+				return printErr
+			}
+		} else {
+			termio.PrintStringResult(fmt.Sprintf("revoked %d ticket(s):", len(revoked)))
+			termio.PrintTabularResult(termio.StdoutWriter, columns, rows)
+		}
+	}
+	return err
+}
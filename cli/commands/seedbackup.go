@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/raedahgroup/godcr/app"
+	"github.com/raedahgroup/godcr/cli/walletloader"
+)
+
+// SeedBackupCommand displays the seed of a wallet whose seed backup was not verified when the wallet was created
+// and asks the user for some of the seed words to verify the backup.
+type SeedBackupCommand struct {
+	commanderStub
+}
+
+// Run opens the wallet, decrypts the unconfirmed seed with the spending passphrase and verifies the user's backup of the seed.
+// The seed is deleted once the backup is verified.
+func (s SeedBackupCommand) Run(ctx context.Context, walletMiddleware app.WalletMiddleware) error {
+	walletExists, err := walletloader.OpenWallet(ctx, walletMiddleware)
+	if err != nil || !walletExists {
+		return err
+	}
+
+	if !walletMiddleware.HasUnconfirmedSeed() {
+		fmt.Println("The wallet seed backup is already verified, the seed is no longer kept by godcr")
+		return nil
+	}
+
+	passphrase, err := getWalletPassphrase()
+	if err != nil {
+		return err
+	}
+	seed, err := walletMiddleware.UnconfirmedSeed(passphrase)
+	if err != nil {
+		return err
+	}
+
+	verified, err := walletloader.BackUpSeed(seed)
+	if err != nil {
+		return err
+	}
+	if !verified {
+		fmt.Println("Seed backup not verified. Run `godcr seedbackup` again once you have stored the seed")
+		return nil
+	}
+
+	return walletMiddleware.ConfirmSeedBackup()
+}
@@ -6,42 +6,81 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/decred/dcrd/dcrutil"
 	"github.com/raedahgroup/dcrlibwallet/txhelper"
 	"github.com/raedahgroup/godcr/app/walletcore"
+	"github.com/raedahgroup/godcr/cli/termio"
 	"github.com/raedahgroup/godcr/cli/termio/terminalprompt"
 )
 
+// SendOptions holds flags that can be used to provide send transaction details without being prompted for them.
+// Prompts are only displayed for values that are not provided using these flags.
+type SendOptions struct {
+	SpendUnconfirmed bool     `short:"u" long:"spendunconfirmed" description:"Use unconfirmed outputs for send transactions."`
+	SourceAccount    string   `long:"from" description:"Name of the account to send from"`
+	Destinations     []string `long:"to" description:"Destination address and amount in DCR as address:amount, or @contact:amount to send to an address book contact. Repeat to send to multiple addresses"`
+	FeeRate          float64  `long:"feerate" description:"Fee rate in DCR/kB. Defaults to 0.0001 DCR/kB"`
+	CoinSelection    string   `long:"coin-selection" description:"Strategy for selecting the inputs to spend automatically. Defaults to largest-first" choice:"largest-first" choice:"smallest-first" choice:"exact-match" choice:"privacy" choice:"oldest-first"`
+	Max              bool     `long:"max" description:"Send the entire spendable balance of the account, or of the selected inputs, to a single address. The fee is deducted from the amount sent"`
+	PassphraseFile   string   `long:"passphrase-file" description:"Path to a file containing the spending passphrase"`
+	SkipConfirmation bool     `short:"y" long:"yes" description:"Broadcast the transaction without asking for confirmation"`
+}
+
 // SendCommand lets the user send DCR.
 type SendCommand struct {
 	commanderStub
-	SpendUnconfirmed bool `short:"u" long:"spendunconfirmed" description:"Use unconfirmed outputs for send transactions."`
+	SendOptions
 }
 
 // Run runs the `send` command.
 func (s SendCommand) Run(ctx context.Context, wallet walletcore.Wallet) error {
-	return send(wallet, s.SpendUnconfirmed, false)
+	return send(wallet, s.SendOptions, nil)
 }
 
 // SendCustomCommand sends DCR using coin control.
 type SendCustomCommand struct {
 	commanderStub
-	SpendUnconfirmed bool `short:"u" long:"spendunconfirmed" description:"Use unconfirmed outputs for send transactions."`
+	SendOptions
+	CustomSendOptions
+}
+
+// CustomSendOptions holds flags that can be used to select inputs and change outputs for a custom send without being prompted.
+type CustomSendOptions struct {
+	Utxos           []string `long:"utxo" description:"Unspent output to spend as txhash:index. Repeat to spend multiple outputs"`
+	AutoSelectUtxos bool     `long:"auto-select-utxos" description:"Automatically select the unspent outputs to spend"`
+	ChangeOutputs   int      `long:"change-outputs" description:"Number of change outputs to create. The change amount is split randomly among the change outputs"`
 }
 
 // Run runs the `send-custom` command.
 func (s SendCustomCommand) Run(ctx context.Context, wallet walletcore.Wallet) error {
-	return send(wallet, s.SpendUnconfirmed, true)
+	return send(wallet, s.SendOptions, &s.CustomSendOptions)
 }
 
-func send(wallet walletcore.Wallet, spendUnconfirmed bool, custom bool) error {
+// send creates and broadcasts a transaction using the provided options, prompting the user for values not provided
+// if customOptions is not nil, the user gets to select the inputs to spend and the change outputs to create
+func send(wallet walletcore.Wallet, options SendOptions, customOptions *CustomSendOptions) error {
+	// watch-only wallets cannot sign, fail before asking for a passphrase
+	if wallet.IsWatchingOnlyWallet() {
+		return walletcore.ErrWatchingOnlyWallet
+	}
+
 	var requiredConfirmations int32 = walletcore.DefaultRequiredConfirmations
-	if spendUnconfirmed {
+	if options.SpendUnconfirmed {
 		requiredConfirmations = 0
 	}
 
-	sourceAccount, err := selectAccount(wallet)
-	if err != nil {
-		return err
+	var sourceAccount uint32
+	var err error
+	if options.SourceAccount != "" {
+		sourceAccount, err = wallet.AccountNumber(options.SourceAccount)
+		if err != nil {
+			return fmt.Errorf("error fetching account number: %s", err.Error())
+		}
+	} else {
+		sourceAccount, err = selectAccount(wallet)
+		if err != nil {
+			return err
+		}
 	}
 
 	// check if account has positive non-zero balance before proceeding
@@ -55,42 +94,138 @@ func send(wallet walletcore.Wallet, spendUnconfirmed bool, custom bool) error {
 		return fmt.Errorf("Selected account has 0 balance. Cannot proceed")
 	}
 
-	sendDestinations, sendAmountTotal, err := getSendTxDestinations(wallet)
+	feeRate, err := walletcore.ParseFeeRate(options.FeeRate)
 	if err != nil {
 		return err
 	}
 
-	if accountBalance.Spendable.ToCoin() < sendAmountTotal {
-		return fmt.Errorf("Selected account has insufficient balance. Cannot proceed")
-	}
-
 	var sentTxHash string
-	if custom {
-		sentTxHash, err = completeCustomSend(wallet, sourceAccount, sendDestinations, sendAmountTotal, requiredConfirmations)
+	if options.Max {
+		sentTxHash, err = completeMaxSend(wallet, sourceAccount, requiredConfirmations, feeRate, options, customOptions)
 	} else {
-		sentTxHash, err = completeNormalSend(wallet, sourceAccount, sendDestinations, requiredConfirmations)
+		sentTxHash, err = sendToDestinations(wallet, sourceAccount, accountBalance, requiredConfirmations, feeRate, options, customOptions)
 	}
 
 	if err != nil {
 		return err
 	}
 
+	if !termio.IsTableOutput() {
+		result := map[string]string{"hash": sentTxHash}
+		return termio.PrintFormattedResult(result, []string{"Hash"}, [][]interface{}{{sentTxHash}})
+	}
+
 	fmt.Println("Sent txid", sentTxHash)
 	return nil
 }
 
-func completeCustomSend(wallet walletcore.Wallet, sourceAccount uint32, sendDestinations []txhelper.TransactionDestination, sendAmountTotal float64, requiredConfirmations int32) (string, error) {
-	var changeOutputDestinations []txhelper.TransactionDestination
-	var utxoSelection []*walletcore.UnspentOutput
-	var totalInputAmount float64
+// sendToDestinations sends the amounts specified by the user to 1 or more destination addresses
+func sendToDestinations(wallet walletcore.Wallet, sourceAccount uint32, accountBalance *walletcore.Balance, requiredConfirmations int32,
+	feeRate dcrutil.Amount, options SendOptions, customOptions *CustomSendOptions) (string, error) {
 
-	// get all utxos in account, pass 0 amount to get all
-	utxos, err := wallet.UnspentOutputs(sourceAccount, 0, requiredConfirmations)
+	var sendDestinations []txhelper.TransactionDestination
+	var sendAmountTotal float64
+	var err error
+	if len(options.Destinations) > 0 {
+		sendDestinations, sendAmountTotal, err = parseSendTxDestinations(wallet, options.Destinations)
+	} else {
+		sendDestinations, sendAmountTotal, err = getSendTxDestinations(wallet)
+	}
 	if err != nil {
 		return "", err
 	}
 
-	choice, err := terminalprompt.RequestInput("Would you like to (a)utomatically or (m)anually select inputs? (A/m)", func(input string) error {
+	if accountBalance.Spendable.ToCoin() < sendAmountTotal {
+		return "", fmt.Errorf("Selected account has insufficient balance. Cannot proceed")
+	}
+
+	if customOptions != nil {
+		return completeCustomSend(wallet, sourceAccount, sendDestinations, sendAmountTotal, requiredConfirmations, feeRate, options, customOptions)
+	}
+	return completeNormalSend(wallet, sourceAccount, sendDestinations, requiredConfirmations, feeRate, options)
+}
+
+// completeMaxSend sends the entire spendable balance of the account, or of the selected inputs for custom sends, to a single address.
+// The fee is deducted from the amount sent and no change output is created
+func completeMaxSend(wallet walletcore.Wallet, sourceAccount uint32, requiredConfirmations int32, feeRate dcrutil.Amount,
+	options SendOptions, customOptions *CustomSendOptions) (string, error) {
+
+	destinationAddress, err := maxSendDestinationAddress(wallet, options.Destinations)
+	if err != nil {
+		return "", err
+	}
+
+	// all unspent outputs in the account are spent unless the user selects the inputs
+	var utxoKeys []string
+	if customOptions != nil {
+		if customOptions.ChangeOutputs > 0 {
+			return "", errors.New("change outputs cannot be created when sending the maximum amount")
+		}
+		utxoKeys, err = maxSendUtxos(wallet, sourceAccount, requiredConfirmations, customOptions)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	estimate, err := wallet.EstimateSweepTransaction(sourceAccount, requiredConfirmations, destinationAddress, utxoKeys, feeRate)
+	if err != nil {
+		return "", err
+	}
+
+	if !options.SkipConfirmation {
+		printTransactionEstimate(estimate, nil)
+		if err = confirmSend(); err != nil {
+			return "", err
+		}
+	}
+
+	passphrase, err := options.walletPassphrase()
+	if err != nil {
+		return "", err
+	}
+
+	return walletcore.SendEstimatedTransaction(wallet, estimate, passphrase)
+}
+
+// maxSendDestinationAddress returns the address passed with --to or prompts the user for the address to send the maximum amount to
+func maxSendDestinationAddress(wallet walletcore.Wallet, destinations []string) (string, error) {
+	if len(destinations) > 1 {
+		return "", errors.New("the maximum amount can only be sent to one destination address")
+	}
+
+	if len(destinations) == 1 {
+		if strings.Contains(destinations[0], ":") {
+			return "", errors.New("the amount is computed when sending the maximum amount, use --to=<address> without an amount")
+		}
+		return resolveAddress(wallet, destinations[0])
+	}
+
+	validateAddress := func(address string) error {
+		if address == "" {
+			return errors.New("You did not specify an address. Try again.")
+		}
+		_, err := resolveAddress(wallet, address)
+		return err
+	}
+
+	destination, err := terminalprompt.RequestInput("Destination Address or @contact", validateAddress)
+	if err != nil {
+		return "", fmt.Errorf("error receiving input: %s", err.Error())
+	}
+	return resolveAddress(wallet, destination)
+}
+
+// maxSendUtxos returns the keys of the unspent outputs selected with --utxo or prompts the user to select the inputs to spend.
+// A nil slice is returned if all unspent outputs in the account should be spent
+func maxSendUtxos(wallet walletcore.Wallet, sourceAccount uint32, requiredConfirmations int32, customOptions *CustomSendOptions) ([]string, error) {
+	if len(customOptions.Utxos) > 0 {
+		return customOptions.Utxos, nil
+	}
+	if customOptions.AutoSelectUtxos {
+		return nil, nil
+	}
+
+	choice, err := terminalprompt.RequestInput("Would you like to spend (a)ll unspent outputs or (m)anually select inputs? (A/m)", func(input string) error {
 		switch strings.ToLower(input) {
 		case "", "a", "m":
 			return nil
@@ -98,47 +233,104 @@ func completeCustomSend(wallet walletcore.Wallet, sourceAccount uint32, sendDest
 		return errors.New("invalid entry")
 	})
 	if err != nil {
-		return "", fmt.Errorf("error in reading choice: %s", err.Error())
+		return nil, fmt.Errorf("error in reading choice: %s", err.Error())
 	}
-	if strings.ToLower(choice) == "a" || choice == "" {
-		utxoSelection, totalInputAmount = bestSizedInput(utxos, sendAmountTotal)
-	} else {
-		utxoSelection, totalInputAmount, err = getUtxosForNewTransaction(utxos, sendAmountTotal)
-		if err != nil {
-			return "", err
-		}
+	if strings.ToLower(choice) != "m" {
+		return nil, nil
 	}
 
-	changeOutputDestinations, err = getChangeOutputDestinations(wallet, totalInputAmount, sourceAccount,
-		len(utxoSelection), sendDestinations)
+	utxos, err := wallet.UnspentOutputs(sourceAccount, 0, requiredConfirmations)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	utxoSelection, _, err := getUtxosForNewTransaction(utxos, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	utxoKeys := make([]string, len(utxoSelection))
+	for i, utxo := range utxoSelection {
+		utxoKeys[i] = utxo.OutputKey
 	}
+	return utxoKeys, nil
+}
+
+func completeCustomSend(wallet walletcore.Wallet, sourceAccount uint32, sendDestinations []txhelper.TransactionDestination, sendAmountTotal float64,
+	requiredConfirmations int32, feeRate dcrutil.Amount, options SendOptions, customOptions *CustomSendOptions) (string, error) {
+
+	var changeOutputDestinations []txhelper.TransactionDestination
+	var utxoSelection []*walletcore.UnspentOutput
+	var totalInputAmount float64
 
-	passphrase, err := getWalletPassphrase()
+	// get all utxos in account, pass 0 amount to get all
+	utxos, err := wallet.UnspentOutputs(sourceAccount, 0, requiredConfirmations)
 	if err != nil {
 		return "", err
 	}
 
-	fmt.Println("You are about to spend the input(s)")
-	for _, utxo := range utxoSelection {
-		fmt.Println(fmt.Sprintf(" %s \t from %s", utxo.Amount.String(), utxo.Address))
+	if len(customOptions.Utxos) > 0 {
+		utxoSelection, totalInputAmount, err = findUtxos(utxos, customOptions.Utxos)
+		if err != nil {
+			return "", err
+		}
+		if totalInputAmount < sendAmountTotal {
+			return "", errors.New("Total amount from selected inputs is smaller than amount to send")
+		}
+	} else {
+		autoSelect := customOptions.AutoSelectUtxos
+		if !autoSelect {
+			choice, err := terminalprompt.RequestInput("Would you like to (a)utomatically or (m)anually select inputs? (A/m)", func(input string) error {
+				switch strings.ToLower(input) {
+				case "", "a", "m":
+					return nil
+				}
+				return errors.New("invalid entry")
+			})
+			if err != nil {
+				return "", fmt.Errorf("error in reading choice: %s", err.Error())
+			}
+			autoSelect = strings.ToLower(choice) == "a" || choice == ""
+		}
+
+		if autoSelect {
+			utxoSelection, totalInputAmount, err = selectCustomSendInputs(utxos, sendDestinations, options.CoinSelection, feeRate)
+			if err != nil {
+				return "", err
+			}
+		} else {
+			utxoSelection, totalInputAmount, err = getUtxosForNewTransaction(utxos, sendAmountTotal)
+			if err != nil {
+				return "", err
+			}
+		}
 	}
-	fmt.Println("and send")
-	for _, destination := range sendDestinations {
-		fmt.Println(fmt.Sprintf(" %f DCR \t to %s", destination.Amount, destination.Address))
+
+	if customOptions.ChangeOutputs > 0 {
+		changeOutputDestinations, err = getChangeDestinationsWithRandomAmounts(wallet, totalInputAmount, sourceAccount,
+			len(utxoSelection), sendDestinations, customOptions.ChangeOutputs, feeRate)
+	} else {
+		changeOutputDestinations, err = getChangeOutputDestinations(wallet, totalInputAmount, sourceAccount,
+			len(utxoSelection), sendDestinations, feeRate)
 	}
-	for _, destination := range changeOutputDestinations {
-		fmt.Println(fmt.Sprintf(" %f DCR \t to %s (change)", destination.Amount, destination.Address))
+	if err != nil {
+		return "", err
 	}
 
-	sendConfirmed, err := terminalprompt.RequestYesNoConfirmation("Do you want to broadcast it?", "")
-	if err != nil {
-		return "", fmt.Errorf("error reading your response: %s", err.Error())
+	if !options.SkipConfirmation {
+		summary, err := walletcore.SummarizeTransaction(sourceAccount, requiredConfirmations, utxoSelection, sendDestinations, changeOutputDestinations)
+		if err != nil {
+			return "", err
+		}
+		printTransactionEstimate(summary, changeOutputDestinations)
+
+		if err = confirmSend(); err != nil {
+			return "", err
+		}
 	}
 
-	if !sendConfirmed {
-		return "", errors.New("transaction canceled")
+	passphrase, err := options.walletPassphrase()
+	if err != nil {
+		return "", err
 	}
 
 	var outputKeys []string
@@ -148,29 +340,105 @@ func completeCustomSend(wallet walletcore.Wallet, sourceAccount uint32, sendDest
 	return wallet.SendFromUTXOs(sourceAccount, requiredConfirmations, outputKeys, sendDestinations, changeOutputDestinations, passphrase)
 }
 
-func completeNormalSend(wallet walletcore.Wallet, sourceAccount uint32, sendDestinations []txhelper.TransactionDestination, requiredConfirmations int32) (string, error) {
-	passphrase, err := getWalletPassphrase()
+func completeNormalSend(wallet walletcore.Wallet, sourceAccount uint32, sendDestinations []txhelper.TransactionDestination,
+	requiredConfirmations int32, feeRate dcrutil.Amount, options SendOptions) (string, error) {
+
+	estimate, err := wallet.EstimateTransaction(sourceAccount, requiredConfirmations, sendDestinations, nil, options.CoinSelection, feeRate)
 	if err != nil {
 		return "", err
 	}
 
-	if len(sendDestinations) == 1 {
-		fmt.Println(fmt.Sprintf("You are about to send %f DCR to %s", sendDestinations[0].Amount, sendDestinations[0].Address))
-	} else {
-		fmt.Println("You are about to send")
-		for _, destination := range sendDestinations {
-			fmt.Println(fmt.Sprintf(" %f DCR \t to %s", destination.Amount, destination.Address))
+	if !options.SkipConfirmation {
+		printTransactionEstimate(estimate, nil)
+		if err = confirmSend(); err != nil {
+			return "", err
+		}
+	}
+
+	passphrase, err := options.walletPassphrase()
+	if err != nil {
+		return "", err
+	}
+
+	return walletcore.SendEstimatedTransaction(wallet, estimate, passphrase)
+}
+
+// selectCustomSendInputs selects the inputs for a custom send from the unlocked outputs in `utxos` using the `coinSelection` strategy.
+// The inputs cover the destinations and the fee for a transaction with one change output
+func selectCustomSendInputs(utxos []*walletcore.UnspentOutput, sendDestinations []txhelper.TransactionDestination, coinSelection string,
+	feeRate dcrutil.Amount) ([]*walletcore.UnspentOutput, float64, error) {
+
+	var sendAmount dcrutil.Amount
+	for _, destination := range sendDestinations {
+		amount, err := dcrutil.NewAmount(destination.Amount)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid amount for %s: %s", destination.Address, err.Error())
+		}
+		sendAmount += amount
+	}
+
+	unlockedUtxos := make([]*walletcore.UnspentOutput, 0, len(utxos))
+	for _, utxo := range utxos {
+		if !utxo.Locked {
+			unlockedUtxos = append(unlockedUtxos, utxo)
 		}
 	}
 
+	utxoSelection, err := walletcore.SelectCoins(coinSelection, unlockedUtxos, walletcore.SelectionTarget{
+		SendAmount: sendAmount,
+		FeeRate:    feeRate,
+		NumOutputs: len(sendDestinations) + 1,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var totalInputAmount dcrutil.Amount
+	for _, utxo := range utxoSelection {
+		totalInputAmount += utxo.Amount
+	}
+	return utxoSelection, totalInputAmount.ToCoin(), nil
+}
+
+// printTransactionEstimate prints the inputs, outputs, size and fee of a transaction that is about to be sent
+// if the change outputs are not known yet, the estimated change is printed as a single output to a new address
+func printTransactionEstimate(estimate *walletcore.TransactionEstimate, changeDestinations []txhelper.TransactionDestination) {
+	fmt.Println("You are about to spend the input(s)")
+	for _, utxo := range estimate.Inputs {
+		fmt.Printf(" %s \t from %s\n", utxo.Amount.String(), utxo.Address)
+	}
+	fmt.Println("and send")
+	for _, destination := range estimate.Destinations {
+		fmt.Printf(" %f DCR \t to %s\n", destination.Amount, destination.Address)
+	}
+	if changeDestinations != nil {
+		for _, destination := range changeDestinations {
+			fmt.Printf(" %f DCR \t to %s (change)\n", destination.Amount, destination.Address)
+		}
+	} else if estimate.Change > 0 {
+		fmt.Printf(" %s \t to a new address in the source account (change)\n", estimate.Change.String())
+	}
+	fmt.Printf("Estimated size: %d bytes\n", estimate.Size)
+	fmt.Printf("Fee: %s (%s/kB)\n", estimate.Fee.String(), estimate.FeeRate.String())
+}
+
+func confirmSend() error {
 	sendConfirmed, err := terminalprompt.RequestYesNoConfirmation("Do you want to broadcast it?", "")
 	if err != nil {
-		return "", fmt.Errorf("error reading your response: %s", err.Error())
+		return fmt.Errorf("error reading your response: %s", err.Error())
 	}
 
 	if !sendConfirmed {
-		return "", errors.New("transaction cancelled")
+		return errors.New("transaction canceled")
 	}
+	return nil
+}
 
-	return wallet.SendFromAccount(sourceAccount, requiredConfirmations, sendDestinations, passphrase)
+// walletPassphrase reads the spending passphrase from the passphrase file if one was provided
+// or prompts the user for the passphrase
+func (options SendOptions) walletPassphrase() (string, error) {
+	if options.PassphraseFile != "" {
+		return readPassphraseFile(options.PassphraseFile)
+	}
+	return getWalletPassphrase()
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+
 	"github.com/raedahgroup/godcr/app/walletcore"
 	"github.com/raedahgroup/godcr/cli/termio"
 )
@@ -22,6 +23,20 @@ func (g StakeInfoCommand) Run(ctx context.Context, wallet walletcore.Wallet) err
 	if stakeInfo == nil {
 		return errors.New("no tickets in wallet")
 	}
+
+	if !termio.IsTableOutput() {
+		columns := []string{
+			"Expired", "Immature", "Live", "Revoked", "Unmined", "Unspent",
+			"All Mempool Tickets", "Pool Size", "Missed", "Voted", "Total Subsidy",
+		}
+		rows := [][]interface{}{{
+			stakeInfo.Expired, stakeInfo.Immature, stakeInfo.Live, stakeInfo.Revoked,
+			stakeInfo.OwnMempoolTix, stakeInfo.Unspent, stakeInfo.AllMempoolTix,
+			stakeInfo.PoolSize, stakeInfo.Missed, stakeInfo.Voted, stakeInfo.TotalSubsidy,
+		}}
+		return termio.PrintFormattedResult(stakeInfo, columns, rows)
+	}
+
 	output := fmt.Sprintf("stake info for wallet:\n"+
 		"expired %d  immature %d  live %d  revoked %d  unmined %d  unspent %d  "+
 		"allmempooltix %d  poolsize %d  missed %d  voted %d  total subsidy %d",
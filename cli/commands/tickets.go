@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/raedahgroup/godcr/app/walletcore"
+	"github.com/raedahgroup/godcr/cli/termio"
+)
+
+// TicketsCommand lists the tickets purchased by the wallet with their status and details.
+type TicketsCommand struct {
+	commanderStub
+	Statuses []string `long:"status" description:"Only show tickets with this status. Repeat to include multiple statuses" choice:"unmined" choice:"immature" choice:"live" choice:"voted" choice:"missed" choice:"expired" choice:"revoked" choice:"unknown"`
+	Accounts []string `long:"account" description:"Only show tickets purchased from this account. Repeat to include multiple accounts"`
+	Limit    int      `long:"limit" description:"Maximum number of tickets to show. 0 shows all tickets"`
+}
+
+// Run displays the wallet tickets that match the filter flags, newest first.
+func (t TicketsCommand) Run(ctx context.Context, wallet walletcore.Wallet) error {
+	filter, err := t.ticketFilter(wallet)
+	if err != nil {
+		return err
+	}
+
+	tickets, err := wallet.Tickets(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	columns := []string{
+		"Hash",
+		"Status",
+		"Price",
+		"Purchase Height",
+		"Vote Hash",
+		"Reward",
+	}
+	rows := make([][]interface{}, len(tickets))
+	for i, ticket := range tickets {
+		purchaseHeight := "unmined"
+		if ticket.PurchaseHeight >= 0 {
+			purchaseHeight = fmt.Sprintf("%d", ticket.PurchaseHeight)
+		}
+
+		spenderHash := ticket.VoteHash
+		if ticket.RevocationHash != "" {
+			spenderHash = fmt.Sprintf("%s (revocation)", ticket.RevocationHash)
+		}
+
+		rows[i] = []interface{}{
+			ticket.Hash,
+			ticket.Status,
+			ticket.Price,
+			purchaseHeight,
+			spenderHash,
+			ticket.Reward,
+		}
+	}
+
+	if !termio.IsTableOutput() {
+		return termio.PrintFormattedResult(tickets, columns, rows)
+	}
+
+	if len(tickets) == 0 {
+		termio.PrintStringResult("no tickets found")
+		return nil
+	}
+	termio.PrintTabularResult(termio.StdoutWriter, columns, rows)
+	return nil
+}
+
+// ticketFilter converts the filter flags to a walletcore.TicketFilter
+func (t TicketsCommand) ticketFilter(wallet walletcore.Wallet) (*walletcore.TicketFilter, error) {
+	if t.Limit < 0 {
+		return nil, fmt.Errorf("limit cannot be negative")
+	}
+	filter := &walletcore.TicketFilter{Limit: t.Limit}
+
+	for _, statusName := range t.Statuses {
+		status, err := walletcore.ParseTicketStatus(statusName)
+		if err != nil {
+			return nil, err
+		}
+		filter.Statuses = append(filter.Statuses, status)
+	}
+
+	for _, accountName := range t.Accounts {
+		accountNumber, err := wallet.AccountNumber(accountName)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching account number for %s: %s", accountName, err.Error())
+		}
+		filter.Accounts = append(filter.Accounts, accountNumber)
+	}
+
+	return filter, nil
+}
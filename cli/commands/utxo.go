@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/raedahgroup/godcr/app/walletcore"
+	"github.com/raedahgroup/godcr/cli/termio"
+)
+
+// UtxoCommand groups the commands for locking unspent outputs to keep them from being spent.
+// Locked outputs are skipped when inputs are selected automatically and cannot be selected manually until unlocked
+type UtxoCommand struct {
+	commanderStub
+	Lock       LockUtxoCommand       `command:"lock" description:"Lock one or more unspent outputs so they are not spent"`
+	Unlock     UnlockUtxoCommand     `command:"unlock" description:"Unlock one or more locked unspent outputs so they can be spent again"`
+	ListLocked ListLockedUtxoCommand `command:"list-locked" description:"List the locked unspent outputs"`
+}
+
+// utxoKeysArgs are the keys of the unspent outputs to lock or unlock, in the format txhash:index
+type utxoKeysArgs struct {
+	OutputKeys []string `positional-arg-name:"txhash:index" required:"1"`
+}
+
+// LockUtxoCommand locks the unspent outputs passed as arguments.
+type LockUtxoCommand struct {
+	commanderStub
+	Args utxoKeysArgs `positional-args:"yes"`
+}
+
+// Run runs the `utxo lock` command.
+func (lockCommand LockUtxoCommand) Run(ctx context.Context, wallet walletcore.Wallet) error {
+	if err := wallet.LockUnspentOutputs(lockCommand.Args.OutputKeys); err != nil {
+		return fmt.Errorf("error locking unspent outputs: %s", err.Error())
+	}
+	return printUtxoKeys(lockCommand.Args.OutputKeys, "Locked")
+}
+
+// UnlockUtxoCommand unlocks the locked unspent outputs passed as arguments.
+type UnlockUtxoCommand struct {
+	commanderStub
+	Args utxoKeysArgs `positional-args:"yes"`
+}
+
+// Run runs the `utxo unlock` command.
+func (unlockCommand UnlockUtxoCommand) Run(ctx context.Context, wallet walletcore.Wallet) error {
+	if err := wallet.UnlockUnspentOutputs(unlockCommand.Args.OutputKeys); err != nil {
+		return fmt.Errorf("error unlocking unspent outputs: %s", err.Error())
+	}
+	return printUtxoKeys(unlockCommand.Args.OutputKeys, "Unlocked")
+}
+
+// ListLockedUtxoCommand lists the locked unspent outputs with the account, address and amount of those still in the wallet.
+type ListLockedUtxoCommand struct {
+	commanderStub
+}
+
+type lockedUtxo struct {
+	Key     string `json:"key"`
+	Account string `json:"account"`
+	Address string `json:"address"`
+	Amount  string `json:"amount"`
+	Label   string `json:"label,omitempty"`
+}
+
+// Run runs the `utxo list-locked` command.
+func (listCommand ListLockedUtxoCommand) Run(ctx context.Context, wallet walletcore.Wallet) error {
+	lockedKeys, err := wallet.LockedUnspentOutputs()
+	if err != nil {
+		return fmt.Errorf("error fetching locked unspent outputs: %s", err.Error())
+	}
+
+	accounts, err := wallet.AccountsOverview(walletcore.DefaultRequiredConfirmations)
+	if err != nil {
+		return fmt.Errorf("error fetching accounts: %s", err.Error())
+	}
+
+	// find the details of the locked outputs among the unspent outputs in all accounts
+	unspentOutputs := make(map[string]*walletcore.UnspentOutput)
+	accountNames := make(map[string]string)
+	for _, account := range accounts {
+		utxos, err := wallet.UnspentOutputs(account.Number, 0, 0)
+		if err != nil {
+			return fmt.Errorf("error fetching unspent outputs: %s", err.Error())
+		}
+		for _, utxo := range utxos {
+			unspentOutputs[utxo.OutputKey] = utxo
+			accountNames[utxo.OutputKey] = account.Name
+		}
+	}
+
+	lockedUtxos := make([]*lockedUtxo, len(lockedKeys))
+	rows := make([][]interface{}, len(lockedKeys))
+	for i, key := range lockedKeys {
+		// outputs that have been spent, e.g. by another wallet using the same seed, remain locked
+		locked := &lockedUtxo{Key: key, Account: "-", Address: "-", Amount: "spent or not found"}
+		if utxo, ok := unspentOutputs[key]; ok {
+			locked.Account = accountNames[key]
+			locked.Address = utxo.Address
+			locked.Amount = utxo.Amount.String()
+			locked.Label = utxo.Label
+		}
+		lockedUtxos[i] = locked
+		rows[i] = []interface{}{locked.Key, locked.Account, locked.Address, locked.Amount, locked.Label}
+	}
+
+	columns := []string{"Output", "Account", "Address", "Amount", "Label"}
+	if !termio.IsTableOutput() {
+		return termio.PrintFormattedResult(lockedUtxos, columns, rows)
+	}
+
+	if len(lockedKeys) == 0 {
+		fmt.Println("No unspent outputs are locked")
+		return nil
+	}
+
+	termio.PrintTabularResult(termio.TabWriter(os.Stdout), columns, rows)
+	return nil
+}
+
+func printUtxoKeys(outputKeys []string, status string) error {
+	rows := make([][]interface{}, len(outputKeys))
+	for i, key := range outputKeys {
+		rows[i] = []interface{}{key}
+	}
+
+	if !termio.IsTableOutput() {
+		result := map[string]interface{}{
+			"status":  status,
+			"outputs": outputKeys,
+		}
+		return termio.PrintFormattedResult(result, []string{"Output"}, rows)
+	}
+
+	fmt.Printf("%s %d unspent output(s)\n", status, len(outputKeys))
+	return nil
+}
@@ -0,0 +1,159 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/raedahgroup/godcr/app/config"
+	"github.com/raedahgroup/godcr/cli/termio"
+)
+
+// WalletsCommand groups the commands for managing the wallet profiles set in the config file.
+// Use the global --wallet option to select the wallet profile other commands are run against
+type WalletsCommand struct {
+	commanderStub
+	List   ListWalletsCommand  `command:"list" description:"List the wallet profiles set in the config file"`
+	Add    AddWalletCommand    `command:"add" description:"Add a wallet profile to the config file"`
+	Remove RemoveWalletCommand `command:"remove" description:"Remove a wallet profile from the config file. The wallet files are not deleted"`
+}
+
+// ListWalletsCommand prints the name, network, medium and location of each wallet profile.
+type ListWalletsCommand struct{}
+
+type walletProfileInfo struct {
+	Name     string `json:"name"`
+	Network  string `json:"network"`
+	Medium   string `json:"medium"`
+	Location string `json:"location"`
+}
+
+// Execute loads the wallet profiles from the config file and prints them.
+func (l ListWalletsCommand) Execute(args []string) error {
+	appConfig, _, err := config.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	profiles, err := appConfig.WalletProfiles()
+	if err != nil {
+		return err
+	}
+
+	walletProfiles := make([]*walletProfileInfo, len(profiles))
+	rows := make([][]interface{}, len(profiles))
+	for i, profile := range profiles {
+		walletProfiles[i] = &walletProfileInfo{
+			Name:     profile.Name,
+			Network:  profile.NetType(),
+			Medium:   profile.Medium(),
+			Location: profile.Location(),
+		}
+		rows[i] = []interface{}{profile.Name, profile.NetType(), profile.Medium(), profile.Location()}
+	}
+
+	columns := []string{"Name", "Network", "Medium", "Location"}
+	if !termio.IsTableOutput() {
+		return termio.PrintFormattedResult(walletProfiles, columns, rows)
+	}
+
+	termio.PrintTabularResult(termio.TabWriter(os.Stdout), columns, rows)
+	return nil
+}
+
+// AddWalletCommand saves a new wallet profile to the config file.
+// Options that are not set are taken from the top-level wallet options when the profile is loaded
+type AddWalletCommand struct {
+	AppDataDir      string `long:"appdata" description:"Path to the application data directory of the wallet. Defaults to a directory named after the profile in godcr's app data directory"`
+	UseTestNet      bool   `long:"testnet" description:"Open the testnet wallet instead of mainnet"`
+	UseWalletRPC    bool   `long:"usewalletrpc" description:"Connect to a running dcrwallet daemon over rpc to use the wallet"`
+	UseMockWallet   bool   `long:"usemockwallet" description:"Use an in-memory wallet populated with fake data"`
+	WalletRPCServer string `long:"walletrpcserver" description:"Wallet RPC server address to connect to"`
+	WalletRPCCert   string `long:"walletrpccert" description:"Path to dcrwallet certificate file"`
+	NoWalletRPCTLS  bool   `long:"nowalletrpctls" description:"Disable TLS when connecting to dcrwallet daemon via RPC"`
+	Args            struct {
+		Name string `positional-arg-name:"name" required:"yes"`
+	} `positional-args:"yes"`
+}
+
+// Execute validates the profile name and appends the wallet profile to the config file.
+func (a AddWalletCommand) Execute(args []string) error {
+	if err := config.ValidateWalletProfileName(a.Args.Name); err != nil {
+		return err
+	}
+
+	appConfig, _, err := config.LoadConfig()
+	if err != nil {
+		return err
+	}
+	profiles, err := appConfig.WalletProfiles()
+	if err != nil {
+		return err
+	}
+	for _, profile := range profiles {
+		if profile.Name == a.Args.Name {
+			return fmt.Errorf("a wallet profile named %s already exists", a.Args.Name)
+		}
+	}
+
+	profile := &config.WalletProfile{
+		Name: a.Args.Name,
+		WalletOptions: config.WalletOptions{
+			AppDataDir:      a.AppDataDir,
+			UseTestNet:      a.UseTestNet,
+			UseWalletRPC:    a.UseWalletRPC,
+			UseMockWallet:   a.UseMockWallet,
+			WalletRPCServer: a.WalletRPCServer,
+			WalletRPCCert:   a.WalletRPCCert,
+			NoWalletRPCTLS:  a.NoWalletRPCTLS,
+		},
+	}
+
+	// dcrlibwallet wallets need their own app data directory, else the profile would open the default wallet
+	if profile.AppDataDir == "" && !profile.UseWalletRPC && !profile.UseMockWallet {
+		profile.AppDataDir = config.DefaultProfileAppDataDir(profile.Name)
+	}
+
+	// string options that are not set are taken from the top-level wallet options, as they would be when the profile is loaded
+	topLevelOptions := appConfig.WalletOptions
+	if profile.AppDataDir == "" {
+		profile.AppDataDir = topLevelOptions.AppDataDir
+	}
+	if profile.WalletRPCServer == "" {
+		profile.WalletRPCServer = topLevelOptions.WalletRPCServer
+	}
+	if profile.WalletRPCCert == "" {
+		profile.WalletRPCCert = topLevelOptions.WalletRPCCert
+	}
+
+	// the profile is encoded against the top-level options it is parsed with, so every option that differs from them is saved,
+	// including boolean options that are false in the profile but true in the top-level options
+	if err = config.AddWalletProfile(profile, topLevelOptions); err != nil {
+		return err
+	}
+
+	fmt.Printf("Wallet profile %s added. Use --wallet=%s to open the wallet\n", profile.Name, profile.Name)
+	return nil
+}
+
+// RemoveWalletCommand removes a wallet profile from the config file.
+type RemoveWalletCommand struct {
+	Args struct {
+		Name string `positional-arg-name:"name" required:"yes"`
+	} `positional-args:"yes"`
+}
+
+// Execute removes the wallet profile from the config file, leaving the wallet files on disk.
+// godcr's files for the profile, such as labels and locked outputs, are renamed by config.RemoveWalletProfile.
+func (r RemoveWalletCommand) Execute(args []string) error {
+	if r.Args.Name == config.DefaultWalletProfileName {
+		return fmt.Errorf("the %s wallet is set by the top-level wallet options and cannot be removed", config.DefaultWalletProfileName)
+	}
+
+	if err := config.RemoveWalletProfile(r.Args.Name); err != nil {
+		return err
+	}
+
+	fmt.Printf("Wallet profile %s removed. The wallet files were not deleted, godcr's labels, locked outputs, "+
+		"hidden accounts and seed backup for the wallet were renamed with a .removed suffix\n", r.Args.Name)
+	return nil
+}
@@ -0,0 +1,198 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/raedahgroup/godcr/app"
+	"github.com/raedahgroup/godcr/cli/termio"
+	"github.com/raedahgroup/godcr/cli/walletloader"
+)
+
+// execHookQueueSize is the number of events that can wait for the --exec command to finish running for earlier events.
+// Hooks are not run for events received while the queue is full
+const execHookQueueSize = 100
+
+// WatchCommand syncs the blockchain and keeps the wallet open afterwards, printing wallet activity as it happens
+type WatchCommand struct {
+	commanderStub
+	Confirmations []int32 `long:"confirmations" description:"Print a line when a wallet transaction reaches this number of confirmations. Repeat to watch multiple confirmation milestones" default:"1" default:"6"`
+	Exec          string  `long:"exec" description:"Shell command to run for each event. The event is passed to the command as json on stdin and the event type is set in the GODCR_EVENT_TYPE environment variable"`
+}
+
+// Run opens the wallet, subscribes to wallet events and syncs the blockchain,
+// then prints each event until the program is interrupted
+// Events are printed as one json object per line if the global --output=json option is set
+func (w WatchCommand) Run(ctx context.Context, walletMiddleware app.WalletMiddleware) error {
+	if termio.OutputFormat() == termio.OutputFormatCSV {
+		return fmt.Errorf("csv output is not supported by watch, use --output=json for machine-readable output")
+	}
+	for _, confirmations := range w.Confirmations {
+		if confirmations < 1 || confirmations > app.ConfirmationsTracked {
+			return fmt.Errorf("confirmation milestones must be between 1 and %d", app.ConfirmationsTracked)
+		}
+	}
+
+	walletExists, err := walletloader.OpenWallet(ctx, walletMiddleware)
+	if err != nil || !walletExists {
+		return err
+	}
+
+	// subscribe before syncing so that transactions received during sync are also reported
+	events, err := walletMiddleware.Subscribe(ctx)
+	if err != nil {
+		return fmt.Errorf("error subscribing to wallet events: %s", err.Error())
+	}
+
+	// hooks run one at a time, in the order of events, so slow hooks do not hold up reading events
+	var execHooks chan app.WalletEvent
+	if w.Exec != "" {
+		execHooks = make(chan app.WalletEvent, execHookQueueSize)
+		go w.runExecHooks(ctx, execHooks)
+	}
+
+	syncErr := make(chan error, 1)
+	go func() {
+		syncErr <- walletloader.SyncBlockChain(ctx, walletMiddleware)
+	}()
+
+	for {
+		select {
+		case err := <-syncErr:
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(os.Stderr, "Watching wallet activity. Press Ctrl+C to stop")
+
+		case event, ok := <-events:
+			if !ok {
+				// events channel is closed when ctx is canceled, i.e. the program is shutting down
+				return nil
+			}
+			if !w.shouldReport(event) {
+				continue
+			}
+			if err := w.printEvent(event); err != nil {
+				return err
+			}
+			if execHooks != nil {
+				select {
+				case execHooks <- event:
+				default:
+					fmt.Fprintf(os.Stderr, "exec hook queue is full, not running exec hook for %s event\n", event.EventType())
+				}
+			}
+		}
+	}
+}
+
+// shouldReport returns true for events that are printed by the watch command
+// sync progress is already logged while syncing and only confirmation milestones are reported
+func (w WatchCommand) shouldReport(event app.WalletEvent) bool {
+	switch event := event.(type) {
+	case app.SyncProgressEvent:
+		return false
+	case app.ConfirmationEvent:
+		for _, confirmations := range w.Confirmations {
+			if event.Confirmations == confirmations {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+func (w WatchCommand) printEvent(event app.WalletEvent) error {
+	if termio.OutputFormat() == termio.OutputFormatJSON {
+		eventJSON, err := json.Marshal(app.NewEventMessage(event))
+		if err != nil {
+			return fmt.Errorf("error encoding event as json: %s", err.Error())
+		}
+		fmt.Println(string(eventJSON))
+		return nil
+	}
+
+	termio.PrintStringResult(eventDescription(event))
+	return nil
+}
+
+// eventDescription returns a one-line, human-readable description of `event`
+func eventDescription(event app.WalletEvent) string {
+	switch event := event.(type) {
+	case app.NewBlockEvent:
+		if event.Hash == "" {
+			return fmt.Sprintf("new block %d", event.Height)
+		}
+		return fmt.Sprintf("new block %d %s", event.Height, event.Hash)
+
+	case app.TransactionEvent:
+		tx := event.Transaction
+		status := "unconfirmed"
+		if event.Mined {
+			status = fmt.Sprintf("mined in block %d", event.BlockHeight)
+		}
+		return fmt.Sprintf("transaction %s %s %s %s, %s", tx.Hash, tx.Type, tx.Direction, tx.Amount, status)
+
+	case app.ConfirmationEvent:
+		return fmt.Sprintf("transaction %s has %d confirmation(s)", event.TransactionHash, event.Confirmations)
+
+	case app.TicketStatusEvent:
+		if event.TransactionHash != "" && event.TransactionHash != event.TicketHash {
+			return fmt.Sprintf("ticket %s %s in transaction %s", event.TicketHash, event.Status, event.TransactionHash)
+		}
+		return fmt.Sprintf("ticket %s %s", event.TicketHash, event.Status)
+
+	case app.AccountEvent:
+		return fmt.Sprintf("account %d (%s) updated", event.AccountNumber, event.AccountName)
+
+	case app.DroppedEvent:
+		return fmt.Sprintf("%d event(s) dropped, events were received faster than they could be printed", event.Count)
+
+	default:
+		return event.EventType()
+	}
+}
+
+// runExecHooks runs the --exec shell command for each event received on `events` until ctx is canceled
+func (w WatchCommand) runExecHooks(ctx context.Context, events <-chan app.WalletEvent) {
+	for {
+		select {
+		case event := <-events:
+			w.runExecHook(ctx, event)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// runExecHook runs the --exec shell command for `event`, passing the event as json on stdin
+// hook failures are printed to stderr and do not stop the watch
+func (w WatchCommand) runExecHook(ctx context.Context, event app.WalletEvent) {
+	eventJSON, err := json.Marshal(app.NewEventMessage(event))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error encoding event as json: %s\n", err.Error())
+		return
+	}
+
+	var hook *exec.Cmd
+	if runtime.GOOS == "windows" {
+		hook = exec.CommandContext(ctx, "cmd", "/C", w.Exec)
+	} else {
+		hook = exec.CommandContext(ctx, "sh", "-c", w.Exec)
+	}
+	hook.Env = append(os.Environ(), "GODCR_EVENT_TYPE="+event.EventType())
+	hook.Stdin = bytes.NewReader(eventJSON)
+	hook.Stdout = os.Stdout
+	hook.Stderr = os.Stderr
+
+	if err := hook.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "error running exec hook for %s event: %s\n", event.EventType(), err.Error())
+	}
+}
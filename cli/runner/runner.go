@@ -6,6 +6,7 @@ import (
 	flags "github.com/jessevdk/go-flags"
 	"github.com/raedahgroup/godcr/app"
 	"github.com/raedahgroup/godcr/app/config"
+	"github.com/raedahgroup/godcr/cli/termio"
 )
 
 type CommandRunner struct {
@@ -30,6 +31,9 @@ func (runner *CommandRunner) Run(command flags.Commander, args []string, options
 		return brokenCommandError(runner.parser.Command)
 	}
 
+	// commands print their results in the output format selected with the --output option
+	termio.SetOutputFormat(options.OutputFormat)
+
 	// inject walletMiddleware dependency for commands implementing WalletMiddlewareCommandRunner
 	if commandRunner, ok := command.(WalletMiddlewareCommandRunner); ok {
 		return commandRunner.Run(runner.ctx, runner.walletMiddleware)
@@ -0,0 +1,65 @@
+package runner
+
+import (
+	"context"
+	"testing"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/raedahgroup/godcr/app/config"
+	"github.com/raedahgroup/godcr/app/walletmediums/mockwallet"
+	"github.com/raedahgroup/godcr/cli/commands"
+	"github.com/raedahgroup/godcr/cli/termio"
+)
+
+// walletCommands are commands that read from the wallet without prompting for input
+var walletCommands = map[string]flags.Commander{
+	"balance":          commands.BalanceCommand{},
+	"balance detailed": commands.BalanceCommand{Detailed: true},
+	"accounts list":    commands.ListAccountsCommand{},
+	"tickets":          commands.TicketsCommand{},
+	"stakeinfo":        commands.StakeInfoCommand{},
+	"label list":       commands.ListLabelsCommand{},
+	"utxo list-locked": commands.ListLockedUtxoCommand{},
+}
+
+func runWalletCommands(t *testing.T, runner *CommandRunner) {
+	for _, outputFormat := range []string{termio.OutputFormatTable, termio.OutputFormatJSON, termio.OutputFormatCSV} {
+		for name, command := range walletCommands {
+			if err := runner.Run(command, nil, config.CliOptions{OutputFormat: outputFormat}); err != nil {
+				t.Errorf("%s (%s output): %s", name, outputFormat, err.Error())
+			}
+		}
+	}
+	termio.SetOutputFormat(termio.OutputFormatTable)
+}
+
+func TestCommandsWithMockWallet(t *testing.T) {
+	mock := mockwallet.New("testnet")
+	runner := New(nil, context.Background(), mock)
+
+	runWalletCommands(t, runner)
+
+	if !mock.IsWalletOpen() {
+		t.Error("wallet was not opened before running wallet commands")
+	}
+}
+
+func TestCommandsWithCreatedMockWallet(t *testing.T) {
+	mock := mockwallet.NewEmpty("testnet")
+	if exists, _ := mock.WalletExists(); exists {
+		t.Fatal("empty mock wallet reports that a wallet exists")
+	}
+
+	seed, err := mock.GenerateNewWalletSeed()
+	if err != nil {
+		t.Fatalf("error generating seed: %s", err.Error())
+	}
+	if err = mock.CreateWallet("passphrase", seed); err != nil {
+		t.Fatalf("error creating wallet: %s", err.Error())
+	}
+	if err = mock.CreateWallet("passphrase", seed); err == nil {
+		t.Error("expected an error creating a wallet when one exists")
+	}
+
+	runWalletCommands(t, New(nil, context.Background(), mock))
+}
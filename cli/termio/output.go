@@ -0,0 +1,94 @@
+package termio
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/decred/dcrd/dcrutil"
+)
+
+// supported formats for printing command results, set using the global --output option
+const (
+	OutputFormatTable = "table"
+	OutputFormatJSON  = "json"
+	OutputFormatCSV   = "csv"
+)
+
+var outputFormat = OutputFormatTable
+
+// SetOutputFormat sets the format used to print command results. Empty values are ignored
+func SetOutputFormat(format string) {
+	if format != "" {
+		outputFormat = format
+	}
+}
+
+// OutputFormat returns the format selected for printing command results
+func OutputFormat() string {
+	return outputFormat
+}
+
+// IsTableOutput returns true if command results should be printed as human-readable, tab-aligned text
+// otherwise, command results should be printed using `PrintFormattedResult`
+func IsTableOutput() bool {
+	return outputFormat == OutputFormatTable
+}
+
+// PrintFormattedResult prints `data` to stdout as json if the json output format is selected
+// or prints `columns` and `rows` to stdout as csv if the csv output format is selected
+func PrintFormattedResult(data interface{}, columns []string, rows [][]interface{}) error {
+	switch outputFormat {
+	case OutputFormatJSON:
+		return PrintJSONResult(data)
+	case OutputFormatCSV:
+		return PrintCSVResult(columns, rows)
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+}
+
+// PrintJSONResult prints `data` to stdout as indented json
+func PrintJSONResult(data interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(data); err != nil {
+		return fmt.Errorf("error encoding result as json: %s", err.Error())
+	}
+	return nil
+}
+
+// PrintCSVResult prints `columns` as header row and `rows` to stdout as comma-separated values
+// dcrutil.Amount values are printed as plain DCR numbers without the unit, so they can be used in calculations
+func PrintCSVResult(columns []string, rows [][]interface{}) error {
+	writer := csv.NewWriter(os.Stdout)
+	if err := writer.Write(columns); err != nil {
+		return fmt.Errorf("error writing csv result: %s", err.Error())
+	}
+
+	for _, row := range rows {
+		record := make([]string, len(row))
+		for i, value := range row {
+			record[i] = csvValue(value)
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("error writing csv result: %s", err.Error())
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func csvValue(value interface{}) string {
+	switch v := value.(type) {
+	case dcrutil.Amount:
+		return strconv.FormatFloat(v.ToCoin(), 'f', -1, 64)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/raedahgroup/godcr/app"
+	"github.com/raedahgroup/godcr/app/walletcore"
 	"github.com/raedahgroup/godcr/cli/termio/terminalprompt"
 )
 
@@ -28,6 +29,108 @@ func displayWalletSeed(seed string) {
 		"giving them access to all your funds, so it is imperative that you keep it in a secure location.")
 }
 
+// BackUpSeed displays `seed` and asks the user for randomly chosen words of the seed to check that they wrote it down.
+// The user can display the seed again and retry, or skip the check, in which case false is returned
+// Errors are printed to stderr before they are returned
+func BackUpSeed(seed string) (verified bool, err error) {
+	displayWalletSeed(seed)
+
+	for {
+		err = verifySeedWords(seed)
+		if err == nil {
+			fmt.Println("Seed backup verified")
+			return true, nil
+		}
+		fmt.Fprintln(os.Stderr, err.Error())
+
+		options := []string{"Try again", "Show the seed again", "Skip, I will back up the seed later"}
+		validateOption := func(option string) error {
+			if option != "1" && option != "2" && option != "3" {
+				return fmt.Errorf("invalid option, try again")
+			}
+			return nil
+		}
+		option, err := terminalprompt.RequestSelection("The seed words you entered are not correct", options, validateOption)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading input: %s\n", err.Error())
+			return false, err
+		}
+
+		switch option {
+		case "2":
+			displayWalletSeed(seed)
+		case "3":
+			return false, nil
+		}
+	}
+}
+
+// verifySeedWords asks the user for walletcore.SeedVerificationWordCount randomly chosen words of `seed`
+// and returns an error if any word is not correct
+func verifySeedWords(seed string) error {
+	positions, err := walletcore.SeedVerificationPositions(len(strings.Fields(seed)))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("To confirm that you have stored the seed, enter the following words from it")
+	words := make([]string, len(positions))
+	for i, position := range positions {
+		words[i], err = terminalprompt.RequestInput(fmt.Sprintf("Word #%d", position+1), terminalprompt.EmptyValidator)
+		if err != nil {
+			return fmt.Errorf("error reading input: %s", err.Error())
+		}
+	}
+
+	return walletcore.VerifySeedWords(seed, positions, words)
+}
+
+// requestNewWalletPassphrase asks the user to enter the private passphrase for a new wallet twice
+// errors are printed to stderr before they are returned
+func requestNewWalletPassphrase() (string, error) {
+	passphrase, err := terminalprompt.RequestInputSecure("Enter private passphrase for new wallet", terminalprompt.EmptyValidator)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading input: %s\n", err.Error())
+		return "", err
+	}
+	confirmPassphrase, err := terminalprompt.RequestInputSecure("Confirm passphrase", terminalprompt.EmptyValidator)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading input: %s\n", err.Error())
+		return "", err
+	}
+	if passphrase != confirmPassphrase {
+		fmt.Fprintln(os.Stderr, "Passphrases do not match")
+		return "", fmt.Errorf("passphrases do not match")
+	}
+	return passphrase, nil
+}
+
+// openWalletWithPublicPassphrase asks the user for the public passphrase of a wallet that does not use the default public passphrase
+// and opens the wallet with it. The user gets 3 attempts, errors are printed to stderr before they are returned
+func openWalletWithPublicPassphrase(walletMiddleware app.WalletMiddleware) (err error) {
+	fmt.Println("This wallet uses a custom public passphrase")
+
+	for attempt := 0; attempt < 3; attempt++ {
+		var publicPassphrase string
+		publicPassphrase, err = terminalprompt.RequestInputSecure("Public Passphrase", terminalprompt.EmptyValidator)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading input: %s\n", err.Error())
+			return err
+		}
+
+		err = walletMiddleware.OpenWallet(publicPassphrase)
+		if err != app.ErrInvalidPublicPassphrase {
+			break
+		}
+		fmt.Fprintln(os.Stderr, "Invalid public passphrase")
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open %s wallet: %s\n", walletMiddleware.NetType(), err.Error())
+	}
+	return err
+}
+
 func attemptToCreateWallet(ctx context.Context, walletMiddleware app.WalletMiddleware) error {
 	createWalletPrompt := "No wallet found. Would you like to create one now?"
 	createWallet, err := terminalprompt.RequestYesNoConfirmation(createWalletPrompt, "Y")
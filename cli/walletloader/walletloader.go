@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/raedahgroup/godcr/app"
+	"github.com/raedahgroup/godcr/app/walletcore"
 	"github.com/raedahgroup/godcr/cli/termio/terminalprompt"
 )
 
@@ -24,21 +25,10 @@ func CreateWallet(ctx context.Context, walletMiddleware app.WalletMiddleware) (e
 		return fmt.Errorf("wallet already exists")
 	}
 
-	// ask user to enter passphrase twice
-	passphrase, err := terminalprompt.RequestInputSecure("Enter private passphrase for new wallet", terminalprompt.EmptyValidator)
+	passphrase, err := requestNewWalletPassphrase()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading input: %s\n", err.Error())
-		return
-	}
-	confirmPassphrase, err := terminalprompt.RequestInputSecure("Confirm passphrase", terminalprompt.EmptyValidator)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading input: %s\n", err.Error())
 		return
 	}
-	if passphrase != confirmPassphrase {
-		fmt.Fprintln(os.Stderr, "Passphrases do not match")
-		return fmt.Errorf("passphrases do not match")
-	}
 
 	// get seed and display to user
 	seed, err := walletMiddleware.GenerateNewWalletSeed()
@@ -46,26 +36,13 @@ func CreateWallet(ctx context.Context, walletMiddleware app.WalletMiddleware) (e
 		fmt.Fprintf(os.Stderr, "Error generating seed for new wallet: %s\n", err)
 		return
 	}
-	displayWalletSeed(seed)
-
-	// ask user to back seed up, only proceed after user does so
-	backupPrompt := `Enter "OK" to continue. This assumes you have stored the seed in a safe and secure location`
-	backupValidator := func(userResponse string) error {
-		userResponse = strings.TrimSpace(userResponse)
-		userResponse = strings.Trim(userResponse, `"`)
-		if strings.EqualFold("OK", userResponse) {
-			return nil
-		} else {
-			return fmt.Errorf("invalid response, try again")
-		}
-	}
-	_, err = terminalprompt.RequestInput(backupPrompt, backupValidator)
+
+	// ask user to back seed up and check some of the seed words before finalizing wallet creation
+	seedBackedUp, err := BackUpSeed(seed)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading input: %s\n", err.Error())
 		return
 	}
 
-	// user entered "OK" in last prompt, finalize wallet creation
 	err = walletMiddleware.CreateWallet(passphrase, seed)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error creating wallet: %s\n", err.Error())
@@ -73,6 +50,15 @@ func CreateWallet(ctx context.Context, walletMiddleware app.WalletMiddleware) (e
 	}
 	fmt.Printf("Decred %s wallet created successfully\n", walletMiddleware.NetType())
 
+	// keep the seed until the user confirms the backup, so it can be displayed again
+	if !seedBackedUp {
+		if err = walletMiddleware.SaveUnconfirmedSeed(seed, passphrase); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving seed for backup: %s\n", err.Error())
+			return
+		}
+		fmt.Println("Your seed backup is not verified. Run `godcr seedbackup` to display the seed again and verify your backup")
+	}
+
 	// sync blockchain?
 	syncBlockchainPrompt := "Would you like to sync the blockchain now?"
 	syncBlockchain, err := terminalprompt.RequestYesNoConfirmation(syncBlockchainPrompt, "Y")
@@ -88,6 +74,87 @@ func CreateWallet(ctx context.Context, walletMiddleware app.WalletMiddleware) (e
 	return SyncBlockChain(ctx, walletMiddleware)
 }
 
+// RestoreWallet creates a wallet from the seed of an existing wallet if no wallet exists using the WalletMiddleware provided
+// The blockchain is synced after the wallet is created, to discover the addresses and transactions of the restored wallet
+func RestoreWallet(ctx context.Context, walletMiddleware app.WalletMiddleware) (err error) {
+	walletExists, err := walletMiddleware.WalletExists()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error checking %s wallet: %s\n", walletMiddleware.NetType(), err.Error())
+		return
+	}
+	if walletExists {
+		netType := strings.Title(walletMiddleware.NetType())
+		fmt.Fprintf(os.Stderr, "%s wallet already exists\n", netType)
+		return fmt.Errorf("wallet already exists")
+	}
+
+	seedPrompt := fmt.Sprintf("Enter the %d-word seed or hex seed of the wallet to restore", walletcore.SeedWordCount)
+	seed, err := terminalprompt.RequestInput(seedPrompt, func(seed string) error {
+		return walletcore.ValidateSeed(walletcore.NormalizeSeed(seed))
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading input: %s\n", err.Error())
+		return
+	}
+
+	passphrase, err := requestNewWalletPassphrase()
+	if err != nil {
+		return
+	}
+
+	err = walletMiddleware.CreateWallet(passphrase, walletcore.NormalizeSeed(seed))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error restoring wallet: %s\n", err.Error())
+		return
+	}
+	fmt.Printf("Decred %s wallet restored successfully\n", walletMiddleware.NetType())
+
+	// a restored wallet knows nothing about its past transactions until the blockchain is rescanned
+	fmt.Println("Syncing the blockchain to discover your addresses and transactions. This may take a while")
+	return SyncBlockChain(ctx, walletMiddleware)
+}
+
+// CreateWatchingOnlyWallet creates a wallet without private keys from an account extended public key if no wallet exists
+// The user is asked for the extended public key if `extendedPublicKey` is empty
+// The blockchain is synced after the wallet is created, to discover the addresses and transactions of the account
+func CreateWatchingOnlyWallet(ctx context.Context, walletMiddleware app.WalletMiddleware, extendedPublicKey string) (err error) {
+	walletExists, err := walletMiddleware.WalletExists()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error checking %s wallet: %s\n", walletMiddleware.NetType(), err.Error())
+		return
+	}
+	if walletExists {
+		netType := strings.Title(walletMiddleware.NetType())
+		fmt.Fprintf(os.Stderr, "%s wallet already exists\n", netType)
+		return fmt.Errorf("wallet already exists")
+	}
+
+	validateExtendedPublicKey := func(extendedPublicKey string) error {
+		return walletcore.ValidateExtendedPublicKey(extendedPublicKey, walletMiddleware.NetType())
+	}
+
+	if extendedPublicKey == "" {
+		extendedPublicKey, err = terminalprompt.RequestInput("Enter the extended public key of the account to watch", validateExtendedPublicKey)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading input: %s\n", err.Error())
+			return
+		}
+	} else if err = validateExtendedPublicKey(extendedPublicKey); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return
+	}
+
+	err = walletMiddleware.CreateWatchingOnlyWallet(strings.TrimSpace(extendedPublicKey))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating watch-only wallet: %s\n", err.Error())
+		return
+	}
+	fmt.Printf("Decred %s watch-only wallet created successfully\n", walletMiddleware.NetType())
+
+	fmt.Println("Syncing the blockchain to discover the account's addresses and transactions. This may take a while")
+	return SyncBlockChain(ctx, walletMiddleware)
+}
+
 // OpenWallet is called whenever an action to be executed requires wallet to be loaded
 // notifies the program to exit if wallet doesn't exist or some other error occurs by returning a non-nil error
 //
@@ -111,8 +178,8 @@ func OpenWallet(ctx context.Context, walletMiddleware app.WalletMiddleware) (wal
 			return
 		}
 
-		err = walletMiddleware.OpenWallet()
-		if err != nil {
+		err = walletMiddleware.OpenWallet("")
+		if err != nil && err != app.ErrInvalidPublicPassphrase {
 			errMsg = fmt.Sprintf("Failed to open %s wallet", walletMiddleware.NetType())
 		}
 	}()
@@ -124,11 +191,19 @@ func OpenWallet(ctx context.Context, walletMiddleware app.WalletMiddleware) (wal
 			return
 		}
 
+		if err == app.ErrInvalidPublicPassphrase {
+			// errors are printed by openWalletWithPublicPassphrase
+			err = openWalletWithPublicPassphrase(walletMiddleware)
+			return
+		}
+
 		if errMsg != "" {
 			fmt.Fprintln(os.Stderr, errMsg)
 		}
 		if err != nil {
 			fmt.Fprintln(os.Stderr, err.Error())
+		} else if walletMiddleware.HasUnconfirmedSeed() {
+			fmt.Fprintln(os.Stderr, "Your wallet seed backup is not verified. Run `godcr seedbackup` to display the seed and verify your backup")
 		}
 		return
 
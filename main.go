@@ -13,11 +13,11 @@ import (
 	"github.com/raedahgroup/godcr/app"
 	"github.com/raedahgroup/godcr/app/config"
 	"github.com/raedahgroup/godcr/app/help"
-	"github.com/raedahgroup/godcr/app/walletmediums/dcrlibwallet"
-	"github.com/raedahgroup/godcr/app/walletmediums/dcrwalletrpc"
+	"github.com/raedahgroup/godcr/app/walletregistry"
 	"github.com/raedahgroup/godcr/cli"
 	"github.com/raedahgroup/godcr/cli/commands"
 	"github.com/raedahgroup/godcr/cli/runner"
+	"github.com/raedahgroup/godcr/cli/termio"
 	"github.com/raedahgroup/godcr/nuklear"
 	"github.com/raedahgroup/godcr/qt"
 	"github.com/raedahgroup/godcr/web"
@@ -69,18 +69,18 @@ func main() {
 	shutdownOps = append(shutdownOps, cancel)
 
 	// open connection to wallet and add wallet close function to shutdownOps
-	walletMiddleware := connectToWallet(ctx, appConfig)
-	shutdownOps = append(shutdownOps, walletMiddleware.CloseWallet)
+	walletRegistry := connectToWallet(ctx, appConfig)
+	shutdownOps = append(shutdownOps, walletRegistry.CloseWallet)
 
 	switch appConfig.InterfaceMode {
 	case "cli":
-		enterCliMode(ctx, walletMiddleware, appConfig)
+		enterCliMode(ctx, walletRegistry, appConfig)
 	case "http":
-		enterHttpMode(ctx, walletMiddleware, appConfig)
+		enterHttpMode(ctx, walletRegistry, appConfig)
 	case "nuklear":
-		enterNuklearMode(ctx, walletMiddleware)
+		enterNuklearMode(ctx, walletRegistry)
 	case "qt":
-		enterQtMode(ctx, walletMiddleware)
+		enterQtMode(ctx, walletRegistry)
 	}
 
 	// wait for handleShutdown goroutine, to finish before exiting main
@@ -101,6 +101,7 @@ func attemptExecuteSimpleOp() (isSimpleOp bool, err error) {
 		}
 
 		isSimpleOp = true
+		termio.SetOutputFormat(configWithCommands.OutputFormat)
 		commandRunner := runner.New(parser, nil, nil)
 		return commandRunner.RunNoneWalletCommands(command, args)
 	}
@@ -121,27 +122,22 @@ func attemptExecuteSimpleOp() (isSimpleOp bool, err error) {
 	return
 }
 
-// connectToWallet opens connection to a wallet via any of the available walletmiddleware
-// default walletmiddleware is dcrlibwallet, alternative is dcrwalletrpc
-func connectToWallet(ctx context.Context, config config.Config) app.WalletMiddleware {
-	if !config.UseWalletRPC {
-		var netType string
-		if config.UseTestNet {
-			netType = "testnet"
-		} else {
-			netType = "mainnet"
-		}
-		return dcrlibwallet.New(config.AppDataDir, netType)
+// connectToWallet opens connection to the wallet selected with --wallet, or the default wallet, via a wallet registry
+// the registry allows frontends to switch to the wallets of other wallet profiles at runtime
+func connectToWallet(ctx context.Context, config config.Config) *walletregistry.Registry {
+	profiles, err := config.WalletProfiles()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading wallet profiles: %s\n", err.Error())
+		os.Exit(1)
 	}
 
-	walletMiddleware, err := dcrwalletrpc.New(ctx, config.WalletRPCServer, config.WalletRPCCert, config.NoWalletRPCTLS)
+	registry, err := walletregistry.New(ctx, profiles, config.WalletName)
 	if err != nil {
-		fmt.Println("Connect to dcrwallet rpc failed")
 		fmt.Println(err.Error())
 		os.Exit(1)
 	}
 
-	return walletMiddleware
+	return registry
 }
 
 func enterCliMode(ctx context.Context, walletMiddleware app.WalletMiddleware, appConfig config.Config) {
@@ -150,17 +146,17 @@ func enterCliMode(ctx context.Context, walletMiddleware app.WalletMiddleware, ap
 	beginShutdown <- true
 }
 
-func enterHttpMode(ctx context.Context, walletMiddleware app.WalletMiddleware, appConfig config.Config) {
-	opError = web.StartServer(ctx, walletMiddleware, appConfig.HTTPHost, appConfig.HTTPPort)
+func enterHttpMode(ctx context.Context, walletRegistry *walletregistry.Registry, appConfig config.Config) {
+	opError = web.StartServer(ctx, walletRegistry, appConfig.HTTPHost, appConfig.HTTPPort)
 	// only trigger shutdown if some error occurred, ctx.Err cases would already have triggered shutdown, so ignore
 	if opError != nil && ctx.Err() == nil {
 		beginShutdown <- true
 	}
 }
 
-func enterNuklearMode(ctx context.Context, walletMiddleware app.WalletMiddleware) {
+func enterNuklearMode(ctx context.Context, walletRegistry *walletregistry.Registry) {
 	fmt.Println("Launching desktop app with nuklear")
-	nuklear.LaunchApp(ctx, walletMiddleware)
+	nuklear.LaunchApp(ctx, walletRegistry)
 	// todo need to properly listen for shutdown and trigger shutdown
 	beginShutdown <- true
 }
@@ -0,0 +1,114 @@
+package nuklear
+
+import (
+	"fmt"
+
+	"github.com/aarzilli/nucular"
+	"github.com/aarzilli/nucular/label"
+	"github.com/raedahgroup/godcr/app/walletcore"
+)
+
+var (
+	accountNameInput nucular.TextEditor
+
+	// renamingAccount is the account being renamed with accountNameInput, nil if no account is being renamed
+	renamingAccount *walletcore.AccountDetails
+	accountsErr     error
+)
+
+// AccountsHandler lists all accounts of the wallet, including hidden accounts, and lets the user rename, hide and unhide them
+func (d *Desktop) AccountsHandler(w *nucular.Window) {
+	accounts, err := d.walletMiddleware.AccountDetails(walletcore.DefaultRequiredConfirmations)
+
+	if page := newWindow("Accounts Page", w, 0); page != nil {
+		page.header("Accounts")
+
+		if content := page.contentWindow("Accounts Content"); content != nil {
+			if err != nil {
+				content.setErrorMessage(err.Error())
+			} else {
+				d.drawAccounts(content, accounts)
+			}
+			content.end()
+		}
+		page.end()
+	}
+}
+
+func (d *Desktop) drawAccounts(content *window, accounts []*walletcore.AccountDetails) {
+	content.Row(20).Ratio(0.05, 0.2, 0.2, 0.2, 0.15, 0.1, 0.1)
+	content.Label("#", "LC")
+	content.Label("Name", "LC")
+	content.Label("Balance", "LC")
+	content.Label("Addresses", "LC")
+	content.Label("Derivation Path", "LC")
+	content.Label("", "LC")
+	content.Label("", "LC")
+
+	for _, account := range accounts {
+		name := account.Name
+		if account.Hidden {
+			name += " (hidden)"
+		}
+
+		content.Row(30).Ratio(0.05, 0.2, 0.2, 0.2, 0.15, 0.1, 0.1)
+		content.Label(fmt.Sprint(account.Number), "LC")
+		content.Label(name, "LC")
+		content.Label(account.Balance.Total.String(), "LC")
+		if account.Number == walletcore.ImportedAccountNumber {
+			content.Label(fmt.Sprintf("%d imported", account.ImportedKeyCount), "LC")
+			content.Label("imported keys", "LC")
+			content.Label("", "LC")
+		} else {
+			content.Label(fmt.Sprintf("%d receive, %d change", account.ExternalKeyCount, account.InternalKeyCount), "LC")
+			if account.DerivationPath != "" {
+				content.Label(account.DerivationPath, "LC")
+			} else {
+				content.Label("unknown", "LC")
+			}
+			if content.Button(label.T("Rename"), false) {
+				renamingAccount = account
+				accountNameInput.Buffer = []rune(account.Name)
+				accountsErr = nil
+			}
+		}
+
+		hideText := "Hide"
+		if account.Hidden {
+			hideText = "Unhide"
+		}
+		if content.Button(label.T(hideText), false) {
+			accountsErr = d.walletMiddleware.HideAccount(account.Number, !account.Hidden)
+		}
+	}
+
+	if renamingAccount != nil {
+		content.Row(25).Dynamic(1)
+		content.Label(fmt.Sprintf("Rename account %s", renamingAccount.Name), "LC")
+
+		content.Row(25).Dynamic(1)
+		accountNameInput.Edit(content.Window)
+
+		content.Row(35).Static(150, 150)
+		if content.Button(label.T("Save"), false) {
+			accountsErr = d.walletMiddleware.RenameAccount(renamingAccount.Number, string(accountNameInput.Buffer))
+			if accountsErr == nil {
+				resetAccountInputs()
+			}
+		}
+		if content.Button(label.T("Cancel"), false) {
+			resetAccountInputs()
+		}
+	}
+
+	if accountsErr != nil {
+		content.Row(25).Dynamic(1)
+		content.LabelColored(accountsErr.Error(), "LC", colorTable.ColorChartColorHighlight)
+	}
+}
+
+func resetAccountInputs() {
+	renamingAccount = nil
+	accountNameInput.Buffer = nil
+	accountsErr = nil
+}
@@ -0,0 +1,141 @@
+package nuklear
+
+import (
+	"fmt"
+
+	"github.com/aarzilli/nucular"
+	"github.com/aarzilli/nucular/label"
+	"github.com/raedahgroup/godcr/app/addressbook"
+)
+
+var (
+	contactNameInput    nucular.TextEditor
+	contactAddressInput nucular.TextEditor
+
+	// editingContact is the name of the contact being edited with the contact inputs, a new contact is added if it is empty
+	editingContact string
+	addressBookErr error
+
+	// sendContactIndex is the contact selected on the send page, 0 is the "Select contact" option
+	sendContactIndex = 0
+)
+
+// AddressBookHandler lists the contacts saved for the wallet's network and lets the user add, edit and remove contacts
+func (d *Desktop) AddressBookHandler(w *nucular.Window) {
+	book, err := addressbook.Open(d.walletMiddleware.NetType())
+
+	if page := newWindow("Address Book Page", w, 0); page != nil {
+		page.header("Address Book")
+
+		if content := page.contentWindow("Address Book Content"); content != nil {
+			if err != nil {
+				content.setErrorMessage(err.Error())
+			} else {
+				d.drawContacts(content, book)
+			}
+			content.end()
+		}
+		page.end()
+	}
+}
+
+func (d *Desktop) drawContacts(content *window, book *addressbook.AddressBook) {
+	contacts := book.Contacts()
+	if len(contacts) == 0 {
+		content.Row(25).Dynamic(1)
+		content.Label("The address book is empty", "LC")
+	} else {
+		content.Row(20).Ratio(0.2, 0.5, 0.15, 0.15)
+		content.Label("Name", "LC")
+		content.Label("Address", "LC")
+		content.Label("", "LC")
+		content.Label("", "LC")
+	}
+
+	for _, contact := range contacts {
+		content.Row(30).Ratio(0.2, 0.5, 0.15, 0.15)
+		content.Label(contact.Name, "LC")
+		content.Label(contact.Address, "LC")
+		if content.Button(label.T("Edit"), false) {
+			editingContact = contact.Name
+			contactNameInput.Buffer = []rune(contact.Name)
+			contactAddressInput.Buffer = []rune(contact.Address)
+			addressBookErr = nil
+		}
+		if content.Button(label.T("Remove"), false) {
+			addressBookErr = book.Remove(contact.Name)
+			if editingContact == contact.Name {
+				resetContactInputs()
+			}
+		}
+	}
+
+	content.Row(25).Dynamic(1)
+	if editingContact != "" {
+		content.Label(fmt.Sprintf("Edit contact %s", editingContact), "LC")
+	} else {
+		content.Label("Add contact", "LC")
+	}
+
+	content.Row(15).Ratio(0.3, 0.7)
+	content.Label("Name:", "LC")
+	content.Label("Address:", "LC")
+
+	content.Row(25).Ratio(0.3, 0.7)
+	contactNameInput.Edit(content.Window)
+	contactAddressInput.Edit(content.Window)
+
+	content.Row(35).Static(150, 150)
+	if content.Button(label.T("Save"), false) {
+		name, address := string(contactNameInput.Buffer), string(contactAddressInput.Buffer)
+		if editingContact != "" {
+			addressBookErr = book.Edit(editingContact, name, address)
+		} else {
+			addressBookErr = book.Add(name, address)
+		}
+		if addressBookErr == nil {
+			resetContactInputs()
+		}
+	}
+	if editingContact != "" && content.Button(label.T("Cancel"), false) {
+		resetContactInputs()
+	}
+
+	if addressBookErr != nil {
+		content.Row(25).Dynamic(1)
+		content.LabelColored(addressBookErr.Error(), "LC", colorTable.ColorChartColorHighlight)
+	}
+}
+
+func resetContactInputs() {
+	editingContact = ""
+	contactNameInput.Buffer = nil
+	contactAddressInput.Buffer = nil
+	addressBookErr = nil
+}
+
+// selectSendContact shows a contact selector on the send page that fills the destination address with the selected contact's address
+func (d *Desktop) selectSendContact(content *window) {
+	book, err := addressbook.Open(d.walletMiddleware.NetType())
+	if err != nil || !book.HasContacts() {
+		content.Label("", "LC")
+		return
+	}
+
+	contacts := book.Contacts()
+	options := make([]string, len(contacts)+1)
+	options[0] = "Select contact"
+	for i, contact := range contacts {
+		options[i+1] = contact.Name
+	}
+	if sendContactIndex >= len(options) {
+		sendContactIndex = 0
+	}
+
+	if index := content.ComboSimple(options, sendContactIndex, 25); index != sendContactIndex {
+		sendContactIndex = index
+		if index > 0 {
+			addressInput.Buffer = []rune(contacts[index-1].Address)
+		}
+	}
+}
@@ -2,22 +2,28 @@ package nuklear
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/aarzilli/nucular"
 	"github.com/aarzilli/nucular/label"
 	"github.com/aarzilli/nucular/rect"
 	"github.com/raedahgroup/godcr/app"
 	"github.com/raedahgroup/godcr/app/walletcore"
+	"github.com/raedahgroup/godcr/app/walletregistry"
 )
 
 type pageHandler func(*nucular.Window)
 
 type Desktop struct {
-	window       nucular.MasterWindow
-	currentPage  string
-	wallet       walletcore.Wallet
-	pageHandlers map[string]pageHandler
+	window           nucular.MasterWindow
+	currentPage      string
+	wallet           walletcore.Wallet
+	walletMiddleware app.WalletMiddleware
+	walletRegistry   *walletregistry.Registry
+	pageHandlers     map[string]pageHandler
+
+	// walletLoaded is false while the user is restoring a wallet or entering the wallet's public passphrase,
+	// the wallet pages are not accessible until then
+	walletLoaded bool
 }
 
 const (
@@ -29,10 +35,12 @@ var (
 	contentArea rect.Rect
 )
 
-func LaunchApp(ctx context.Context, walletMiddleware app.WalletMiddleware) error {
+func LaunchApp(ctx context.Context, walletRegistry *walletregistry.Registry) error {
 	d := &Desktop{
-		wallet:       walletMiddleware,
-		pageHandlers: make(map[string]pageHandler),
+		wallet:           walletRegistry,
+		walletMiddleware: walletRegistry,
+		walletRegistry:   walletRegistry,
+		pageHandlers:     make(map[string]pageHandler),
 	}
 
 	window := nucular.NewMasterWindow(nucular.WindowNoScrollbar, app.Name, d.updateFn)
@@ -43,15 +51,18 @@ func LaunchApp(ctx context.Context, walletMiddleware app.WalletMiddleware) error
 	d.currentPage = homePage
 
 	// open wallet and start blockchain syncing in background
-	walletExists, err := openWalletIfExist(ctx, walletMiddleware)
+	walletExists, err := openWalletIfExist(ctx, walletRegistry)
 	if err != nil {
 		return err
 	}
-	if !walletExists {
-		// todo add ui to create wallet
-		err = fmt.Errorf("No wallet found. Use 'godcr create' to create a wallet before launching the desktop app")
-		fmt.Println(err.Error())
-		return err
+	if walletExists && !walletRegistry.IsWalletOpen() {
+		// the wallet uses a custom public passphrase, ask the user for it
+		d.currentPage = "openwallet"
+	} else if walletExists {
+		d.walletLoaded = true
+	} else {
+		// let the user restore a wallet from seed, new wallets can be created with 'godcr createwallet'
+		d.currentPage = "restorewallet"
 	}
 
 	// todo run sync and show progress
@@ -73,9 +84,18 @@ func (d *Desktop) registerHandlers() {
 	d.pageHandlers["receive"] = d.ReceiveHandler
 	d.pageHandlers["send"] = d.SendHandler
 	d.pageHandlers["transactions"] = d.TransactionsHandler
+	d.pageHandlers["accounts"] = d.AccountsHandler
+	d.pageHandlers["staking"] = d.StakingHandler
+	d.pageHandlers["message"] = d.MessageHandler
+	d.pageHandlers["settings"] = d.SettingsHandler
+	d.pageHandlers["addressbook"] = d.AddressBookHandler
 
 	d.pageHandlers["selectutxos"] = d.selectUTXOSHandler
+	d.pageHandlers["sendsummary"] = d.sendSummaryHandler
 	d.pageHandlers["generateaddress"] = d.generateAddressHandler
+	d.pageHandlers["restorewallet"] = d.RestoreWalletHandler
+	d.pageHandlers["wallets"] = d.WalletsHandler
+	d.pageHandlers["openwallet"] = d.OpenWalletHandler
 }
 
 func (d *Desktop) changePage(page string) {
@@ -105,18 +125,51 @@ func (d *Desktop) createNavPane(w *nucular.Window, height int) {
 	setNavStyle(d.window)
 	if sw := w.GroupBegin("Navigation Group", 0); sw != nil {
 		sw.Row(40).Dynamic(1)
+		if !d.walletLoaded {
+			// wallet pages cannot be displayed until a wallet is restored, but the user can switch to another wallet
+			if sw.Button(label.TA("Wallets", "LC"), false) {
+				d.gotoPage("wallets")
+			}
+			sw.GroupEnd()
+			return
+		}
+
 		if sw.Button(label.TA("Balance", "LC"), false) {
 			d.gotoPage("balance")
 		}
-		if sw.Button(label.TA("Send (WIP)", "LC"), false) {
+		if sw.Button(label.TA("Send", "LC"), false) {
 			d.gotoPage("send")
 		}
 		if sw.Button(label.TA("Receive", "LC"), false) {
 			d.gotoPage("receive")
 		}
 		if sw.Button(label.TA("Transactions", "LC"), false) {
+			resetLabelEditor()
 			d.gotoPage("transactions")
 		}
+		if sw.Button(label.TA("Staking", "LC"), false) {
+			resetStakingInputs()
+			d.gotoPage("staking")
+		}
+		if sw.Button(label.TA("Sign/Verify", "LC"), false) {
+			resetMessageInputs()
+			d.gotoPage("message")
+		}
+		if sw.Button(label.TA("Accounts", "LC"), false) {
+			resetAccountInputs()
+			d.gotoPage("accounts")
+		}
+		if sw.Button(label.TA("Address Book", "LC"), false) {
+			resetContactInputs()
+			d.gotoPage("addressbook")
+		}
+		if sw.Button(label.TA("Wallets", "LC"), false) {
+			d.gotoPage("wallets")
+		}
+		if sw.Button(label.TA("Settings", "LC"), false) {
+			resetSettingsInputs()
+			d.gotoPage("settings")
+		}
 		sw.GroupEnd()
 	}
 }
@@ -1,12 +1,17 @@
 package nuklear
 
 import (
+	"fmt"
 	"image"
 	"image/color"
 	"image/draw"
+	"strconv"
+	"strings"
 
 	"github.com/aarzilli/nucular"
 	"github.com/aarzilli/nucular/label"
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/raedahgroup/dcrlibwallet/txhelper"
 	"github.com/raedahgroup/godcr/app/walletcore"
 	qrcode "github.com/skip2/go-qrcode"
 )
@@ -33,8 +38,25 @@ var (
 
 	// form checkbox values
 	checkedUTXOS []bool
+
+	// transaction history filter inputs
+	historyAccountIndex   = 0
+	historyDirectionIndex = 0
+	historyTypeIndex      = 0
+	historySortIndex      = 0
+	historySinceInput     nucular.TextEditor
+	historyUntilInput     nucular.TextEditor
+	historyMinAmountInput nucular.TextEditor
+	historyMaxAmountInput nucular.TextEditor
+	historyOffset         = 0
+
+	historyDirectionOptions = []string{"All directions", "sent", "received", "transferred"}
+	historyTypeOptions      = []string{"All types", "regular", "coinbase", "ticket", "vote", "revoke"}
 )
 
+// historyPageSize is the number of transactions displayed per page on the transactions page
+const historyPageSize = 50
+
 func resetVars() {
 	err = nil
 	accountsResponse = nil
@@ -44,6 +66,23 @@ func resetVars() {
 	selectedAccountNumber = uint32(0)
 	selectedUTXOS = nil
 	checkedUTXOS = nil
+	feeRateInput = nucular.TextEditor{}
+	sendPassphraseInput = nucular.TextEditor{PasswordChar: '*'}
+	sendMax = false
+	sendContactIndex = 0
+	coinSelectionIndex = 0
+	sendEstimate = nil
+	sendErr = nil
+	sentTxHash = ""
+	historyAccountIndex = 0
+	historyDirectionIndex = 0
+	historyTypeIndex = 0
+	historySortIndex = 0
+	historySinceInput = nucular.TextEditor{}
+	historyUntilInput = nucular.TextEditor{}
+	historyMinAmountInput = nucular.TextEditor{}
+	historyMaxAmountInput = nucular.TextEditor{}
+	historyOffset = 0
 }
 
 func (d *Desktop) BalanceHandler(w *nucular.Window) {
@@ -86,8 +125,15 @@ func (d *Desktop) BalanceHandler(w *nucular.Window) {
 }
 
 func (d *Desktop) TransactionsHandler(w *nucular.Window) {
+	if accountsResponse == nil && err == nil {
+		accountsResponse, err = d.wallet.AccountsOverview(walletcore.DefaultRequiredConfirmations)
+	}
 	if transactionsResponse == nil && err == nil {
-		transactionsResponse, err = d.wallet.TransactionHistory()
+		var query *walletcore.TransactionHistoryQuery
+		query, err = historyQuery()
+		if err == nil {
+			transactionsResponse, err = d.wallet.TransactionHistory(query)
+		}
 	}
 
 	if page := newWindow("Transactions Page", w, 0); page != nil {
@@ -95,20 +141,70 @@ func (d *Desktop) TransactionsHandler(w *nucular.Window) {
 
 		// content area
 		if content := page.contentWindow("Transactions Content"); content != nil {
+			accountOptions := []string{"All accounts"}
+			for _, account := range accountsResponse {
+				accountOptions = append(accountOptions, account.Name)
+			}
+
+			// filter controls
+			content.Row(20).Dynamic(4)
+			content.Label("Account:", "LC")
+			content.Label("Direction:", "LC")
+			content.Label("Type:", "LC")
+			content.Label("Sort:", "LC")
+
+			content.Row(25).Dynamic(4)
+			historyAccountIndex = content.ComboSimple(accountOptions, historyAccountIndex, 25)
+			historyDirectionIndex = content.ComboSimple(historyDirectionOptions, historyDirectionIndex, 25)
+			historyTypeIndex = content.ComboSimple(historyTypeOptions, historyTypeIndex, 25)
+			historySortIndex = content.ComboSimple(walletcore.SortOrders, historySortIndex, 25)
+
+			content.Row(20).Dynamic(4)
+			content.Label("Since (YYYY-MM-DD):", "LC")
+			content.Label("Until (YYYY-MM-DD):", "LC")
+			content.Label("Min Amount (DCR):", "LC")
+			content.Label("Max Amount (DCR):", "LC")
+
+			content.Row(25).Dynamic(4)
+			historySinceInput.Edit(content.Window)
+			historyUntilInput.Edit(content.Window)
+			historyMinAmountInput.Edit(content.Window)
+			historyMaxAmountInput.Edit(content.Window)
+
+			content.Row(30).Static(100, 100, 100)
+			if content.Button(label.T("Filter"), false) {
+				historyOffset = 0
+				reloadTransactions()
+			}
+			if content.Button(label.T("Previous"), false) && historyOffset > 0 {
+				historyOffset -= historyPageSize
+				if historyOffset < 0 {
+					historyOffset = 0
+				}
+				reloadTransactions()
+			}
+			if content.Button(label.T("Next"), false) && len(transactionsResponse) == historyPageSize {
+				historyOffset += historyPageSize
+				reloadTransactions()
+			}
 
 			if err != nil {
 				content.setErrorMessage(err.Error())
 			} else {
-				content.Row(20).Ratio(0.18, 0.12, 0.1, 0.15, 0.15, 0.3)
+				d.drawTransactionLabelEditor(content)
+
+				content.Row(20).Ratio(0.15, 0.1, 0.08, 0.1, 0.08, 0.25, 0.16, 0.08)
 				content.Label("Date", "LC")
 				content.Label("Amount", "LC")
 				content.Label("Fee", "LC")
 				content.Label("Direction", "LC")
 				content.Label("Type", "LC")
 				content.Label("Hash", "LC")
+				content.Label("Label", "LC")
+				content.Label("", "LC")
 
 				for _, tx := range transactionsResponse {
-					content.Row(20).Ratio(0.18, 0.12, 0.1, 0.15, 0.15, 0.3)
+					content.Row(20).Ratio(0.15, 0.1, 0.08, 0.1, 0.08, 0.25, 0.16, 0.08)
 
 					content.Label(tx.FormattedTime, "LC")
 					content.Label(amountToString(tx.Amount.ToCoin()), "LC")
@@ -116,6 +212,10 @@ func (d *Desktop) TransactionsHandler(w *nucular.Window) {
 					content.Label(tx.Direction.String(), "LC")
 					content.Label(tx.Type, "LC")
 					content.Label(tx.Hash, "LC")
+					content.Label(tx.Label, "LC")
+					if content.Button(label.T("Label"), false) {
+						editTransactionLabel(tx)
+					}
 				}
 			}
 			content.end()
@@ -124,6 +224,76 @@ func (d *Desktop) TransactionsHandler(w *nucular.Window) {
 	}
 }
 
+// reloadTransactions clears the fetched transactions so they are fetched again using the current history filter values
+func reloadTransactions() {
+	err = nil
+	transactionsResponse = nil
+}
+
+// historyQuery creates a transaction history query from the values of the transactions page filter controls
+func historyQuery() (*walletcore.TransactionHistoryQuery, error) {
+	query := &walletcore.TransactionHistoryQuery{
+		SortOrder: walletcore.SortOrders[historySortIndex],
+		Offset:    historyOffset,
+		Limit:     historyPageSize,
+	}
+
+	// index 0 of the account, direction and type options means no filtering
+	if historyAccountIndex > 0 && historyAccountIndex <= len(accountsResponse) {
+		query.Accounts = []uint32{accountsResponse[historyAccountIndex-1].Number}
+	}
+	if historyDirectionIndex > 0 {
+		direction, err := walletcore.ParseTransactionDirection(historyDirectionOptions[historyDirectionIndex])
+		if err != nil {
+			return nil, err
+		}
+		query.Directions = []txhelper.TransactionDirection{direction}
+	}
+	if historyTypeIndex > 0 {
+		txType, err := walletcore.ParseTransactionType(historyTypeOptions[historyTypeIndex])
+		if err != nil {
+			return nil, err
+		}
+		query.Types = []string{txType}
+	}
+
+	var err error
+	if since := strings.TrimSpace(string(historySinceInput.Buffer)); since != "" {
+		if query.StartTime, err = walletcore.ParseHistoryDate(since, false); err != nil {
+			return nil, err
+		}
+	}
+	if until := strings.TrimSpace(string(historyUntilInput.Buffer)); until != "" {
+		if query.EndTime, err = walletcore.ParseHistoryDate(until, true); err != nil {
+			return nil, err
+		}
+	}
+	if query.MinAmount, err = amountFromEditor(&historyMinAmountInput); err != nil {
+		return nil, fmt.Errorf("invalid minimum amount: %s", err.Error())
+	}
+	if query.MaxAmount, err = amountFromEditor(&historyMaxAmountInput); err != nil {
+		return nil, fmt.Errorf("invalid maximum amount: %s", err.Error())
+	}
+
+	if err = query.Validate(); err != nil {
+		return nil, err
+	}
+	return query, nil
+}
+
+func amountFromEditor(editor *nucular.TextEditor) (dcrutil.Amount, error) {
+	value := strings.TrimSpace(string(editor.Buffer))
+	if value == "" {
+		return 0, nil
+	}
+
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	return dcrutil.NewAmount(amount)
+}
+
 // subpage belonging to ReceiveHandler
 func (d *Desktop) generateAddressHandler(w *nucular.Window) {
 	if page := newWindow("Generate Address Page", w, 0); page != nil {
@@ -217,7 +387,7 @@ func (d *Desktop) selectUTXOSHandler(w *nucular.Window) {
 				content.setErrorMessage(err.Error())
 			} else {
 				content.Row(20).Dynamic(1)
-				content.LabelColored("Select UTXOS to create custom transaction", "LC", color.RGBA{106, 106, 106, 255})
+				content.LabelColored("Select UTXOS to create custom transaction, or none to select them automatically", "LC", color.RGBA{106, 106, 106, 255})
 
 				content.Row(230).Dynamic(1)
 				if txGroup := content.GroupBegin("UTXOS", 0); txGroup != nil {
@@ -236,14 +406,22 @@ func (d *Desktop) selectUTXOSHandler(w *nucular.Window) {
 					}
 
 					for i, v := range utxosResponse {
+						// locked outputs cannot be spent, so they are listed without a checkbox
+						if v.Locked {
+							txGroup.Label("", "LC")
+							txGroup.LabelColored(utxoDescription(v)+" (locked)", "LC", color.RGBA{106, 106, 106, 255})
+							txGroup.Label(v.Amount.String(), "LC")
+							continue
+						}
+
 						if txGroup.CheckboxText("", &checkedUTXOS[i]) {
 							if checkedUTXOS[i] {
-								selectedUTXOS[i] = v.TransactionHash
+								selectedUTXOS[i] = v.OutputKey
 							} else {
 								selectedUTXOS[i] = ""
 							}
 						}
-						txGroup.Label(v.TransactionHash, "LC")
+						txGroup.Label(utxoDescription(v), "LC")
 						txGroup.Label(v.Amount.String(), "LC")
 						//txGroup.Label("time", "LC")
 					}
@@ -254,7 +432,8 @@ func (d *Desktop) selectUTXOSHandler(w *nucular.Window) {
 				if submitButtonGroup := content.GroupBegin("SubmitButtonGroup", nucular.WindowNoHScrollbar); submitButtonGroup != nil {
 					submitButtonGroup.Row(50).Static(150)
 					if submitButtonGroup.Button(label.T("Next"), false) {
-
+						sendEstimate, sendErr = d.estimateSendTx()
+						d.gotoSubpage("sendsummary")
 					}
 					submitButtonGroup.GroupEnd()
 				}
@@ -279,6 +458,8 @@ func (d *Desktop) SendHandler(w *nucular.Window) {
 		if content := page.contentWindow("Send Content"); content != nil {
 			if err != nil {
 				content.setErrorMessage(err.Error())
+			} else if d.wallet.IsWatchingOnlyWallet() {
+				content.setErrorMessage(walletcore.ErrWatchingOnlyWallet.Error())
 			} else {
 				accounts := make([]string, len(accountsResponse))
 				for index, account := range accountsResponse {
@@ -298,11 +479,29 @@ func (d *Desktop) SendHandler(w *nucular.Window) {
 				amountInput.Edit(content.Window)
 
 				content.Row(25).Dynamic(2)
-				content.Label("Destination Address:", "LC")
+				content.Label("", "LC")
+				content.CheckboxText("Send all (fee is deducted from the amount)", &sendMax)
+
+				content.Row(25).Dynamic(2)
+				content.Label("Destination Address or @contact:", "LC")
+				content.Label("Contact:", "LC")
 
 				content.Row(25).Dynamic(2)
 				// address text input
 				addressInput.Edit(content.Window)
+				d.selectSendContact(content)
+
+				content.Row(15).Dynamic(2)
+				content.Label("Fee Rate (DCR/kB, default 0.0001):", "LC")
+
+				content.Row(25).Dynamic(2)
+				feeRateInput.Edit(content.Window)
+
+				content.Row(15).Dynamic(2)
+				content.Label("Input Selection (if no UTXO is selected):", "LC")
+
+				content.Row(25).Dynamic(2)
+				coinSelectionIndex = content.ComboSimple(walletcore.CoinSelectionStrategies, coinSelectionIndex, 25)
 
 				content.Row(35).Static(300)
 				if content.Button(label.T("Next"), false) {
@@ -0,0 +1,66 @@
+package nuklear
+
+import (
+	"fmt"
+
+	"github.com/aarzilli/nucular"
+	"github.com/aarzilli/nucular/label"
+	"github.com/raedahgroup/godcr/app/walletcore"
+)
+
+var (
+	// labelTxHash is the hash of the transaction whose label is being edited on the transactions page
+	labelTxHash  string
+	txLabelInput nucular.TextEditor
+	txLabelErr   error
+)
+
+// editTransactionLabel shows the label editor for `tx`, filled with its current label
+func editTransactionLabel(tx *walletcore.Transaction) {
+	labelTxHash = tx.Hash
+	txLabelInput.Buffer = []rune(tx.Label)
+	txLabelErr = nil
+}
+
+// drawTransactionLabelEditor shows an input for the label of the transaction being edited, if any.
+// Saving an empty label removes the transaction's label
+func (d *Desktop) drawTransactionLabelEditor(content *window) {
+	if labelTxHash == "" {
+		return
+	}
+
+	content.Row(20).Dynamic(1)
+	content.Label(fmt.Sprintf("Label for %s:", labelTxHash), "LC")
+
+	content.Row(25).Ratio(0.6, 0.2, 0.2)
+	txLabelInput.Edit(content.Window)
+	if content.Button(label.T("Save"), false) {
+		txLabelErr = d.wallet.SetLabel(walletcore.LabelTypeTransaction, labelTxHash, string(txLabelInput.Buffer))
+		if txLabelErr == nil {
+			resetLabelEditor()
+			reloadTransactions()
+		}
+	}
+	if content.Button(label.T("Cancel"), false) {
+		resetLabelEditor()
+	}
+
+	if txLabelErr != nil {
+		content.Row(25).Dynamic(1)
+		content.LabelColored(txLabelErr.Error(), "LC", colorTable.ColorChartColorHighlight)
+	}
+}
+
+func resetLabelEditor() {
+	labelTxHash = ""
+	txLabelInput.Buffer = nil
+	txLabelErr = nil
+}
+
+// utxoDescription identifies an unspent output by its transaction hash, followed by its label if it has one
+func utxoDescription(utxo *walletcore.UnspentOutput) string {
+	if utxo.Label == "" {
+		return utxo.TransactionHash
+	}
+	return fmt.Sprintf("%s \"%s\"", utxo.TransactionHash, utxo.Label)
+}
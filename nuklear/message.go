@@ -0,0 +1,112 @@
+package nuklear
+
+import (
+	"github.com/aarzilli/nucular"
+	"github.com/aarzilli/nucular/label"
+)
+
+var (
+	signAddressInput    nucular.TextEditor
+	signMessageInput    nucular.TextEditor
+	signPassphraseInput = nucular.TextEditor{PasswordChar: '*'}
+	// signatureOutput holds the signature of the last signed message, it is an editor so the signature can be copied
+	signatureOutput nucular.TextEditor
+	signMessageErr  error
+
+	verifyAddressInput   nucular.TextEditor
+	verifyMessageInput   nucular.TextEditor
+	verifySignatureInput nucular.TextEditor
+	verifyMessageResult  string
+	verifyMessageErr     error
+)
+
+// MessageHandler lets the user sign a message with the private key of a wallet address and verify signed messages
+func (d *Desktop) MessageHandler(w *nucular.Window) {
+	if page := newWindow("Message Page", w, 0); page != nil {
+		page.header("Sign/Verify Message")
+
+		if content := page.contentWindow("Message Content"); content != nil {
+			if !d.walletMiddleware.IsWatchingOnlyWallet() {
+				d.drawSignMessage(content)
+			}
+			d.drawVerifyMessage(content)
+			content.end()
+		}
+		page.end()
+	}
+}
+
+func (d *Desktop) drawSignMessage(content *window) {
+	content.Row(25).Dynamic(1)
+	content.Label("Sign message", "LC")
+
+	content.Row(15).Ratio(0.4, 0.4, 0.2)
+	content.Label("Address:", "LC")
+	content.Label("Message:", "LC")
+	content.Label("Passphrase:", "LC")
+
+	content.Row(25).Ratio(0.4, 0.4, 0.2)
+	signAddressInput.Edit(content.Window)
+	signMessageInput.Edit(content.Window)
+	signPassphraseInput.Edit(content.Window)
+
+	content.Row(35).Static(150)
+	if content.Button(label.T("Sign"), false) {
+		signature, err := d.walletMiddleware.SignMessage(string(signAddressInput.Buffer), string(signMessageInput.Buffer),
+			string(signPassphraseInput.Buffer))
+		signMessageErr = err
+		signatureOutput.Buffer = []rune(signature)
+		signPassphraseInput.Buffer = nil
+	}
+
+	if signMessageErr != nil {
+		content.Row(25).Dynamic(1)
+		content.LabelColored(signMessageErr.Error(), "LC", colorTable.ColorChartColorHighlight)
+	} else if len(signatureOutput.Buffer) > 0 {
+		content.Row(25).Ratio(0.2, 0.8)
+		content.Label("Signature:", "LC")
+		signatureOutput.Edit(content.Window)
+	}
+}
+
+func (d *Desktop) drawVerifyMessage(content *window) {
+	content.Row(25).Dynamic(1)
+	content.Label("Verify message", "LC")
+
+	content.Row(15).Ratio(0.3, 0.35, 0.35)
+	content.Label("Address:", "LC")
+	content.Label("Message:", "LC")
+	content.Label("Signature:", "LC")
+
+	content.Row(25).Ratio(0.3, 0.35, 0.35)
+	verifyAddressInput.Edit(content.Window)
+	verifyMessageInput.Edit(content.Window)
+	verifySignatureInput.Edit(content.Window)
+
+	content.Row(35).Static(150)
+	if content.Button(label.T("Verify"), false) {
+		valid, err := d.walletMiddleware.VerifyMessage(string(verifyAddressInput.Buffer), string(verifyMessageInput.Buffer),
+			string(verifySignatureInput.Buffer))
+		verifyMessageErr = err
+		if valid {
+			verifyMessageResult = "The signature is valid"
+		} else {
+			verifyMessageResult = "The signature is not valid for this address and message"
+		}
+	}
+
+	content.Row(25).Dynamic(1)
+	if verifyMessageErr != nil {
+		content.LabelColored(verifyMessageErr.Error(), "LC", colorTable.ColorChartColorHighlight)
+	} else if verifyMessageResult != "" {
+		content.Label(verifyMessageResult, "LC")
+	}
+}
+
+func resetMessageInputs() {
+	signPassphraseInput.Buffer = nil
+	signatureOutput.Buffer = nil
+	signMessageErr = nil
+	verifyMessageResult = ""
+	verifyMessageErr = nil
+}
@@ -0,0 +1,151 @@
+package nuklear
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/aarzilli/nucular"
+	"github.com/aarzilli/nucular/label"
+	"github.com/raedahgroup/godcr/app"
+	"github.com/raedahgroup/godcr/app/walletcore"
+)
+
+var (
+	// restore wallet form inputs
+	restoreSeedInput              = nucular.TextEditor{Flags: nucular.EditBox}
+	restorePassphraseInput        = nucular.TextEditor{PasswordChar: '*'}
+	restoreConfirmPassphraseInput = nucular.TextEditor{PasswordChar: '*'}
+	restoreWalletErr              error
+
+	// restoreSync holds the progress of the blockchain sync started after a wallet is restored
+	// it is updated by the sync listener from a different goroutine
+	restoreSync struct {
+		sync.Mutex
+		started bool
+		status  string
+	}
+)
+
+// RestoreWalletHandler displays a form for restoring a wallet from its seed, shown when no wallet exists
+// the blockchain is synced after the wallet is created and the balance page is displayed when the sync completes
+func (d *Desktop) RestoreWalletHandler(w *nucular.Window) {
+	if page := newWindow("Restore Wallet Page", w, 0); page != nil {
+		page.header("Restore Wallet")
+
+		if content := page.contentWindow("Restore Wallet Content"); content != nil {
+			restoreSync.Lock()
+			syncStarted, syncStatus := restoreSync.started, restoreSync.status
+			restoreSync.Unlock()
+
+			if syncStarted {
+				content.Row(25).Dynamic(1)
+				content.Label("Wallet restored. Syncing the blockchain to find your addresses and transactions.", "LC")
+				content.Row(25).Dynamic(1)
+				content.Label(syncStatus, "LC")
+			} else {
+				d.restoreWalletForm(content)
+			}
+			content.end()
+		}
+		page.end()
+	}
+}
+
+func (d *Desktop) restoreWalletForm(content *window) {
+	content.Row(25).Dynamic(1)
+	content.Label(fmt.Sprintf("No wallet found. Enter the %d-word seed or hex seed of the wallet to restore:", walletcore.SeedWordCount), "LC")
+
+	content.Row(80).Dynamic(1)
+	restoreSeedInput.Edit(content.Window)
+
+	content.Row(15).Dynamic(2)
+	content.Label("Wallet Password:", "LC")
+	content.Label("Confirm Password:", "LC")
+
+	content.Row(25).Dynamic(2)
+	restorePassphraseInput.Edit(content.Window)
+	restoreConfirmPassphraseInput.Edit(content.Window)
+
+	if restoreWalletErr != nil {
+		content.Row(25).Dynamic(1)
+		content.LabelColored(restoreWalletErr.Error(), "LC", colorTable.ColorChartColorHighlight)
+	}
+
+	content.Row(35).Static(300)
+	if content.Button(label.T("Restore Wallet"), false) {
+		restoreWalletErr = d.restoreWallet()
+		content.Master().Changed()
+	}
+}
+
+func (d *Desktop) restoreWallet() error {
+	seed := walletcore.NormalizeSeed(string(restoreSeedInput.Buffer))
+	if err := walletcore.ValidateSeed(seed); err != nil {
+		return err
+	}
+
+	passphrase := string(restorePassphraseInput.Buffer)
+	if passphrase == "" {
+		return fmt.Errorf("wallet password is required")
+	}
+	if passphrase != string(restoreConfirmPassphraseInput.Buffer) {
+		return fmt.Errorf("passwords do not match")
+	}
+
+	if err := d.walletMiddleware.CreateWallet(passphrase, seed); err != nil {
+		return fmt.Errorf("error restoring wallet: %s", err.Error())
+	}
+
+	// clear the seed and password from memory, they are no longer needed
+	restoreSeedInput.Buffer = nil
+	restorePassphraseInput.Buffer = nil
+	restoreConfirmPassphraseInput.Buffer = nil
+
+	return d.syncRestoredWallet()
+}
+
+// syncRestoredWallet syncs the blockchain to discover the addresses and transactions of a restored wallet
+// sync progress is displayed on the restore wallet page
+func (d *Desktop) syncRestoredWallet() error {
+	updateStatus := func(status string) {
+		restoreSync.Lock()
+		restoreSync.status = status
+		restoreSync.Unlock()
+		d.window.Changed()
+	}
+
+	restoreSync.Lock()
+	restoreSync.started = true
+	restoreSync.Unlock()
+
+	err := d.walletMiddleware.SyncBlockChain(&app.BlockChainSyncListener{
+		SyncStarted: func() {
+			updateStatus("Blockchain sync started...")
+		},
+		SyncEnded: func(err error) {
+			if err != nil {
+				updateStatus(fmt.Sprintf("Blockchain sync completed with error: %s", err.Error()))
+				return
+			}
+			d.walletLoaded = true
+			d.gotoPage(homePage)
+		},
+		OnHeadersFetched: func(percentageProgress int64) {
+			updateStatus(fmt.Sprintf("Blockchain sync in progress. Fetching headers (1/3): %d%%", percentageProgress))
+		},
+		OnDiscoveredAddress: func(_ string) {
+			updateStatus("Blockchain sync in progress. Discovering addresses (2/3)")
+		},
+		OnRescanningBlocks: func(percentageProgress int64) {
+			updateStatus(fmt.Sprintf("Blockchain sync in progress. Rescanning blocks (3/3): %d%%", percentageProgress))
+		},
+	}, false)
+
+	if err != nil {
+		restoreSync.Lock()
+		restoreSync.started = false
+		restoreSync.Unlock()
+		return fmt.Errorf("blockchain sync failed to start: %s", err.Error())
+	}
+	return nil
+}
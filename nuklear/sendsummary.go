@@ -0,0 +1,168 @@
+package nuklear
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/aarzilli/nucular"
+	"github.com/aarzilli/nucular/label"
+	"github.com/raedahgroup/dcrlibwallet/txhelper"
+	"github.com/raedahgroup/godcr/app/addressbook"
+	"github.com/raedahgroup/godcr/app/walletcore"
+)
+
+var (
+	// send form inputs not shared with other pages
+	feeRateInput        nucular.TextEditor
+	sendPassphraseInput = nucular.TextEditor{PasswordChar: '*'}
+	sendMax             bool
+	coinSelectionIndex  = 0
+
+	// sendEstimate is the transaction previewed on the send summary page, it is sent once the user enters their passphrase
+	sendEstimate *walletcore.TransactionEstimate
+	sendErr      error
+	sentTxHash   string
+)
+
+// estimateSendTx estimates the transaction described by the send form and the utxos selected by the user
+// inputs are selected automatically if the user did not select any utxo
+// if the user chose to send all, all the selected utxos or all utxos in the account are spent and the fee is deducted from the amount
+func (d *Desktop) estimateSendTx() (*walletcore.TransactionEstimate, error) {
+	book, err := addressbook.Open(d.wallet.NetType())
+	if err != nil {
+		return nil, fmt.Errorf("error opening address book: %s", err.Error())
+	}
+	address, err := book.ResolveAddress(string(addressInput.Buffer))
+	if err != nil {
+		return nil, err
+	}
+
+	isValid, err := d.wallet.ValidateAddress(address)
+	if err != nil {
+		return nil, fmt.Errorf("error validating address: %s", err.Error())
+	}
+	if !isValid {
+		return nil, fmt.Errorf("invalid destination address: %s", address)
+	}
+
+	var feeRateDcr float64
+	if feeRateStr := string(feeRateInput.Buffer); feeRateStr != "" {
+		feeRateDcr, err = strconv.ParseFloat(feeRateStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fee rate: %s", err.Error())
+		}
+	}
+	feeRate, err := walletcore.ParseFeeRate(feeRateDcr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fee rate: %s", err.Error())
+	}
+
+	var utxoKeys []string
+	for _, key := range selectedUTXOS {
+		if key != "" {
+			utxoKeys = append(utxoKeys, key)
+		}
+	}
+
+	if sendMax {
+		return d.wallet.EstimateSweepTransaction(selectedAccountNumber, walletcore.DefaultRequiredConfirmations, address, utxoKeys, feeRate)
+	}
+
+	amount, err := strconv.ParseFloat(string(amountInput.Buffer), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount: %s", err.Error())
+	}
+
+	destinations := []txhelper.TransactionDestination{{Address: address, Amount: amount}}
+	coinSelection := walletcore.CoinSelectionStrategies[coinSelectionIndex]
+	return d.wallet.EstimateTransaction(selectedAccountNumber, walletcore.DefaultRequiredConfirmations, destinations, utxoKeys, coinSelection, feeRate)
+}
+
+// sendSummaryHandler displays the inputs, change, size and fee of the transaction being sent
+// and asks for the wallet passphrase to send it
+func (d *Desktop) sendSummaryHandler(w *nucular.Window) {
+	if page := newWindow("Send Summary Page", w, 0); page != nil {
+		page.header("Confirm Transaction")
+
+		if content := page.contentWindow("Send Summary Content"); content != nil {
+			if sentTxHash != "" {
+				content.Row(25).Dynamic(1)
+				content.Label(fmt.Sprintf("The transaction was published successfully. Hash: %s", sentTxHash), "LC")
+			} else if sendEstimate == nil {
+				content.setErrorMessage(sendErr.Error())
+			} else {
+				d.sendSummary(content)
+			}
+			content.end()
+		}
+		page.end()
+	}
+}
+
+func (d *Desktop) sendSummary(content *window) {
+	content.Row(20).Ratio(0.15, 0.6, 0.25)
+	content.Label("", "LC")
+	content.Label("Address", "LC")
+	content.Label("Amount", "LC")
+
+	for _, input := range sendEstimate.Inputs {
+		content.Row(20).Ratio(0.15, 0.6, 0.25)
+		content.Label("Input", "LC")
+		content.Label(input.Address, "LC")
+		content.Label(input.Amount.String(), "LC")
+	}
+	for _, destination := range sendEstimate.Destinations {
+		content.Row(20).Ratio(0.15, 0.6, 0.25)
+		content.Label("Send", "LC")
+		content.Label(destination.Address, "LC")
+		content.Label(fmt.Sprintf("%f DCR", destination.Amount), "LC")
+	}
+	if sendEstimate.Change > 0 {
+		content.Row(20).Ratio(0.15, 0.6, 0.25)
+		content.Label("Change", "LC")
+		content.Label("New address in source account", "LC")
+		content.Label(sendEstimate.Change.String(), "LC")
+	}
+
+	content.Row(20).Dynamic(1)
+	content.Label(fmt.Sprintf("Estimated size: %d bytes", sendEstimate.Size), "LC")
+	content.Row(20).Dynamic(1)
+	content.Label(fmt.Sprintf("Fee: %s (%s/kB)", sendEstimate.Fee.String(), sendEstimate.FeeRate.String()), "LC")
+
+	content.Row(15).Dynamic(2)
+	content.Label("Wallet Password:", "LC")
+
+	content.Row(25).Dynamic(2)
+	sendPassphraseInput.Edit(content.Window)
+
+	if sendErr != nil {
+		content.Row(25).Dynamic(1)
+		content.LabelColored(sendErr.Error(), "LC", colorTable.ColorChartColorHighlight)
+	}
+
+	content.Row(35).Static(150, 150)
+	if content.Button(label.T("Back"), false) {
+		sendErr = nil
+		d.gotoSubpage("send")
+	}
+	if content.Button(label.T("Send"), false) {
+		sendErr = d.sendEstimatedTx()
+		content.Master().Changed()
+	}
+}
+
+func (d *Desktop) sendEstimatedTx() error {
+	passphrase := string(sendPassphraseInput.Buffer)
+	if passphrase == "" {
+		return fmt.Errorf("wallet password is required")
+	}
+
+	txHash, err := walletcore.SendEstimatedTransaction(d.wallet, sendEstimate, passphrase)
+	if err != nil {
+		return fmt.Errorf("error sending transaction: %s", err.Error())
+	}
+
+	sendPassphraseInput.Buffer = nil
+	sentTxHash = txHash
+	return nil
+}
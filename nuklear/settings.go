@@ -0,0 +1,152 @@
+package nuklear
+
+import (
+	"fmt"
+
+	"github.com/aarzilli/nucular"
+	"github.com/aarzilli/nucular/label"
+	"github.com/raedahgroup/godcr/app"
+)
+
+var (
+	publicPassphraseInput = nucular.TextEditor{PasswordChar: '*'}
+	openWalletErr         error
+
+	// changingPublicPassphrase is true if the passphrase inputs on the settings page change the public passphrase
+	// instead of the spending passphrase
+	changingPublicPassphrase bool
+	oldPassphraseInput       = nucular.TextEditor{PasswordChar: '*'}
+	newPassphraseInput       = nucular.TextEditor{PasswordChar: '*'}
+	confirmPassphraseInput   = nucular.TextEditor{PasswordChar: '*'}
+	changePassphraseErr      error
+	changePassphraseResult   string
+)
+
+// OpenWalletHandler asks for the public passphrase of a wallet that does not use the default public passphrase and opens the wallet
+func (d *Desktop) OpenWalletHandler(w *nucular.Window) {
+	if page := newWindow("Open Wallet Page", w, 0); page != nil {
+		page.header("Open Wallet")
+
+		if content := page.contentWindow("Open Wallet Content"); content != nil {
+			content.Row(25).Dynamic(1)
+			content.Label("This wallet uses a custom public passphrase. Enter it to open the wallet", "LC")
+
+			content.Row(25).Ratio(0.3, 0.7)
+			content.Label("Public Passphrase:", "LC")
+			publicPassphraseInput.Edit(content.Window)
+
+			content.Row(35).Static(150)
+			if content.Button(label.T("Open"), false) {
+				openWalletErr = d.walletMiddleware.OpenWallet(string(publicPassphraseInput.Buffer))
+				publicPassphraseInput.Buffer = nil
+				if openWalletErr == nil {
+					d.walletLoaded = true
+					d.gotoPage(homePage)
+				}
+			}
+
+			if openWalletErr != nil {
+				content.Row(25).Dynamic(1)
+				content.LabelColored(openWalletErr.Error(), "LC", colorTable.ColorChartColorHighlight)
+			}
+			content.end()
+		}
+		page.end()
+	}
+}
+
+// SettingsHandler lets the user change the spending and public passphrases of the wallet
+func (d *Desktop) SettingsHandler(w *nucular.Window) {
+	if page := newWindow("Settings Page", w, 0); page != nil {
+		page.header("Settings")
+
+		if content := page.contentWindow("Settings Content"); content != nil {
+			d.drawChangePassphrase(content)
+			content.end()
+		}
+		page.end()
+	}
+}
+
+func (d *Desktop) drawChangePassphrase(content *window) {
+	content.Row(25).Dynamic(1)
+	content.Label("Change passphrase", "LC")
+
+	// watch-only wallets have no spending passphrase, only the public passphrase can be changed
+	watchingOnly := d.walletMiddleware.IsWatchingOnlyWallet()
+	if watchingOnly {
+		changingPublicPassphrase = true
+	} else {
+		content.Row(25).Dynamic(2)
+		if content.OptionText("Spending passphrase", !changingPublicPassphrase) {
+			changingPublicPassphrase = false
+		}
+		if content.OptionText("Public passphrase", changingPublicPassphrase) {
+			changingPublicPassphrase = true
+		}
+	}
+
+	if changingPublicPassphrase {
+		content.Row(25).Dynamic(1)
+		content.Label(fmt.Sprintf("The public passphrase opens the wallet. Unless it is '%s', it is asked for whenever the wallet is opened",
+			app.DefaultPublicPassphrase), "LC")
+	}
+
+	content.Row(15).Ratio(0.33, 0.33, 0.34)
+	content.Label("Current passphrase:", "LC")
+	content.Label("New passphrase:", "LC")
+	content.Label("Confirm new passphrase:", "LC")
+
+	content.Row(25).Ratio(0.33, 0.33, 0.34)
+	oldPassphraseInput.Edit(content.Window)
+	newPassphraseInput.Edit(content.Window)
+	confirmPassphraseInput.Edit(content.Window)
+
+	content.Row(35).Static(150)
+	if content.Button(label.T("Change"), false) {
+		changePassphraseResult, changePassphraseErr = d.changePassphrase()
+		oldPassphraseInput.Buffer = nil
+		newPassphraseInput.Buffer = nil
+		confirmPassphraseInput.Buffer = nil
+	}
+
+	content.Row(25).Dynamic(1)
+	if changePassphraseErr != nil {
+		content.LabelColored(changePassphraseErr.Error(), "LC", colorTable.ColorChartColorHighlight)
+	} else if changePassphraseResult != "" {
+		content.Label(changePassphraseResult, "LC")
+	}
+}
+
+func (d *Desktop) changePassphrase() (string, error) {
+	oldPassphrase := string(oldPassphraseInput.Buffer)
+	newPassphrase := string(newPassphraseInput.Buffer)
+	if newPassphrase == "" {
+		return "", fmt.Errorf("the new passphrase cannot be empty")
+	}
+	if newPassphrase != string(confirmPassphraseInput.Buffer) {
+		return "", fmt.Errorf("the new passphrases do not match")
+	}
+
+	if changingPublicPassphrase {
+		if err := d.walletMiddleware.ChangePublicPassphrase(oldPassphrase, newPassphrase); err != nil {
+			return "", err
+		}
+		return "Public passphrase changed", nil
+	}
+
+	if err := d.walletMiddleware.ChangePrivatePassphrase(oldPassphrase, newPassphrase); err != nil {
+		return "", err
+	}
+	return "Spending passphrase changed", nil
+}
+
+func resetSettingsInputs() {
+	publicPassphraseInput.Buffer = nil
+	openWalletErr = nil
+	oldPassphraseInput.Buffer = nil
+	newPassphraseInput.Buffer = nil
+	confirmPassphraseInput.Buffer = nil
+	changePassphraseErr = nil
+	changePassphraseResult = ""
+}
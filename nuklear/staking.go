@@ -0,0 +1,218 @@
+package nuklear
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aarzilli/nucular"
+	"github.com/aarzilli/nucular/label"
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/raedahgroup/godcr/app/walletcore"
+)
+
+var (
+	stakingAccounts    []*walletcore.Account
+	stakeInfo          *walletcore.StakeInfo
+	stakingTickets     []*walletcore.Ticket
+	stakingErr         error
+	ticketAccountIndex int
+	ticketStatusIndex  int
+
+	revokePassphraseInput = nucular.TextEditor{PasswordChar: '*'}
+	revokedTickets        []*walletcore.RevokedTicket
+	revokeErr             error
+
+	// ticketStatusOptions are the options of the status filter on the staking page, the first option shows all tickets
+	ticketStatusOptions = append([]string{"All statuses"}, walletcore.TicketStatuses...)
+)
+
+// StakingHandler shows the wallet's stake info and lists its tickets, filtered by account and status
+func (d *Desktop) StakingHandler(w *nucular.Window) {
+	if stakingTickets == nil && stakingErr == nil {
+		d.fetchStakingData()
+	}
+
+	if page := newWindow("Staking Page", w, 0); page != nil {
+		page.header("Staking")
+
+		if content := page.contentWindow("Staking Content"); content != nil {
+			if stakeInfo != nil {
+				content.Row(20).Dynamic(1)
+				content.Label(fmt.Sprintf("Unmined %d  Immature %d  Live %d  Voted %d  Missed %d  Expired %d  Revoked %d  Total reward %s",
+					stakeInfo.OwnMempoolTix, stakeInfo.Immature, stakeInfo.Live, stakeInfo.Voted, stakeInfo.Missed,
+					stakeInfo.Expired, stakeInfo.Revoked, amountToString(dcrutil.Amount(stakeInfo.TotalSubsidy).ToCoin())), "LC")
+			}
+
+			accountOptions := []string{"All accounts"}
+			for _, account := range stakingAccounts {
+				accountOptions = append(accountOptions, account.Name)
+			}
+
+			content.Row(20).Dynamic(3)
+			content.Label("Account:", "LC")
+			content.Label("Status:", "LC")
+			content.Label("", "LC")
+
+			content.Row(25).Dynamic(3)
+			ticketAccountIndex = content.ComboSimple(accountOptions, ticketAccountIndex, 25)
+			ticketStatusIndex = content.ComboSimple(ticketStatusOptions, ticketStatusIndex, 25)
+			if content.Button(label.T("Filter"), false) {
+				reloadTickets()
+			}
+
+			if stakingErr != nil {
+				content.setErrorMessage(stakingErr.Error())
+			} else {
+				d.drawRevokeTickets(content)
+				d.drawTickets(content)
+			}
+			content.end()
+		}
+		page.end()
+	}
+}
+
+// drawRevokeTickets shows the passphrase input used to revoke tickets and the result of the last revocation
+func (d *Desktop) drawRevokeTickets(content *window) {
+	if d.walletMiddleware.IsWatchingOnlyWallet() || stakeInfo == nil || stakeInfo.Missed+stakeInfo.Expired == 0 {
+		return
+	}
+
+	content.Row(25).Dynamic(1)
+	content.Label("Missed and expired tickets must be revoked to recover the ticket price", "LC")
+
+	content.Row(25).Ratio(0.3, 0.4, 0.3)
+	content.Label("Spending Passphrase:", "LC")
+	revokePassphraseInput.Edit(content.Window)
+	if content.Button(label.T("Revoke All Missed and Expired"), false) {
+		revokedTickets, revokeErr = d.walletMiddleware.RevokeTickets(context.Background(), string(revokePassphraseInput.Buffer))
+		d.ticketsRevoked()
+	}
+
+	if revokeErr != nil {
+		content.Row(25).Dynamic(1)
+		content.LabelColored(revokeErr.Error(), "LC", colorTable.ColorChartColorHighlight)
+	}
+	if len(revokedTickets) > 0 {
+		content.Row(20).Dynamic(1)
+		content.Label(fmt.Sprintf("Revoked %d ticket(s):", len(revokedTickets)), "LC")
+		for _, revoked := range revokedTickets {
+			content.Row(20).Dynamic(1)
+			content.Label(fmt.Sprintf("%s, revocation %s", revoked.TicketHash, revoked.RevocationHash), "LC")
+		}
+	}
+}
+
+// revokeTicket revokes the ticket with hash `ticketHash` using the passphrase entered on the staking page
+func (d *Desktop) revokeTicket(ticketHash string) {
+	revokedTickets = nil
+	var revocationHash string
+	revocationHash, revokeErr = d.walletMiddleware.RevokeTicket(context.Background(), ticketHash, string(revokePassphraseInput.Buffer))
+	if revokeErr == nil {
+		revokedTickets = []*walletcore.RevokedTicket{{TicketHash: ticketHash, RevocationHash: revocationHash}}
+	}
+	d.ticketsRevoked()
+}
+
+// ticketsRevoked clears the passphrase input and fetches the tickets again to show their new status
+func (d *Desktop) ticketsRevoked() {
+	revokePassphraseInput.Buffer = nil
+	reloadTickets()
+}
+
+func (d *Desktop) drawTickets(content *window) {
+	content.Row(20).Ratio(0.3, 0.1, 0.1, 0.1, 0.25, 0.08, 0.07)
+	content.Label("Hash", "LC")
+	content.Label("Status", "LC")
+	content.Label("Price", "LC")
+	content.Label("Height", "LC")
+	content.Label("Vote", "LC")
+	content.Label("Reward", "LC")
+	content.Label("", "LC")
+
+	if len(stakingTickets) == 0 {
+		content.Row(20).Dynamic(1)
+		content.Label("No tickets found", "LC")
+		return
+	}
+
+	for _, ticket := range stakingTickets {
+		purchaseHeight := "unmined"
+		if ticket.PurchaseHeight >= 0 {
+			purchaseHeight = fmt.Sprint(ticket.PurchaseHeight)
+		}
+
+		spenderHash := ticket.VoteHash
+		if ticket.RevocationHash != "" {
+			spenderHash = ticket.RevocationHash + " (revocation)"
+		}
+
+		var reward string
+		if ticket.Reward != 0 {
+			reward = amountToString(ticket.Reward.ToCoin())
+		}
+
+		content.Row(20).Ratio(0.3, 0.1, 0.1, 0.1, 0.25, 0.08, 0.07)
+		content.Label(ticket.Hash, "LC")
+		content.Label(ticket.Status, "LC")
+		content.Label(amountToString(ticket.Price.ToCoin()), "LC")
+		content.Label(purchaseHeight, "LC")
+		content.Label(spenderHash, "LC")
+		content.Label(reward, "LC")
+		if ticket.Revokable() && !d.walletMiddleware.IsWatchingOnlyWallet() {
+			if content.Button(label.T("Revoke"), false) {
+				d.revokeTicket(ticket.Hash)
+			}
+		} else {
+			content.Label("", "LC")
+		}
+	}
+}
+
+// fetchStakingData fetches the stake info and the tickets that match the current filter values of the staking page
+func (d *Desktop) fetchStakingData() {
+	ctx := context.Background()
+
+	if stakingAccounts == nil {
+		stakingAccounts, stakingErr = d.walletMiddleware.AccountsOverview(walletcore.DefaultRequiredConfirmations)
+		if stakingErr != nil {
+			return
+		}
+	}
+
+	stakeInfo, stakingErr = d.walletMiddleware.StakeInfo(ctx)
+	if stakingErr != nil {
+		return
+	}
+
+	filter := &walletcore.TicketFilter{}
+	if ticketAccountIndex > 0 && ticketAccountIndex <= len(stakingAccounts) {
+		filter.Accounts = []uint32{stakingAccounts[ticketAccountIndex-1].Number}
+	}
+	if ticketStatusIndex > 0 {
+		filter.Statuses = []string{ticketStatusOptions[ticketStatusIndex]}
+	}
+
+	stakingTickets, stakingErr = d.walletMiddleware.Tickets(ctx, filter)
+	if stakingErr == nil && stakingTickets == nil {
+		// an empty list marks the tickets as fetched
+		stakingTickets = []*walletcore.Ticket{}
+	}
+}
+
+// reloadTickets clears the fetched tickets so they are fetched again using the current filter values
+func reloadTickets() {
+	stakingErr = nil
+	stakingTickets = nil
+}
+
+func resetStakingInputs() {
+	stakingAccounts = nil
+	stakeInfo = nil
+	ticketAccountIndex = 0
+	ticketStatusIndex = 0
+	revokePassphraseInput.Buffer = nil
+	revokedTickets = nil
+	revokeErr = nil
+	reloadTickets()
+}
@@ -27,8 +27,11 @@ func openWalletIfExist(ctx context.Context, walletMiddleware app.WalletMiddlewar
 			return
 		}
 
-		err = walletMiddleware.OpenWallet()
-		if err != nil {
+		// wallets with a custom public passphrase are opened from the open wallet page
+		err = walletMiddleware.OpenWallet("")
+		if err == app.ErrInvalidPublicPassphrase {
+			err = nil
+		} else if err != nil {
 			errMsg = fmt.Sprintf("Failed to open %s wallet", walletMiddleware.NetType())
 		}
 	}()
@@ -0,0 +1,78 @@
+package nuklear
+
+import (
+	"fmt"
+
+	"github.com/aarzilli/nucular"
+	"github.com/aarzilli/nucular/label"
+)
+
+var switchWalletErr error
+
+// WalletsHandler lists the wallet profiles set in the config file and lets the user switch to another wallet
+func (d *Desktop) WalletsHandler(w *nucular.Window) {
+	if page := newWindow("Wallets Page", w, 0); page != nil {
+		page.header("Wallets")
+
+		if content := page.contentWindow("Wallets Content"); content != nil {
+			currentProfile := d.walletRegistry.CurrentProfile()
+
+			content.Row(20).Ratio(0.2, 0.15, 0.15, 0.35, 0.15)
+			content.Label("Name", "LC")
+			content.Label("Network", "LC")
+			content.Label("Medium", "LC")
+			content.Label("Location", "LC")
+			content.Label("", "LC")
+
+			for _, profile := range d.walletRegistry.Profiles() {
+				content.Row(30).Ratio(0.2, 0.15, 0.15, 0.35, 0.15)
+				content.Label(profile.Name, "LC")
+				content.Label(profile.NetType(), "LC")
+				content.Label(profile.Medium(), "LC")
+				content.Label(profile.Location(), "LC")
+
+				if profile.Name == currentProfile.Name {
+					content.Label("Current", "LC")
+				} else if content.Button(label.T("Switch"), false) {
+					switchWalletErr = d.switchWallet(profile.Name)
+				}
+			}
+
+			if switchWalletErr != nil {
+				content.Row(25).Dynamic(1)
+				content.LabelColored(switchWalletErr.Error(), "LC", colorTable.ColorChartColorHighlight)
+			}
+			content.end()
+		}
+		page.end()
+	}
+}
+
+// switchWallet selects the wallet of another profile, showing the restore wallet page if the wallet does not exist
+func (d *Desktop) switchWallet(profileName string) error {
+	if err := d.walletRegistry.SwitchWallet(profileName); err != nil {
+		return err
+	}
+
+	walletExists, err := d.walletMiddleware.WalletExists()
+	if err != nil {
+		return fmt.Errorf("error checking wallet: %s", err.Error())
+	}
+
+	restoreSync.Lock()
+	restoreSync.started = false
+	restoreSync.status = ""
+	restoreSync.Unlock()
+
+	d.walletLoaded = walletExists && d.walletMiddleware.IsWalletOpen()
+	if d.walletLoaded {
+		d.gotoPage(homePage)
+	} else if walletExists {
+		// the wallet uses a custom public passphrase, ask the user for it
+		resetSettingsInputs()
+		d.gotoPage("openwallet")
+	} else {
+		d.gotoPage("restorewallet")
+	}
+	return nil
+}
@@ -25,7 +25,7 @@ func LaunchApp(ctx context.Context, walletMiddleware app.WalletMiddleware) error
 
 	// todo check if wallet exists and if not, show a create wallet page instead
 
-	err := walletMiddleware.OpenWallet()
+	err := walletMiddleware.OpenWallet("")
 	if err != nil {
 		return err
 	}
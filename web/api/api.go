@@ -0,0 +1,159 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/raedahgroup/godcr/app"
+)
+
+// Version is the current version of the json api, all api routes are served under /api/{Version}
+const Version = "v1"
+
+// API holds data required to process json api requests
+type API struct {
+	walletMiddleware app.WalletMiddleware
+
+	// checkBlockchainSynced returns an error describing the current blockchain sync status if the blockchain is not synced
+	checkBlockchainSynced func() error
+}
+
+// errorResponse is the json envelope used for all api error responses
+type errorResponse struct {
+	Success bool `json:"success"`
+	Error   struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// successResponse is the json envelope used for all successful api responses
+type successResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data"`
+}
+
+// Router creates a router for all json api routes, to be mounted at /api/{Version}
+func Router(walletMiddleware app.WalletMiddleware, checkBlockchainSynced func() error) chi.Router {
+	api := &API{
+		walletMiddleware:      walletMiddleware,
+		checkBlockchainSynced: checkBlockchainSynced,
+	}
+
+	router := chi.NewRouter()
+	router.NotFound(func(res http.ResponseWriter, req *http.Request) {
+		renderError(res, http.StatusNotFound, "no api route for %s %s", req.Method, req.URL.Path)
+	})
+	router.MethodNotAllowed(func(res http.ResponseWriter, req *http.Request) {
+		renderError(res, http.StatusMethodNotAllowed, "method %s is not allowed for %s", req.Method, req.URL.Path)
+	})
+
+	router.Group(api.registerRoutes)
+	return router
+}
+
+func (api *API) registerRoutes(router chi.Router) {
+	// all api routes require the wallet to be open and the blockchain to be synced
+	router.Use(api.walletLoaderMiddleware)
+
+	router.Get("/accounts", api.accounts)
+	router.Post("/accounts", api.createAccount)
+	router.Get("/accounts/details", api.accountDetails)
+	router.Get("/accounts/by-name/{accountName}", api.accountByName)
+	router.Get("/accounts/{accountNumber}", api.account)
+	router.Get("/accounts/{accountNumber}/balance", api.accountBalance)
+	router.Post("/accounts/{accountNumber}/rename", api.renameAccount)
+	router.Post("/accounts/{accountNumber}/hide", api.hideAccount)
+	router.Get("/accounts/{accountNumber}/receive-address", api.receiveAddress)
+	router.Post("/accounts/{accountNumber}/addresses", api.generateNewAddress)
+	router.Get("/accounts/{accountNumber}/unspent-outputs", api.unspentOutputs)
+
+	router.Get("/unspent-outputs/locked", api.lockedOutputs)
+	router.Post("/unspent-outputs/lock", api.lockOutputs)
+	router.Post("/unspent-outputs/unlock", api.unlockOutputs)
+
+	router.Get("/addresses/{address}", api.addressInfo)
+
+	router.Get("/labels", api.labels)
+	router.Post("/labels", api.setLabel)
+	router.Post("/labels/import", api.importLabels)
+
+	router.Get("/contacts", api.contacts)
+	router.Post("/contacts", api.addContact)
+	router.Put("/contacts/{name}", api.editContact)
+	router.Delete("/contacts/{name}", api.removeContact)
+
+	router.Post("/send", api.send)
+	router.Post("/send/estimate", api.estimateSend)
+
+	router.Post("/messages/sign", api.signMessage)
+	router.Post("/messages/verify", api.verifyMessage)
+
+	router.Get("/transactions", api.transactionHistory)
+	router.Get("/transactions/{hash}", api.transactionDetails)
+
+	router.Get("/stake-info", api.stakeInfo)
+	router.Get("/tickets", api.tickets)
+	router.Post("/tickets", api.purchaseTickets)
+	router.Post("/tickets/revoke", api.revokeTickets)
+}
+
+// walletLoaderMiddleware responds with an error and does not call the actual route handler if
+// - wallet doesn't exist (hasn't been created)
+// - wallet exists but is not open
+// - wallet is open but blockchain isn't synced
+func (api *API) walletLoaderMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		walletExists, err := api.walletMiddleware.WalletExists()
+		if err != nil {
+			renderError(res, http.StatusInternalServerError, "error checking for wallet: %s", err.Error())
+			return
+		}
+		if !walletExists {
+			renderError(res, http.StatusServiceUnavailable, "wallet not found, create a wallet first")
+			return
+		}
+
+		if !api.walletMiddleware.IsWalletOpen() {
+			renderError(res, http.StatusServiceUnavailable, "wallet is not open, restart the server")
+			return
+		}
+
+		if err = api.checkBlockchainSynced(); err != nil {
+			renderError(res, http.StatusServiceUnavailable, "%s", err.Error())
+			return
+		}
+
+		next.ServeHTTP(res, req)
+	})
+}
+
+func renderData(res http.ResponseWriter, data interface{}) {
+	renderJSON(res, http.StatusOK, &successResponse{
+		Success: true,
+		Data:    data,
+	})
+}
+
+func renderError(res http.ResponseWriter, statusCode int, messageFormat string, args ...interface{}) {
+	response := &errorResponse{}
+	response.Error.Code = statusCode
+	response.Error.Message = fmt.Sprintf(messageFormat, args...)
+	renderJSON(res, statusCode, response)
+}
+
+func renderJSON(res http.ResponseWriter, statusCode int, response interface{}) {
+	d, err := json.Marshal(response)
+	if err != nil {
+		log.Printf("error marshalling api response: %s", err.Error())
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(statusCode)
+	res.Write(d)
+}
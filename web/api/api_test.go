@@ -0,0 +1,103 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/raedahgroup/godcr/app/walletmediums/mockwallet"
+)
+
+func blockchainSynced() error {
+	return nil
+}
+
+// openMockWallet returns a pre-populated mock wallet that is open, as it would be after the web server opens it
+func openMockWallet(t *testing.T) *mockwallet.MockWallet {
+	mock := mockwallet.New("testnet")
+	if err := mock.OpenWallet(""); err != nil {
+		t.Fatalf("error opening mock wallet: %s", err.Error())
+	}
+	return mock
+}
+
+// request sends a request to `router` and returns the response status code and the decoded response envelope
+func request(t *testing.T, router chi.Router, method, path, body string) (int, map[string]interface{}) {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(res.Body.Bytes(), &response); err != nil {
+		t.Fatalf("%s %s: response is not json: %s", method, path, err.Error())
+	}
+	return res.Code, response
+}
+
+func TestRoutesWithMockWallet(t *testing.T) {
+	router := Router(openMockWallet(t), blockchainSynced)
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{http.MethodGet, "/accounts", "", http.StatusOK},
+		{http.MethodGet, "/accounts/details", "", http.StatusOK},
+		{http.MethodGet, "/accounts/0", "", http.StatusOK},
+		{http.MethodGet, "/accounts/0/balance", "", http.StatusOK},
+		{http.MethodGet, "/accounts/0/unspent-outputs", "", http.StatusOK},
+		{http.MethodGet, "/accounts/by-name/default", "", http.StatusOK},
+		{http.MethodGet, "/unspent-outputs/locked", "", http.StatusOK},
+		{http.MethodGet, "/labels", "", http.StatusOK},
+		{http.MethodGet, "/transactions", "", http.StatusOK},
+		{http.MethodGet, "/transactions?limit=2&sort=oldest", "", http.StatusOK},
+		{http.MethodGet, "/stake-info", "", http.StatusOK},
+		{http.MethodGet, "/tickets", "", http.StatusOK},
+		{http.MethodPost, "/accounts", `{"name": "spending", "passphrase": "` + mockwallet.DefaultPrivatePassphrase + `"}`, http.StatusOK},
+		{http.MethodPost, "/accounts", `{"name": ""}`, http.StatusBadRequest},
+		{http.MethodPost, "/accounts", `not json`, http.StatusBadRequest},
+		{http.MethodPost, "/accounts/0/rename", `{"name": "main"}`, http.StatusOK},
+		{http.MethodPost, "/accounts/0/rename", `{"name": ""}`, http.StatusBadRequest},
+		{http.MethodPost, "/accounts/0/rename", `{"name": "savings"}`, http.StatusBadRequest},
+		{http.MethodGet, "/accounts/not-a-number", "", http.StatusBadRequest},
+		{http.MethodGet, "/transactions/not-a-hash", "", http.StatusBadRequest},
+		{http.MethodGet, "/no-such-route", "", http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		status, response := request(t, router, test.method, test.path, test.body)
+		if status != test.status {
+			t.Errorf("%s %s: status %d, expected %d: %v", test.method, test.path, status, test.status, response)
+			continue
+		}
+		if success := response["success"] == true; success != (test.status == http.StatusOK) {
+			t.Errorf("%s %s: success is %v for status %d", test.method, test.path, response["success"], status)
+		}
+	}
+}
+
+func TestRoutesWithEmptyMockWallet(t *testing.T) {
+	router := Router(mockwallet.NewEmpty("testnet"), blockchainSynced)
+
+	status, response := request(t, router, http.MethodGet, "/accounts", "")
+	if status != http.StatusServiceUnavailable {
+		t.Errorf("status %d, expected %d: %v", status, http.StatusServiceUnavailable, response)
+	}
+}
+
+func TestRoutesBeforeBlockchainSync(t *testing.T) {
+	router := Router(openMockWallet(t), func() error {
+		return errors.New("Blockchain hasn't been synced")
+	})
+
+	status, response := request(t, router, http.MethodGet, "/accounts", "")
+	if status != http.StatusServiceUnavailable {
+		t.Errorf("status %d, expected %d: %v", status, http.StatusServiceUnavailable, response)
+	}
+}
@@ -0,0 +1,898 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/go-chi/chi"
+	"github.com/raedahgroup/dcrlibwallet"
+	"github.com/raedahgroup/dcrlibwallet/txhelper"
+	"github.com/raedahgroup/godcr/app/addressbook"
+	"github.com/raedahgroup/godcr/app/walletcore"
+	"github.com/raedahgroup/godcr/web/routes"
+)
+
+type destination struct {
+	Address string  `json:"address"`
+	Amount  float64 `json:"amount"`
+}
+
+type createAccountRequest struct {
+	Name       string `json:"name"`
+	Passphrase string `json:"passphrase"`
+}
+
+type renameAccountRequest struct {
+	Name string `json:"name"`
+}
+
+type hideAccountRequest struct {
+	Hidden bool `json:"hidden"`
+}
+
+type sendRequest struct {
+	SourceAccount      uint32         `json:"source_account"`
+	Destinations       []*destination `json:"destinations"`
+	Utxos              []string       `json:"utxos"`
+	ChangeDestinations []*destination `json:"change_destinations"`
+	SpendUnconfirmed   bool           `json:"spend_unconfirmed"`
+	FeeRate            float64        `json:"fee_rate"`
+	SendMax            bool           `json:"send_max"`
+	CoinSelection      string         `json:"coin_selection"`
+	Passphrase         string         `json:"passphrase"`
+}
+
+type outputKeysRequest struct {
+	Keys []string `json:"keys"`
+}
+
+type contactRequest struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+type signMessageRequest struct {
+	Address    string `json:"address"`
+	Message    string `json:"message"`
+	Passphrase string `json:"passphrase"`
+}
+
+type verifyMessageRequest struct {
+	Address   string `json:"address"`
+	Message   string `json:"message"`
+	Signature string `json:"signature"`
+}
+
+type purchaseTicketsRequest struct {
+	Account          uint32  `json:"account"`
+	NumTickets       uint32  `json:"num_tickets"`
+	MinConfirmations *uint32 `json:"min_conf"`
+	TicketAddress    string  `json:"ticket_address"`
+	PoolAddress      string  `json:"pool_address"`
+	PoolFees         float64 `json:"pool_fees"`
+	Expiry           uint32  `json:"expiry"`
+	TxFee            int64   `json:"tx_fee"`
+	TicketFee        int64   `json:"ticket_fee"`
+	Passphrase       string  `json:"passphrase"`
+}
+
+// revokeTicketsRequest revokes the tickets in TicketHashes, or all missed and expired tickets if TicketHashes is empty
+type revokeTicketsRequest struct {
+	TicketHashes []string `json:"ticket_hashes"`
+	Passphrase   string   `json:"passphrase"`
+}
+
+type addressInfo struct {
+	Address       string `json:"address"`
+	IsMine        bool   `json:"is_mine"`
+	AccountNumber uint32 `json:"account_number"`
+	AccountName   string `json:"account_name"`
+}
+
+func (api *API) accounts(res http.ResponseWriter, req *http.Request) {
+	requiredConfirmations, err := requiredConfirmationsFromQuery(req)
+	if err != nil {
+		renderError(res, http.StatusBadRequest, "%s", err.Error())
+		return
+	}
+
+	accounts, err := api.walletMiddleware.AccountsOverview(requiredConfirmations)
+	if err != nil {
+		renderError(res, http.StatusInternalServerError, "error fetching accounts: %s", err.Error())
+		return
+	}
+
+	renderData(res, accounts)
+}
+
+func (api *API) createAccount(res http.ResponseWriter, req *http.Request) {
+	var request createAccountRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		renderError(res, http.StatusBadRequest, "invalid request body: %s", err.Error())
+		return
+	}
+	if request.Name == "" {
+		renderError(res, http.StatusBadRequest, "account name is required")
+		return
+	}
+
+	accountNumber, err := api.walletMiddleware.NextAccount(request.Name, request.Passphrase)
+	if err != nil {
+		renderError(res, signingErrorStatus(err), "error creating account: %s", err.Error())
+		return
+	}
+
+	renderData(res, map[string]interface{}{
+		"name":   request.Name,
+		"number": accountNumber,
+	})
+}
+
+// accountDetails renders the details of all accounts, including hidden accounts
+func (api *API) accountDetails(res http.ResponseWriter, req *http.Request) {
+	requiredConfirmations, err := requiredConfirmationsFromQuery(req)
+	if err != nil {
+		renderError(res, http.StatusBadRequest, "%s", err.Error())
+		return
+	}
+
+	accounts, err := api.walletMiddleware.AccountDetails(requiredConfirmations)
+	if err != nil {
+		renderError(res, http.StatusInternalServerError, "error fetching accounts: %s", err.Error())
+		return
+	}
+
+	renderData(res, accounts)
+}
+
+func (api *API) renameAccount(res http.ResponseWriter, req *http.Request) {
+	accountNumber, err := accountNumberFromURL(req)
+	if err != nil {
+		renderError(res, http.StatusBadRequest, "%s", err.Error())
+		return
+	}
+
+	var request renameAccountRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		renderError(res, http.StatusBadRequest, "invalid request body: %s", err.Error())
+		return
+	}
+	if request.Name == "" {
+		renderError(res, http.StatusBadRequest, "account name is required")
+		return
+	}
+	// an error finding an account by the new name means the name is not in use
+	if existingAccount, err := api.walletMiddleware.AccountNumber(request.Name); err == nil && existingAccount != accountNumber {
+		renderError(res, http.StatusBadRequest, "account named %s already exists", request.Name)
+		return
+	}
+
+	if err = api.walletMiddleware.RenameAccount(accountNumber, request.Name); err != nil {
+		renderError(res, http.StatusInternalServerError, "%s", err.Error())
+		return
+	}
+
+	api.renderAccount(res, req, accountNumber)
+}
+
+func (api *API) hideAccount(res http.ResponseWriter, req *http.Request) {
+	accountNumber, err := accountNumberFromURL(req)
+	if err != nil {
+		renderError(res, http.StatusBadRequest, "%s", err.Error())
+		return
+	}
+
+	var request hideAccountRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		renderError(res, http.StatusBadRequest, "invalid request body: %s", err.Error())
+		return
+	}
+
+	if _, err = api.walletMiddleware.AccountName(accountNumber); err != nil {
+		renderError(res, http.StatusNotFound, "account not found: %s", err.Error())
+		return
+	}
+	if err = api.walletMiddleware.HideAccount(accountNumber, request.Hidden); err != nil {
+		renderError(res, http.StatusInternalServerError, "error hiding account: %s", err.Error())
+		return
+	}
+
+	api.accountDetails(res, req)
+}
+
+func (api *API) accountByName(res http.ResponseWriter, req *http.Request) {
+	accountNumber, err := api.walletMiddleware.AccountNumber(chi.URLParam(req, "accountName"))
+	if err != nil {
+		renderError(res, http.StatusNotFound, "account not found: %s", err.Error())
+		return
+	}
+
+	api.renderAccount(res, req, accountNumber)
+}
+
+func (api *API) account(res http.ResponseWriter, req *http.Request) {
+	accountNumber, err := accountNumberFromURL(req)
+	if err != nil {
+		renderError(res, http.StatusBadRequest, "%s", err.Error())
+		return
+	}
+
+	api.renderAccount(res, req, accountNumber)
+}
+
+func (api *API) renderAccount(res http.ResponseWriter, req *http.Request, accountNumber uint32) {
+	requiredConfirmations, err := requiredConfirmationsFromQuery(req)
+	if err != nil {
+		renderError(res, http.StatusBadRequest, "%s", err.Error())
+		return
+	}
+
+	accountName, err := api.walletMiddleware.AccountName(accountNumber)
+	if err != nil {
+		renderError(res, http.StatusNotFound, "account not found: %s", err.Error())
+		return
+	}
+
+	balance, err := api.walletMiddleware.AccountBalance(accountNumber, requiredConfirmations)
+	if err != nil {
+		renderError(res, http.StatusInternalServerError, "error fetching account balance: %s", err.Error())
+		return
+	}
+
+	renderData(res, &walletcore.Account{
+		Name:    accountName,
+		Number:  accountNumber,
+		Balance: balance,
+	})
+}
+
+func (api *API) accountBalance(res http.ResponseWriter, req *http.Request) {
+	accountNumber, err := accountNumberFromURL(req)
+	if err != nil {
+		renderError(res, http.StatusBadRequest, "%s", err.Error())
+		return
+	}
+
+	requiredConfirmations, err := requiredConfirmationsFromQuery(req)
+	if err != nil {
+		renderError(res, http.StatusBadRequest, "%s", err.Error())
+		return
+	}
+
+	balance, err := api.walletMiddleware.AccountBalance(accountNumber, requiredConfirmations)
+	if err != nil {
+		renderError(res, http.StatusInternalServerError, "error fetching account balance: %s", err.Error())
+		return
+	}
+
+	renderData(res, balance)
+}
+
+func (api *API) receiveAddress(res http.ResponseWriter, req *http.Request) {
+	accountNumber, err := accountNumberFromURL(req)
+	if err != nil {
+		renderError(res, http.StatusBadRequest, "%s", err.Error())
+		return
+	}
+
+	address, err := api.walletMiddleware.ReceiveAddress(accountNumber)
+	if err != nil {
+		renderError(res, http.StatusInternalServerError, "error getting receive address: %s", err.Error())
+		return
+	}
+
+	renderData(res, map[string]string{"address": address})
+}
+
+func (api *API) generateNewAddress(res http.ResponseWriter, req *http.Request) {
+	accountNumber, err := accountNumberFromURL(req)
+	if err != nil {
+		renderError(res, http.StatusBadRequest, "%s", err.Error())
+		return
+	}
+
+	address, err := api.walletMiddleware.GenerateNewAddress(accountNumber)
+	if err != nil {
+		renderError(res, http.StatusInternalServerError, "error generating address: %s", err.Error())
+		return
+	}
+
+	renderData(res, map[string]string{"address": address})
+}
+
+func (api *API) unspentOutputs(res http.ResponseWriter, req *http.Request) {
+	accountNumber, err := accountNumberFromURL(req)
+	if err != nil {
+		renderError(res, http.StatusBadRequest, "%s", err.Error())
+		return
+	}
+
+	requiredConfirmations, err := requiredConfirmationsFromQuery(req)
+	if err != nil {
+		renderError(res, http.StatusBadRequest, "%s", err.Error())
+		return
+	}
+
+	var targetAmount int64
+	if targetAmountStr := req.URL.Query().Get("target-amount"); targetAmountStr != "" {
+		targetAmountDcr, err := strconv.ParseFloat(targetAmountStr, 64)
+		if err != nil {
+			renderError(res, http.StatusBadRequest, "invalid target-amount: %s", err.Error())
+			return
+		}
+		targetAmount, err = txhelper.AmountToAtom(targetAmountDcr)
+		if err != nil {
+			renderError(res, http.StatusBadRequest, "invalid target-amount: %s", err.Error())
+			return
+		}
+	}
+
+	utxos, err := api.walletMiddleware.UnspentOutputs(accountNumber, targetAmount, requiredConfirmations)
+	if err != nil {
+		renderError(res, http.StatusInternalServerError, "error fetching unspent outputs: %s", err.Error())
+		return
+	}
+
+	// always respond with a json array, even if the account has no unspent outputs
+	if utxos == nil {
+		utxos = []*walletcore.UnspentOutput{}
+	}
+	renderData(res, utxos)
+}
+
+func (api *API) lockedOutputs(res http.ResponseWriter, req *http.Request) {
+	outputKeys, err := api.walletMiddleware.LockedUnspentOutputs()
+	if err != nil {
+		renderError(res, http.StatusInternalServerError, "error fetching locked outputs: %s", err.Error())
+		return
+	}
+
+	renderData(res, outputKeys)
+}
+
+func (api *API) lockOutputs(res http.ResponseWriter, req *http.Request) {
+	outputKeys, ok := decodeOutputKeysRequest(res, req)
+	if !ok {
+		return
+	}
+
+	if err := api.walletMiddleware.LockUnspentOutputs(outputKeys); err != nil {
+		renderError(res, http.StatusBadRequest, "error locking outputs: %s", err.Error())
+		return
+	}
+
+	api.lockedOutputs(res, req)
+}
+
+func (api *API) unlockOutputs(res http.ResponseWriter, req *http.Request) {
+	outputKeys, ok := decodeOutputKeysRequest(res, req)
+	if !ok {
+		return
+	}
+
+	if err := api.walletMiddleware.UnlockUnspentOutputs(outputKeys); err != nil {
+		renderError(res, http.StatusBadRequest, "error unlocking outputs: %s", err.Error())
+		return
+	}
+
+	api.lockedOutputs(res, req)
+}
+
+// decodeOutputKeysRequest reads the output keys in the request body, rendering an error response if there are none
+func decodeOutputKeysRequest(res http.ResponseWriter, req *http.Request) ([]string, bool) {
+	var request outputKeysRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		renderError(res, http.StatusBadRequest, "invalid request body: %s", err.Error())
+		return nil, false
+	}
+	if len(request.Keys) == 0 {
+		renderError(res, http.StatusBadRequest, "at least one output key is required")
+		return nil, false
+	}
+	return request.Keys, true
+}
+
+func (api *API) labels(res http.ResponseWriter, req *http.Request) {
+	labels, err := api.walletMiddleware.Labels()
+	if err != nil {
+		renderError(res, http.StatusInternalServerError, "error fetching labels: %s", err.Error())
+		return
+	}
+
+	renderData(res, labels)
+}
+
+// setLabel saves the label in the request body, an empty label removes the label of the record
+func (api *API) setLabel(res http.ResponseWriter, req *http.Request) {
+	var label walletcore.Label
+	if err := json.NewDecoder(req.Body).Decode(&label); err != nil {
+		renderError(res, http.StatusBadRequest, "invalid request body: %s", err.Error())
+		return
+	}
+
+	if err := api.walletMiddleware.SetLabel(label.Type, label.Ref, label.Label); err != nil {
+		renderError(res, http.StatusBadRequest, "error saving label: %s", err.Error())
+		return
+	}
+
+	api.labels(res, req)
+}
+
+// importLabels saves the labels in the request body, which must be in BIP-329 json lines format
+func (api *API) importLabels(res http.ResponseWriter, req *http.Request) {
+	labels, skipped, err := walletcore.ReadLabels(req.Body)
+	if err != nil {
+		renderError(res, http.StatusBadRequest, "invalid labels: %s", err.Error())
+		return
+	}
+
+	if err = api.walletMiddleware.ImportLabels(labels); err != nil {
+		renderError(res, http.StatusBadRequest, "error importing labels: %s", err.Error())
+		return
+	}
+
+	renderData(res, map[string]int{
+		"imported": len(labels),
+		"skipped":  skipped,
+	})
+}
+
+func (api *API) contacts(res http.ResponseWriter, req *http.Request) {
+	book, err := addressbook.Open(api.walletMiddleware.NetType())
+	if err != nil {
+		renderError(res, http.StatusInternalServerError, "error opening address book: %s", err.Error())
+		return
+	}
+
+	renderData(res, book.Contacts())
+}
+
+func (api *API) addContact(res http.ResponseWriter, req *http.Request) {
+	var request contactRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		renderError(res, http.StatusBadRequest, "invalid request body: %s", err.Error())
+		return
+	}
+
+	book, err := addressbook.Open(api.walletMiddleware.NetType())
+	if err != nil {
+		renderError(res, http.StatusInternalServerError, "error opening address book: %s", err.Error())
+		return
+	}
+	if err = book.Add(request.Name, request.Address); err != nil {
+		renderError(res, http.StatusBadRequest, "error adding contact: %s", err.Error())
+		return
+	}
+
+	api.contacts(res, req)
+}
+
+func (api *API) editContact(res http.ResponseWriter, req *http.Request) {
+	var request contactRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		renderError(res, http.StatusBadRequest, "invalid request body: %s", err.Error())
+		return
+	}
+
+	book, err := addressbook.Open(api.walletMiddleware.NetType())
+	if err != nil {
+		renderError(res, http.StatusInternalServerError, "error opening address book: %s", err.Error())
+		return
+	}
+	if err = book.Edit(chi.URLParam(req, "name"), request.Name, request.Address); err != nil {
+		renderError(res, http.StatusBadRequest, "error editing contact: %s", err.Error())
+		return
+	}
+
+	api.contacts(res, req)
+}
+
+func (api *API) removeContact(res http.ResponseWriter, req *http.Request) {
+	book, err := addressbook.Open(api.walletMiddleware.NetType())
+	if err != nil {
+		renderError(res, http.StatusInternalServerError, "error opening address book: %s", err.Error())
+		return
+	}
+	if err = book.Remove(chi.URLParam(req, "name")); err != nil {
+		renderError(res, http.StatusNotFound, "error removing contact: %s", err.Error())
+		return
+	}
+
+	api.contacts(res, req)
+}
+
+func (api *API) addressInfo(res http.ResponseWriter, req *http.Request) {
+	address := chi.URLParam(req, "address")
+
+	isValid, err := api.walletMiddleware.ValidateAddress(address)
+	if err != nil {
+		renderError(res, http.StatusInternalServerError, "error validating address: %s", err.Error())
+		return
+	}
+	if !isValid {
+		renderError(res, http.StatusBadRequest, "%s is not a valid %s address", address, api.walletMiddleware.NetType())
+		return
+	}
+
+	info, err := api.walletMiddleware.AddressInfo(address)
+	if err != nil {
+		renderError(res, http.StatusInternalServerError, "error fetching address info: %s", err.Error())
+		return
+	}
+
+	renderData(res, &addressInfo{
+		Address:       info.Address,
+		IsMine:        info.IsMine,
+		AccountNumber: info.AccountNumber,
+		AccountName:   info.AccountName,
+	})
+}
+
+func (api *API) send(res http.ResponseWriter, req *http.Request) {
+	request, sendDestinations, err := api.decodeSendRequest(req)
+	if err != nil {
+		renderError(res, http.StatusBadRequest, "%s", err.Error())
+		return
+	}
+	changeDestinations, err := api.txDestinations(request.ChangeDestinations)
+	if err != nil {
+		renderError(res, http.StatusBadRequest, "%s", err.Error())
+		return
+	}
+
+	if request.SendMax && len(changeDestinations) > 0 {
+		renderError(res, http.StatusBadRequest, "change destinations cannot be set with send_max")
+		return
+	}
+
+	var txHash string
+	if len(request.Utxos) > 0 && len(changeDestinations) > 0 {
+		// the fee is whatever is left after paying the destinations and change destinations selected by the caller
+		txHash, err = api.walletMiddleware.SendFromUTXOs(request.SourceAccount, request.requiredConfirmations(), request.Utxos,
+			sendDestinations, changeDestinations, request.Passphrase)
+	} else {
+		estimate, estimateErr := api.estimateTransaction(request, sendDestinations)
+		if estimateErr != nil {
+			renderError(res, http.StatusBadRequest, "%s", estimateErr.Error())
+			return
+		}
+		txHash, err = walletcore.SendEstimatedTransaction(api.walletMiddleware, estimate, request.Passphrase)
+	}
+
+	if err != nil {
+		renderError(res, signingErrorStatus(err), "error sending transaction: %s", err.Error())
+		return
+	}
+
+	renderData(res, map[string]string{"hash": txHash})
+}
+
+// estimateSend responds with the inputs, change, size and fee of the transaction that `send` would create for the same request
+func (api *API) estimateSend(res http.ResponseWriter, req *http.Request) {
+	request, sendDestinations, err := api.decodeSendRequest(req)
+	if err != nil {
+		renderError(res, http.StatusBadRequest, "%s", err.Error())
+		return
+	}
+
+	estimate, err := api.estimateTransaction(request, sendDestinations)
+	if err != nil {
+		renderError(res, http.StatusBadRequest, "%s", err.Error())
+		return
+	}
+
+	renderData(res, estimate)
+}
+
+// decodeSendRequest reads a send request from the request body and validates its destinations
+func (api *API) decodeSendRequest(req *http.Request) (*sendRequest, []txhelper.TransactionDestination, error) {
+	var request sendRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		return nil, nil, fmt.Errorf("invalid request body: %s", err.Error())
+	}
+
+	if len(request.Destinations) == 0 {
+		return nil, nil, fmt.Errorf("at least one destination is required")
+	}
+
+	if request.SendMax {
+		// the amount sent is computed from the inputs, so only the destination address is used
+		if len(request.Destinations) > 1 {
+			return nil, nil, fmt.Errorf("send_max can only be used with one destination")
+		}
+		address, err := api.resolveAddress(request.Destinations[0].Address)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &request, []txhelper.TransactionDestination{{Address: address}}, nil
+	}
+
+	sendDestinations, err := api.txDestinations(request.Destinations)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &request, sendDestinations, nil
+}
+
+func (api *API) estimateTransaction(request *sendRequest, sendDestinations []txhelper.TransactionDestination) (*walletcore.TransactionEstimate, error) {
+	feeRate, err := walletcore.ParseFeeRate(request.FeeRate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fee rate: %s", err.Error())
+	}
+
+	if request.SendMax {
+		return api.walletMiddleware.EstimateSweepTransaction(request.SourceAccount, request.requiredConfirmations(), sendDestinations[0].Address,
+			request.Utxos, feeRate)
+	}
+	if err = walletcore.ValidateCoinSelectionStrategy(request.CoinSelection); err != nil {
+		return nil, err
+	}
+	return api.walletMiddleware.EstimateTransaction(request.SourceAccount, request.requiredConfirmations(), sendDestinations, request.Utxos,
+		request.CoinSelection, feeRate)
+}
+
+func (request *sendRequest) requiredConfirmations() int32 {
+	if request.SpendUnconfirmed {
+		return 0
+	}
+	return walletcore.DefaultRequiredConfirmations
+}
+
+// txDestinations validates the addresses and amounts in `destinations` and converts them to []txhelper.TransactionDestination
+func (api *API) txDestinations(destinations []*destination) ([]txhelper.TransactionDestination, error) {
+	txDestinations := make([]txhelper.TransactionDestination, len(destinations))
+	for i, destination := range destinations {
+		address, err := api.resolveAddress(destination.Address)
+		if err != nil {
+			return nil, err
+		}
+		if destination.Amount <= 0 {
+			return nil, fmt.Errorf("invalid amount for destination %s", destination.Address)
+		}
+
+		txDestinations[i] = txhelper.TransactionDestination{
+			Address: address,
+			Amount:  destination.Amount,
+		}
+	}
+	return txDestinations, nil
+}
+
+// resolveAddress returns the address of the contact if `destination` is an address book contact name, e.g. @alice,
+// and checks that the address is valid
+func (api *API) resolveAddress(destination string) (string, error) {
+	book, err := addressbook.Open(api.walletMiddleware.NetType())
+	if err != nil {
+		return "", fmt.Errorf("error opening address book: %s", err.Error())
+	}
+	address, err := book.ResolveAddress(destination)
+	if err != nil {
+		return "", err
+	}
+
+	isValid, err := api.walletMiddleware.ValidateAddress(address)
+	if err != nil {
+		return "", fmt.Errorf("error validating address %s: %s", address, err.Error())
+	}
+	if !isValid {
+		return "", fmt.Errorf("invalid destination address: %s", address)
+	}
+	return address, nil
+}
+
+func (api *API) transactionHistory(res http.ResponseWriter, req *http.Request) {
+	// the api returns all matching transactions unless a limit is set
+	query, err := routes.HistoryQueryFromParams(req.URL.Query(), 0)
+	if err != nil {
+		renderError(res, http.StatusBadRequest, "invalid history filter: %s", err.Error())
+		return
+	}
+
+	transactions, err := api.walletMiddleware.TransactionHistory(query)
+	if err != nil {
+		renderError(res, http.StatusInternalServerError, "error fetching history: %s", err.Error())
+		return
+	}
+
+	if transactions == nil {
+		transactions = []*walletcore.Transaction{}
+	}
+	renderData(res, transactions)
+}
+
+func (api *API) transactionDetails(res http.ResponseWriter, req *http.Request) {
+	hash := chi.URLParam(req, "hash")
+	if _, err := chainhash.NewHashFromStr(hash); err != nil {
+		renderError(res, http.StatusBadRequest, "invalid transaction hash: %s", err.Error())
+		return
+	}
+
+	transaction, err := api.walletMiddleware.GetTransaction(hash)
+	if err != nil {
+		renderError(res, http.StatusNotFound, "error fetching transaction: %s", err.Error())
+		return
+	}
+
+	renderData(res, transaction)
+}
+
+func (api *API) stakeInfo(res http.ResponseWriter, req *http.Request) {
+	stakeInfo, err := api.walletMiddleware.StakeInfo(req.Context())
+	if err != nil {
+		renderError(res, http.StatusInternalServerError, "%s", err.Error())
+		return
+	}
+
+	renderData(res, stakeInfo)
+}
+
+func (api *API) tickets(res http.ResponseWriter, req *http.Request) {
+	filter, err := routes.TicketFilterFromParams(req.URL.Query())
+	if err != nil {
+		renderError(res, http.StatusBadRequest, "invalid ticket filter: %s", err.Error())
+		return
+	}
+
+	tickets, err := api.walletMiddleware.Tickets(req.Context(), filter)
+	if err != nil {
+		renderError(res, http.StatusInternalServerError, "error fetching tickets: %s", err.Error())
+		return
+	}
+
+	if tickets == nil {
+		tickets = []*walletcore.Ticket{}
+	}
+	renderData(res, tickets)
+}
+
+func (api *API) purchaseTickets(res http.ResponseWriter, req *http.Request) {
+	var request purchaseTicketsRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		renderError(res, http.StatusBadRequest, "invalid request body: %s", err.Error())
+		return
+	}
+
+	if request.NumTickets == 0 {
+		request.NumTickets = 1
+	}
+	var requiredConfirmations uint32 = walletcore.DefaultRequiredConfirmations
+	if request.MinConfirmations != nil {
+		requiredConfirmations = *request.MinConfirmations
+	}
+
+	ticketHashes, err := api.walletMiddleware.PurchaseTickets(req.Context(), dcrlibwallet.PurchaseTicketsRequest{
+		Account:               request.Account,
+		NumTickets:            request.NumTickets,
+		RequiredConfirmations: requiredConfirmations,
+		TicketAddress:         request.TicketAddress,
+		PoolAddress:           request.PoolAddress,
+		PoolFees:              request.PoolFees,
+		Expiry:                request.Expiry,
+		TxFee:                 request.TxFee,
+		TicketFee:             request.TicketFee,
+		Passphrase:            []byte(request.Passphrase),
+	})
+	if err != nil {
+		renderError(res, signingErrorStatus(err), "%s", err.Error())
+		return
+	}
+
+	renderData(res, map[string]interface{}{"ticket_hashes": ticketHashes})
+}
+
+func (api *API) revokeTickets(res http.ResponseWriter, req *http.Request) {
+	var request revokeTicketsRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		renderError(res, http.StatusBadRequest, "invalid request body: %s", err.Error())
+		return
+	}
+
+	// watch-only wallets cannot sign revocations
+	if api.walletMiddleware.IsWatchingOnlyWallet() {
+		renderError(res, http.StatusForbidden, "%s", walletcore.ErrWatchingOnlyWallet.Error())
+		return
+	}
+
+	var revoked []*walletcore.RevokedTicket
+	var err error
+	if len(request.TicketHashes) == 0 {
+		revoked, err = api.walletMiddleware.RevokeTickets(req.Context(), request.Passphrase)
+	} else {
+		for _, ticketHash := range request.TicketHashes {
+			var revocationHash string
+			revocationHash, err = api.walletMiddleware.RevokeTicket(req.Context(), ticketHash, request.Passphrase)
+			if err != nil {
+				err = fmt.Errorf("error revoking ticket %s: %s", ticketHash, err.Error())
+				break
+			}
+			revoked = append(revoked, &walletcore.RevokedTicket{TicketHash: ticketHash, RevocationHash: revocationHash})
+		}
+	}
+	if err != nil {
+		if len(revoked) > 0 {
+			renderError(res, signingErrorStatus(err), "%s (%d ticket(s) were revoked before the error)", err.Error(), len(revoked))
+			return
+		}
+		renderError(res, signingErrorStatus(err), "%s", err.Error())
+		return
+	}
+
+	if revoked == nil {
+		revoked = []*walletcore.RevokedTicket{}
+	}
+	renderData(res, map[string]interface{}{"revoked_tickets": revoked})
+}
+
+func (api *API) signMessage(res http.ResponseWriter, req *http.Request) {
+	var request signMessageRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		renderError(res, http.StatusBadRequest, "invalid request body: %s", err.Error())
+		return
+	}
+	if request.Address == "" {
+		renderError(res, http.StatusBadRequest, "address is required")
+		return
+	}
+
+	signature, err := api.walletMiddleware.SignMessage(request.Address, request.Message, request.Passphrase)
+	if err != nil {
+		renderError(res, signingErrorStatus(err), "%s", err.Error())
+		return
+	}
+
+	renderData(res, map[string]interface{}{"signature": signature})
+}
+
+func (api *API) verifyMessage(res http.ResponseWriter, req *http.Request) {
+	var request verifyMessageRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		renderError(res, http.StatusBadRequest, "invalid request body: %s", err.Error())
+		return
+	}
+	if request.Address == "" || request.Signature == "" {
+		renderError(res, http.StatusBadRequest, "address and signature are required")
+		return
+	}
+
+	valid, err := api.walletMiddleware.VerifyMessage(request.Address, request.Message, request.Signature)
+	if err != nil {
+		renderError(res, http.StatusBadRequest, "%s", err.Error())
+		return
+	}
+
+	renderData(res, map[string]interface{}{"valid": valid})
+}
+
+// signingErrorStatus returns the status code for an error returned by a wallet operation that requires private keys
+// such operations are forbidden for watch-only wallets, other errors are reported as server errors
+func signingErrorStatus(err error) int {
+	if err == walletcore.ErrWatchingOnlyWallet {
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
+
+func accountNumberFromURL(req *http.Request) (uint32, error) {
+	accountNumber, err := strconv.ParseUint(chi.URLParam(req, "accountNumber"), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid account number: %s", err.Error())
+	}
+	return uint32(accountNumber), nil
+}
+
+// requiredConfirmationsFromQuery reads the min-conf query parameter, defaulting to walletcore.DefaultRequiredConfirmations
+func requiredConfirmationsFromQuery(req *http.Request) (int32, error) {
+	minConf := req.URL.Query().Get("min-conf")
+	if minConf == "" {
+		return walletcore.DefaultRequiredConfirmations, nil
+	}
+
+	requiredConfirmations, err := strconv.ParseInt(minConf, 10, 32)
+	if err != nil || requiredConfirmations < 0 {
+		return 0, fmt.Errorf("invalid min-conf value: %s", minConf)
+	}
+	return int32(requiredConfirmations), nil
+}
@@ -0,0 +1,95 @@
+/**==================================================================*
+ *                  WALLET EVENTS                                    *
+ *===================================================================*/
+var syncStageDescriptions = {
+    "started": "Blockchain sync started...",
+    "fetching_headers": "Blockchain sync in progress. Fetching headers (1/3)",
+    "discovering_addresses": "Blockchain sync in progress. Discovering addresses (2/3)",
+    "rescanning_blocks": "Blockchain sync in progress. Rescanning blocks (3/3)",
+    "finished": "Blockchain sync completed successfully"
+};
+
+function showSyncProgress(syncProgress) {
+    var status = syncStageDescriptions[syncProgress.stage] || "";
+    if (syncProgress.stage === "fetching_headers" || syncProgress.stage === "rescanning_blocks") {
+        status += ": " + syncProgress.progress + "%";
+    }
+    if (syncProgress.error) {
+        status = "Blockchain sync completed with error: " + syncProgress.error;
+    }
+    $("#wallet-status").text("Blockchain status: " + status);
+
+    // pages that could not be displayed because the blockchain wasn't synced can now be displayed
+    if (syncProgress.stage === "finished" && !syncProgress.error && $("#wallet-not-ready").length > 0) {
+        window.location.reload();
+    }
+}
+
+// refreshWalletWidgets replaces the elements of the current page that display balances or transactions
+// with the same elements from a fresh copy of the page. The rest of the page, including any form input, is left as is
+function refreshWalletWidgets() {
+    var widgets = $(".wallet-widget");
+    if (widgets.length === 0) {
+        return;
+    }
+
+    $.get(window.location.href, function (html) {
+        var page = $("<div>").append($.parseHTML(html));
+        widgets.each(function () {
+            var updatedWidget = page.find("#" + this.id);
+            // the page may now display an error instead of the widget, keep showing the last known values
+            if (updatedWidget.length > 0) {
+                $(this).replaceWith(updatedWidget);
+            }
+        });
+    });
+}
+
+function refreshWalletPage() {
+    if (window.location.pathname === "/" || window.location.pathname === "/history") {
+        refreshWalletWidgets();
+    }
+}
+
+function refreshTransactionPage(transactionHash) {
+    if (window.location.pathname === "/transaction_details/" + transactionHash) {
+        refreshWalletWidgets();
+    }
+}
+
+function listenForWalletEvents() {
+    if (!window.WebSocket) {
+        return;
+    }
+
+    var protocol = window.location.protocol === "https:" ? "wss://" : "ws://";
+    var socket = new WebSocket(protocol + window.location.host + "/ws");
+
+    socket.onmessage = function (message) {
+        var event = JSON.parse(message.data);
+        switch (event.type) {
+            case "sync_progress":
+                showSyncProgress(event.data);
+                break;
+            case "transaction":
+                refreshWalletPage();
+                refreshTransactionPage(event.data.transaction.hash);
+                break;
+            case "new_block":
+                // confirmations of displayed transactions change with every new block
+                refreshWalletPage();
+                break;
+            case "confirmation":
+                refreshTransactionPage(event.data.transaction_hash);
+                break;
+            case "ticket_status":
+                refreshTransactionPage(event.data.ticket_hash);
+                refreshTransactionPage(event.data.transaction_hash);
+                break;
+        }
+    };
+}
+
+$(function () {
+    listenForWalletEvents();
+});
@@ -2,6 +2,11 @@
  *                  SEND PAGE FUNCTIONS                              *
  *===================================================================*/
 function validateAmountField() { 
+    // the amount is computed by the server when sending the entire balance
+    if ($("#send-max").is(":checked")) {
+        return true;
+    }
+
     if ($("#amount").val() === "") {
         $(".errors").html("<div class='error'>Please enter an amount first</div>");
         return false;
@@ -26,7 +31,7 @@ function validateSendForm() {
         errors.push("The destination address is required");
     }
 
-    if ($("#use-custom").prop("checked") && (getSelectedInputsSum() < $("#amount").val()) ) {
+    if ($("#use-custom").prop("checked") && !$("#send-max").is(":checked") && (getSelectedInputsSum() < $("#amount").val()) ) {
         errors.push("The sum of selected inputs is less than send amount");
     }
 
@@ -81,9 +86,14 @@ function openCustomizePanel(get_unconfirmed) {
         var utxoHtml = txs.map(tx => {
             var receiveDateTime = new Date(tx.receive_time * 1000);
             var dcrAmount = tx.amount / 100000000;
-            return  "<tr>" + 
-                        "<td width='5%'><input type='checkbox' class='custom-input' name='utxo' value="+ tx.key+" data-amount='" + dcrAmount + "' /></td>" +
-                        "<td width='50%'>" + tx.key + "</td>" + 
+            // locked outputs are listed but cannot be selected
+            var disabled = tx.locked ? " disabled" : "";
+            var lockedLabel = tx.locked ? " <span class='badge badge-secondary'>locked</span>" : "";
+            // labels are set by the user, escape them before adding them to the html
+            var outputLabel = tx.label ? " <small class='text-muted'>" + $("<div>").text(tx.label).html() + "</small>" : "";
+            return  "<tr" + (tx.locked ? " class='text-muted'" : "") + ">" + 
+                        "<td width='5%'><input type='checkbox' class='custom-input' name='utxo' value="+ tx.key+" data-amount='" + dcrAmount + "'" + disabled + " /></td>" +
+                        "<td width='50%'>" + tx.key + lockedLabel + outputLabel + "</td>" + 
                         "<td width='20%'>" + dcrAmount + " DCR</td>" + 
                         "<td width='25%'>" + receiveDateTime.toString().split(' ').slice(0,5).join(' '); + "</td>" +
                     "</tr>"
@@ -133,12 +143,8 @@ function getUnspentOutputs(account_number, get_unconfirmed, success_callback) {
     })
 }
 
-function submitSendForm() {
-    var form = $("#send-form");
-    var submit_btn = $("#send-form #submit-btn");
-    submit_btn.attr("disabled", "disabled").html("Sending...");
-
-    var postData = form.serialize();
+function sendFormData() {
+    var postData = $("#send-form").serialize();
     postData += "&totalSelectedInputAmountDcr=" + getSelectedInputsSum();
 
     // add source-account value to post data if source-account element is disabled
@@ -146,10 +152,62 @@ function submitSendForm() {
         postData += "&source-account=" + $("#source-account").val();
     }
 
+    return postData;
+}
+
+function estimateSendTx(success_callback) {
+    var submit_btn = $("#send-form #submit-btn");
+    submit_btn.attr("disabled", "disabled").html("Estimating fee...");
+
+    $.ajax({
+        url: "/send/estimate",
+        method: "POST",
+        data: sendFormData(),
+        success: function(response) {
+            if (response.error) {
+                setErrorMessage(response.error);
+            } else {
+                clearMessages();
+                success_callback(response.estimate);
+            }
+        },
+        error: function(error) {
+            setErrorMessage("A server error occurred");
+        },
+        complete: function() {
+            submit_btn.removeAttr("disabled").html("Next");
+        }
+    })
+}
+
+function atomsToDcr(atoms) {
+    return (atoms / 100000000) + " DCR";
+}
+
+function showTransactionEstimate(estimate) {
+    var rows = estimate.inputs.map(input => {
+        return "<tr><td>Input</td><td>" + input.address + "</td><td>" + atomsToDcr(input.amount) + "</td></tr>";
+    });
+    rows.push("<tr><td>Send</td><td>" + $("#destination-address").val() + "</td><td>" + atomsToDcr(estimate.send_amount) + "</td></tr>");
+    if (estimate.change > 0) {
+        rows.push("<tr><td>Change</td><td>New address in source account</td><td>" + atomsToDcr(estimate.change) + "</td></tr>");
+    }
+
+    var summaryHtml = "<table class='table table-sm'><tbody>" + rows.join("\n") + "</tbody></table>" +
+        "<p>Estimated size: <strong>" + estimate.size + " bytes</strong><br/>" +
+        "Fee: <strong>" + atomsToDcr(estimate.fee) + "</strong> (" + atomsToDcr(estimate.fee_rate) + "/kB)</p>";
+    $("#passphrase-modal .tx-summary").html(summaryHtml);
+}
+
+function submitSendForm() {
+    var form = $("#send-form");
+    var submit_btn = $("#send-form #submit-btn");
+    submit_btn.attr("disabled", "disabled").html("Sending...");
+
     $.ajax({
         url: form.attr("action"),
         method: "POST",
-        data: postData,
+        data: sendFormData(),
         success: function(response) {
             if (response.error) {
                 setErrorMessage(response.error)
@@ -182,14 +240,19 @@ function validatePassphrase() {
 
 function getWalletPassphraseAndSubmit() {
     var passphraseModal = $("#passphrase-modal");
-    
-    $("#passphrase-submit").on("click", function(){
-        if (validatePassphrase()) {
-            passphraseModal.modal('hide');
-            submitSendForm();
-        }
+
+    // show the inputs, change and fee of the transaction before asking for the passphrase
+    estimateSendTx(function(estimate) {
+        showTransactionEstimate(estimate);
+
+        $("#passphrase-submit").off("click").on("click", function(){
+            if (validatePassphrase()) {
+                passphraseModal.modal('hide');
+                submitSendForm();
+            }
+        });
+        passphraseModal.modal();
     });
-    passphraseModal.modal();
 }
 
 
@@ -230,8 +293,18 @@ $(function(){
             resetCustomizePanel();
             $("#custom-tx-row").slideUp();
         }
+
+        // inputs are only selected automatically when custom inputs are not used
+        $("#coin-selection-group").toggle(!this.checked);
     });
     
+    $("#send-max").on("change", function(){
+        $("#amount").prop("disabled", this.checked);
+        if (this.checked) {
+            $("#amount").val("");
+        }
+    });
+
     $("#spend-unconfirmed").on("change", function(){
         var use_custom = $("#use-custom").is(":checked");
 
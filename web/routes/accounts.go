@@ -0,0 +1,72 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/raedahgroup/godcr/app/walletcore"
+)
+
+func (routes *Routes) accountsPage(res http.ResponseWriter, req *http.Request) {
+	accounts, err := routes.walletMiddleware.AccountDetails(walletcore.DefaultRequiredConfirmations)
+	if err != nil {
+		routes.renderError(fmt.Sprintf("Error fetching accounts: %s", err.Error()), res)
+		return
+	}
+
+	data := map[string]interface{}{
+		"accounts":              accounts,
+		"importedAccountNumber": uint32(walletcore.ImportedAccountNumber),
+		"watchingOnly":          routes.walletMiddleware.IsWatchingOnlyWallet(),
+	}
+	routes.render("accounts.html", data, res)
+}
+
+func (routes *Routes) createAccount(res http.ResponseWriter, req *http.Request) {
+	req.ParseForm()
+	name := req.FormValue("name")
+	passphrase := req.FormValue("passphrase")
+	if name == "" || passphrase == "" {
+		routes.renderError("Account name and wallet passphrase are required", res)
+		return
+	}
+
+	if _, err := routes.walletMiddleware.NextAccount(name, passphrase); err != nil {
+		routes.renderError(fmt.Sprintf("Error creating account: %s", err.Error()), res)
+		return
+	}
+
+	http.Redirect(res, req, "/accounts", 303)
+}
+
+func (routes *Routes) renameAccount(res http.ResponseWriter, req *http.Request) {
+	routes.updateAccount(res, req, func(accountNumber uint32) error {
+		return routes.walletMiddleware.RenameAccount(accountNumber, req.FormValue("name"))
+	})
+}
+
+func (routes *Routes) hideAccount(res http.ResponseWriter, req *http.Request) {
+	routes.updateAccount(res, req, func(accountNumber uint32) error {
+		return routes.walletMiddleware.HideAccount(accountNumber, req.FormValue("hide") == "true")
+	})
+}
+
+// updateAccount applies a change submitted from the accounts page to the account in the `account` form field
+// and shows the page again, or shows an error page if the change fails
+func (routes *Routes) updateAccount(res http.ResponseWriter, req *http.Request, update func(accountNumber uint32) error) {
+	req.ParseForm()
+
+	accountNumber, err := strconv.ParseUint(req.FormValue("account"), 10, 32)
+	if err != nil {
+		routes.renderError(fmt.Sprintf("Invalid account number: %s", req.FormValue("account")), res)
+		return
+	}
+
+	if err = update(uint32(accountNumber)); err != nil {
+		routes.renderError(fmt.Sprintf("Error updating account: %s", err.Error()), res)
+		return
+	}
+
+	http.Redirect(res, req, "/accounts", 303)
+}
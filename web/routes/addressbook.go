@@ -0,0 +1,83 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/raedahgroup/godcr/app/addressbook"
+	"github.com/raedahgroup/godcr/app/walletcore"
+)
+
+// addressBook returns the address book for the network of the selected wallet
+func (routes *Routes) addressBook() (*addressbook.AddressBook, error) {
+	book, err := addressbook.Open(routes.walletMiddleware.NetType())
+	if err != nil {
+		return nil, fmt.Errorf("error opening address book: %s", err.Error())
+	}
+	return book, nil
+}
+
+func (routes *Routes) addressBookPage(res http.ResponseWriter, req *http.Request) {
+	book, err := routes.addressBook()
+	if err != nil {
+		routes.renderError(err.Error(), res)
+		return
+	}
+
+	data := map[string]interface{}{
+		"contacts":      book.Contacts(),
+		"netType":       routes.walletMiddleware.NetType(),
+		"contactPrefix": addressbook.ContactPrefix,
+	}
+	routes.render("addressbook.html", data, res)
+}
+
+func (routes *Routes) addContact(res http.ResponseWriter, req *http.Request) {
+	routes.updateAddressBook(res, req, func(book *addressbook.AddressBook) error {
+		return book.Add(req.FormValue("name"), req.FormValue("address"))
+	})
+}
+
+func (routes *Routes) editContact(res http.ResponseWriter, req *http.Request) {
+	routes.updateAddressBook(res, req, func(book *addressbook.AddressBook) error {
+		return book.Edit(req.FormValue("name"), req.FormValue("new-name"), req.FormValue("new-address"))
+	})
+}
+
+func (routes *Routes) removeContact(res http.ResponseWriter, req *http.Request) {
+	routes.updateAddressBook(res, req, func(book *addressbook.AddressBook) error {
+		return book.Remove(req.FormValue("name"))
+	})
+}
+
+// updateAddressBook applies a change submitted from the address book page and shows the page again,
+// or shows an error page if the change fails
+func (routes *Routes) updateAddressBook(res http.ResponseWriter, req *http.Request, update func(book *addressbook.AddressBook) error) {
+	req.ParseForm()
+
+	book, err := routes.addressBook()
+	if err != nil {
+		routes.renderError(err.Error(), res)
+		return
+	}
+
+	if err = update(book); err != nil {
+		routes.renderError(fmt.Sprintf("Error updating address book: %s", err.Error()), res)
+		return
+	}
+
+	http.Redirect(res, req, "/addressbook", 303)
+}
+
+// outputContactNames maps the addresses paid by the outputs of `tx` to the names of the contacts with those addresses
+func outputContactNames(book *addressbook.AddressBook, tx *walletcore.TransactionDetails) map[string]string {
+	contactNames := make(map[string]string)
+	for _, output := range tx.Outputs {
+		for _, address := range output.Addresses {
+			if name := book.NameForAddress(address.Address); name != "" {
+				contactNames[address.Address] = name
+			}
+		}
+	}
+	return contactNames
+}
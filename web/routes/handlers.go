@@ -1,14 +1,16 @@
 package routes
 
 import (
+	"bytes"
 	"encoding/base64"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 
-	"github.com/decred/dcrd/dcrutil"
 	"github.com/go-chi/chi"
 	"github.com/raedahgroup/dcrlibwallet/txhelper"
+	"github.com/raedahgroup/godcr/app/export"
 	"github.com/raedahgroup/godcr/app/walletcore"
 	qrcode "github.com/skip2/go-qrcode"
 )
@@ -20,20 +22,67 @@ func (routes *Routes) createWalletPage(res http.ResponseWriter, req *http.Reques
 		return
 	}
 
-	data := struct{ Seed string }{seed}
-	routes.render("createwallet.html", data, res)
+	routes.seedVerification.setNewWalletSeed(seed)
+	routes.renderSeedStep("createwallet.html", seed, res)
 }
 
+// createWallet moves through the steps of creating a wallet: displaying the seed, asking for some of its words to verify
+// that the seed was backed up, and creating the wallet.
+// The seed generated by createWalletPage is kept on the server, it is not sent back by the browser.
+// If the user chooses to verify the backup later, the seed is kept encrypted with the wallet passphrase until the backup is verified
 func (routes *Routes) createWallet(res http.ResponseWriter, req *http.Request) {
 	req.ParseForm()
-	seed := req.FormValue("seed")
+	seed := routes.seedVerification.getNewWalletSeed()
+	if seed == "" {
+		http.Redirect(res, req, "/createwallet", 303)
+		return
+	}
+
+	switch req.FormValue("step") {
+	case "seed":
+		routes.renderSeedStep("createwallet.html", seed, res)
+		return
+
+	case "verify":
+		if _, err := routes.seedVerification.choosePositions(); err != nil {
+			routes.renderError(err.Error(), res)
+			return
+		}
+		routes.renderVerifyStep("createwallet.html", "", res)
+		return
+	}
+
 	passhprase := req.FormValue("password")
+	if passhprase == "" {
+		routes.renderVerifyStep("createwallet.html", "Wallet password is required", res)
+		return
+	}
+	if passhprase != req.FormValue("confirmPassword") {
+		routes.renderVerifyStep("createwallet.html", "Passwords do not match", res)
+		return
+	}
+
+	verifyLater := req.FormValue("verifyLater") != ""
+	if !verifyLater {
+		if err := routes.seedVerification.verify(req, seed); err != nil {
+			routes.renderVerifyStep("createwallet.html", err.Error(), res)
+			return
+		}
+	}
 
 	err := routes.walletMiddleware.CreateWallet(passhprase, seed)
 	if err != nil {
 		routes.renderError(fmt.Sprintf("Error creating wallet: %s", err.Error()), res)
 		return
 	}
+	routes.seedVerification.reset()
+
+	if verifyLater {
+		if err = routes.walletMiddleware.SaveUnconfirmedSeed(seed, passhprase); err != nil {
+			routes.renderError(fmt.Sprintf("Wallet created but the seed could not be saved for a later backup: %s", err.Error()), res)
+			return
+		}
+	}
 
 	// wallet created successfully, wallet is now open, perform first sync
 	routes.syncBlockchain()
@@ -41,6 +90,57 @@ func (routes *Routes) createWallet(res http.ResponseWriter, req *http.Request) {
 	http.Redirect(res, req, "/", 303)
 }
 
+func (routes *Routes) restoreWalletPage(res http.ResponseWriter, req *http.Request) {
+	routes.renderRestoreWalletPage("", "", res)
+}
+
+func (routes *Routes) restoreWallet(res http.ResponseWriter, req *http.Request) {
+	req.ParseForm()
+	seed := walletcore.NormalizeSeed(req.FormValue("seed"))
+	passphrase := req.FormValue("password")
+
+	// show validation errors on the restore page so the user can correct the seed
+	if err := walletcore.ValidateSeed(seed); err != nil {
+		routes.renderRestoreWalletPage(seed, err.Error(), res)
+		return
+	}
+	if passphrase == "" {
+		routes.renderRestoreWalletPage(seed, "Wallet password is required", res)
+		return
+	}
+
+	walletExists, err := routes.walletMiddleware.WalletExists()
+	if err != nil {
+		routes.renderError(fmt.Sprintf("Error checking for wallet: %s", err.Error()), res)
+		return
+	}
+	if walletExists {
+		routes.renderError("Cannot restore wallet, a wallet already exists", res)
+		return
+	}
+
+	err = routes.walletMiddleware.CreateWallet(passphrase, seed)
+	if err != nil {
+		routes.renderError(fmt.Sprintf("Error restoring wallet: %s", err.Error()), res)
+		return
+	}
+
+	// wallet restored successfully, wallet is now open, sync to discover the wallet's addresses and transactions
+	// sync progress is shown on the pages until the sync completes
+	routes.syncBlockchain()
+
+	http.Redirect(res, req, "/", 303)
+}
+
+func (routes *Routes) renderRestoreWalletPage(seed, errorMessage string, res http.ResponseWriter) {
+	data := map[string]interface{}{
+		"seed":          seed,
+		"error":         errorMessage,
+		"seedWordCount": walletcore.SeedWordCount,
+	}
+	routes.render("restorewallet.html", data, res)
+}
+
 func (routes *Routes) balancePage(res http.ResponseWriter, req *http.Request) {
 	accounts, err := routes.walletMiddleware.AccountsOverview(walletcore.DefaultRequiredConfirmations)
 	if err != nil {
@@ -52,21 +152,36 @@ func (routes *Routes) balancePage(res http.ResponseWriter, req *http.Request) {
 	showDetails := req.FormValue("detailed") != ""
 
 	data := map[string]interface{}{
-		"accounts": accounts,
-		"detailed": showDetails,
+		"accounts":           accounts,
+		"detailed":           showDetails,
+		"hasUnconfirmedSeed": routes.walletMiddleware.HasUnconfirmedSeed(),
 	}
 	routes.render("balance.html", data, res)
 }
 
 func (routes *Routes) sendPage(res http.ResponseWriter, req *http.Request) {
+	if routes.walletMiddleware.IsWatchingOnlyWallet() {
+		routes.renderError(walletcore.ErrWatchingOnlyWallet.Error(), res)
+		return
+	}
+
 	accounts, err := routes.walletMiddleware.AccountsOverview(walletcore.DefaultRequiredConfirmations)
 	if err != nil {
 		routes.renderError(fmt.Sprintf("Error fetching accounts: %s", err.Error()), res)
 		return
 	}
 
+	book, err := routes.addressBook()
+	if err != nil {
+		routes.renderError(err.Error(), res)
+		return
+	}
+
 	data := map[string]interface{}{
-		"accounts": accounts,
+		"accounts":                accounts,
+		"contacts":                book.Contacts(),
+		"coinSelectionStrategies": walletcore.CoinSelectionStrategies,
+		"defaultCoinSelection":    walletcore.DefaultCoinSelection,
 	}
 	routes.render("send.html", data, res)
 }
@@ -75,81 +190,120 @@ func (routes *Routes) submitSendTxForm(res http.ResponseWriter, req *http.Reques
 	data := map[string]interface{}{}
 	defer renderJSON(data, res)
 
+	if routes.walletMiddleware.IsWatchingOnlyWallet() {
+		data["error"] = walletcore.ErrWatchingOnlyWallet.Error()
+		return
+	}
+
+	// the transaction is estimated again with the same inputs and fee rate, so it pays exactly the fee that was previewed
+	estimate, err := routes.estimateSendTx(req)
+	if err != nil {
+		data["error"] = err.Error()
+		return
+	}
+
+	passphrase := req.FormValue("wallet-passphrase")
+	txHash, err := walletcore.SendEstimatedTransaction(routes.walletMiddleware, estimate, passphrase)
+	if err != nil {
+		data["error"] = err.Error()
+		return
+	}
+
+	data["txHash"] = txHash
+}
+
+// estimateSendTxForm responds with the inputs, change, size and fee of the transaction described by the send form
+// so the user can review them before entering their passphrase
+func (routes *Routes) estimateSendTxForm(res http.ResponseWriter, req *http.Request) {
+	data := map[string]interface{}{}
+	defer renderJSON(data, res)
+
+	estimate, err := routes.estimateSendTx(req)
+	if err != nil {
+		data["error"] = err.Error()
+		return
+	}
+
+	data["estimate"] = estimate
+}
+
+func (routes *Routes) estimateSendTx(req *http.Request) (*walletcore.TransactionEstimate, error) {
 	req.ParseForm()
-	utxos := req.Form["utxo"]
-	totalSelectedInputAmountDcr := req.FormValue("totalSelectedInputAmountDcr")
 	amountStr := req.FormValue("amount")
 	selectedAccount := req.FormValue("source-account")
 	destAddress := req.FormValue("destination-address")
-	passphrase := req.FormValue("wallet-passphrase")
 	spendUnconfirmed := req.FormValue("spend-unconfirmed")
 	useCustom := req.FormValue("use-custom")
+	feeRateStr := req.FormValue("fee-rate")
+	sendMax := req.FormValue("send-max")
+	coinSelection := req.FormValue("coin-selection")
 
-	amount, err := strconv.ParseFloat(amountStr, 64)
+	account, err := strconv.ParseUint(selectedAccount, 10, 32)
 	if err != nil {
-		data["error"] = err.Error()
-		return
+		return nil, err
 	}
 
-	account, err := strconv.ParseUint(selectedAccount, 10, 32)
+	var feeRateDcr float64
+	if feeRateStr != "" {
+		feeRateDcr, err = strconv.ParseFloat(feeRateStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fee rate: %s", err.Error())
+		}
+	}
+	feeRate, err := walletcore.ParseFeeRate(feeRateDcr)
 	if err != nil {
-		data["error"] = err.Error()
-		return
+		return nil, fmt.Errorf("invalid fee rate: %s", err.Error())
 	}
-	sourceAccount := uint32(account)
 
-	sendDestinations := []txhelper.TransactionDestination{{
-		Amount:  amount,
-		Address: destAddress,
-	}}
+	// the destination can be the name of an address book contact, e.g. @alice
+	book, err := routes.addressBook()
+	if err != nil {
+		return nil, err
+	}
+	if destAddress, err = book.ResolveAddress(destAddress); err != nil {
+		return nil, err
+	}
+
+	isValid, err := routes.walletMiddleware.ValidateAddress(destAddress)
+	if err != nil {
+		return nil, fmt.Errorf("error validating address: %s", err.Error())
+	}
+	if !isValid {
+		return nil, fmt.Errorf("invalid destination address: %s", destAddress)
+	}
 
 	var requiredConfirmations int32 = walletcore.DefaultRequiredConfirmations
 	if spendUnconfirmed != "" {
 		requiredConfirmations = 0
 	}
 
-	var txHash string
+	// inputs are selected automatically unless the user selected custom inputs
+	var utxos []string
 	if useCustom != "" {
-		totalInputAmountDcr, err := strconv.ParseFloat(totalSelectedInputAmountDcr, 64)
-		if err != nil {
-			data["error"] = err.Error()
-			return
-		}
-
-		totalInputAmount, err := dcrutil.NewAmount(totalInputAmountDcr)
-		if err != nil {
-			data["error"] = err.Error()
-			return
-		}
-
-		changeAddress, err := routes.walletMiddleware.GenerateNewAddress(sourceAccount)
-		if err != nil {
-			data["error"] = err.Error()
-			return
-		}
-
-		changeAmount, err := txhelper.EstimateChange(len(utxos), int64(totalInputAmount), sendDestinations, []string{changeAddress})
-		if err != nil {
-			data["error"] = err.Error()
-			return
+		utxos = req.Form["utxo"]
+		if len(utxos) == 0 {
+			return nil, fmt.Errorf("select at least one input to spend")
 		}
+	}
 
-		changeDestinations := []txhelper.TransactionDestination{{
-			Amount:  dcrutil.Amount(changeAmount).ToCoin(),
-			Address: changeAddress,
-		}}
-
-		txHash, err = routes.walletMiddleware.SendFromUTXOs(sourceAccount, requiredConfirmations, utxos, sendDestinations, changeDestinations, passphrase)
-	} else {
-		txHash, err = routes.walletMiddleware.SendFromAccount(sourceAccount, requiredConfirmations, sendDestinations, passphrase)
+	// when sending the entire balance, the amount is what is left after paying the fee
+	if sendMax != "" {
+		return routes.walletMiddleware.EstimateSweepTransaction(uint32(account), requiredConfirmations, destAddress, utxos, feeRate)
 	}
 
+	amount, err := strconv.ParseFloat(amountStr, 64)
 	if err != nil {
-		data["error"] = err.Error()
-		return
+		return nil, err
 	}
 
-	data["txHash"] = txHash
+	sendDestinations := []txhelper.TransactionDestination{{
+		Amount:  amount,
+		Address: destAddress,
+	}}
+	if err = walletcore.ValidateCoinSelectionStrategy(coinSelection); err != nil {
+		return nil, err
+	}
+	return routes.walletMiddleware.EstimateTransaction(uint32(account), requiredConfirmations, sendDestinations, utxos, coinSelection, feeRate)
 }
 
 func (routes *Routes) receivePage(res http.ResponseWriter, req *http.Request) {
@@ -231,18 +385,107 @@ func (routes *Routes) getUnspentOutputs(res http.ResponseWriter, req *http.Reque
 }
 
 func (routes *Routes) historyPage(res http.ResponseWriter, req *http.Request) {
-	txns, err := routes.walletMiddleware.TransactionHistory()
+	params := req.URL.Query()
+	query, err := HistoryQueryFromParams(params, historyPageSize)
+	if err != nil {
+		routes.renderError(fmt.Sprintf("Invalid history filter: %s", err.Error()), res)
+		return
+	}
+
+	txns, err := routes.walletMiddleware.TransactionHistory(query)
 	if err != nil {
 		routes.renderError(fmt.Sprintf("Error fetching history: %s", err.Error()), res)
 		return
 	}
 
+	accounts, err := routes.walletMiddleware.AccountsOverview(walletcore.DefaultRequiredConfirmations)
+	if err != nil {
+		routes.renderError(fmt.Sprintf("Error fetching accounts: %s", err.Error()), res)
+		return
+	}
+
+	book, err := routes.addressBook()
+	if err != nil {
+		routes.renderError(err.Error(), res)
+		return
+	}
+	contacts, err := book.TransactionContacts(routes.walletMiddleware, txns)
+	if err != nil {
+		routes.renderError(fmt.Sprintf("Error finding contacts: %s", err.Error()), res)
+		return
+	}
+
 	data := map[string]interface{}{
-		"result": txns,
+		"result":     txns,
+		"contacts":   contacts,
+		"accounts":   accounts,
+		"params":     params,
+		"directions": walletcore.TransactionDirectionNames,
+		"types":      walletcore.TransactionTypeNames,
+		"sortOrders": walletcore.SortOrders,
+	}
+
+	// only show the next page link if the current page is full
+	if query.Limit > 0 && len(txns) == query.Limit {
+		data["nextPage"] = pageQueryString(params, query.Offset+query.Limit)
+	}
+	if query.Offset > 0 && query.Limit > 0 {
+		previousOffset := query.Offset - query.Limit
+		if previousOffset < 0 {
+			previousOffset = 0
+		}
+		data["previousPage"] = pageQueryString(params, previousOffset)
+	}
+
+	// export links use the current filter but not the current page
+	exportParams := url.Values{}
+	for key, values := range params {
+		exportParams[key] = values
 	}
+	exportParams.Del("offset")
+	exportParams.Set("format", export.FormatCSV)
+	data["exportCSV"] = exportParams.Encode()
+	exportParams.Set("format", export.FormatOFX)
+	data["exportOFX"] = exportParams.Encode()
+
 	routes.render("history.html", data, res)
 }
 
+// exportHistory sends the transactions matching the history filter query parameters as a csv or ofx file download
+func (routes *Routes) exportHistory(res http.ResponseWriter, req *http.Request) {
+	params := req.URL.Query()
+
+	// export all matching transactions, ignoring the history page's paging
+	params.Del("offset")
+	query, err := HistoryQueryFromParams(params, 0)
+	if err != nil {
+		routes.renderError(fmt.Sprintf("Invalid history filter: %s", err.Error()), res)
+		return
+	}
+
+	format := params.Get("format")
+	if format == "" {
+		format = export.FormatCSV
+	}
+
+	var exportData bytes.Buffer
+	err = export.TransactionHistory(routes.walletMiddleware, query, format, &exportData)
+	if err != nil {
+		routes.renderError(fmt.Sprintf("Error exporting history: %s", err.Error()), res)
+		return
+	}
+
+	contentType := "text/csv"
+	if format == export.FormatOFX {
+		contentType = "application/x-ofx"
+	}
+	fileName := fmt.Sprintf("godcr-%s-history.%s", routes.walletMiddleware.NetType(), format)
+
+	res.Header().Set("Content-Type", contentType)
+	res.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	res.Write(exportData.Bytes())
+}
+
 func (routes *Routes) transactionDetailsPage(res http.ResponseWriter, req *http.Request) {
 	hash := chi.URLParam(req, "hash")
 	tx, err := routes.walletMiddleware.GetTransaction(hash)
@@ -252,8 +495,41 @@ func (routes *Routes) transactionDetailsPage(res http.ResponseWriter, req *http.
 		return
 	}
 
+	book, err := routes.addressBook()
+	if err != nil {
+		routes.renderError(err.Error(), res)
+		return
+	}
+
 	data := map[string]interface{}{
-		"tx": tx,
+		"tx":           tx,
+		"contactNames": outputContactNames(book, tx),
 	}
 	routes.render("transaction_details.html", data, res)
 }
+
+func (routes *Routes) walletsPage(res http.ResponseWriter, req *http.Request) {
+	data := map[string]interface{}{
+		"profiles":       routes.walletRegistry.Profiles(),
+		"currentProfile": routes.walletRegistry.CurrentProfile(),
+	}
+	routes.render("wallets.html", data, res)
+}
+
+func (routes *Routes) switchWallet(res http.ResponseWriter, req *http.Request) {
+	req.ParseForm()
+	profileName := req.FormValue("wallet")
+
+	err := routes.walletRegistry.SwitchWallet(profileName)
+	if err != nil {
+		routes.renderError(fmt.Sprintf("Error switching wallet: %s", err.Error()), res)
+		return
+	}
+
+	// each wallet is synced the first time it is selected, wallets that have been synced keep syncing in the background
+	if routes.walletMiddleware.IsWalletOpen() && routes.blockchain().status() == syncStatusNotStarted {
+		routes.syncBlockchain()
+	}
+
+	http.Redirect(res, req, "/", 303)
+}
@@ -0,0 +1,120 @@
+package routes
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/raedahgroup/godcr/app/walletcore"
+)
+
+// historyPageSize is the number of transactions displayed per page on the history page
+const historyPageSize = 50
+
+// HistoryQueryFromParams creates a transaction history query from url query parameters.
+// Supported parameters are account, direction and type (each can be repeated), since and until (YYYY-MM-DD),
+// min-amount and max-amount (in DCR), sort, offset and limit.
+// `defaultLimit` is used if the limit parameter is not set.
+func HistoryQueryFromParams(params url.Values, defaultLimit int) (*walletcore.TransactionHistoryQuery, error) {
+	query := &walletcore.TransactionHistoryQuery{
+		SortOrder: params.Get("sort"),
+		Limit:     defaultLimit,
+	}
+
+	for _, accountStr := range params["account"] {
+		if accountStr == "" {
+			continue
+		}
+		account, err := strconv.ParseUint(accountStr, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid account number: %s", accountStr)
+		}
+		query.Accounts = append(query.Accounts, uint32(account))
+	}
+
+	for _, directionName := range params["direction"] {
+		if directionName == "" {
+			continue
+		}
+		direction, err := walletcore.ParseTransactionDirection(directionName)
+		if err != nil {
+			return nil, err
+		}
+		query.Directions = append(query.Directions, direction)
+	}
+
+	for _, typeName := range params["type"] {
+		if typeName == "" {
+			continue
+		}
+		txType, err := walletcore.ParseTransactionType(typeName)
+		if err != nil {
+			return nil, err
+		}
+		query.Types = append(query.Types, txType)
+	}
+
+	var err error
+	if since := params.Get("since"); since != "" {
+		if query.StartTime, err = walletcore.ParseHistoryDate(since, false); err != nil {
+			return nil, err
+		}
+	}
+	if until := params.Get("until"); until != "" {
+		if query.EndTime, err = walletcore.ParseHistoryDate(until, true); err != nil {
+			return nil, err
+		}
+	}
+
+	if query.MinAmount, err = amountParam(params, "min-amount"); err != nil {
+		return nil, err
+	}
+	if query.MaxAmount, err = amountParam(params, "max-amount"); err != nil {
+		return nil, err
+	}
+
+	if offset := params.Get("offset"); offset != "" {
+		if query.Offset, err = strconv.Atoi(offset); err != nil {
+			return nil, fmt.Errorf("invalid offset: %s", offset)
+		}
+	}
+	if limit := params.Get("limit"); limit != "" {
+		if query.Limit, err = strconv.Atoi(limit); err != nil {
+			return nil, fmt.Errorf("invalid limit: %s", limit)
+		}
+	}
+
+	if err = query.Validate(); err != nil {
+		return nil, err
+	}
+	return query, nil
+}
+
+func amountParam(params url.Values, name string) (dcrutil.Amount, error) {
+	value := params.Get(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	amountDcr, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %s", name, value)
+	}
+
+	amount, err := dcrutil.NewAmount(amountDcr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %s", name, err.Error())
+	}
+	return amount, nil
+}
+
+// pageQueryString returns the url query string for the history page at `offset`, preserving other query parameters
+func pageQueryString(params url.Values, offset int) string {
+	pageParams := url.Values{}
+	for key, values := range params {
+		pageParams[key] = values
+	}
+	pageParams.Set("offset", strconv.Itoa(offset))
+	return pageParams.Encode()
+}
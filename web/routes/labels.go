@@ -0,0 +1,55 @@
+package routes
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/raedahgroup/godcr/app/walletcore"
+)
+
+// setTransactionLabel saves the label of the transaction or, if the output form field is set, of one of its outputs.
+// An empty label removes the label
+func (routes *Routes) setTransactionLabel(res http.ResponseWriter, req *http.Request) {
+	req.ParseForm()
+	hash := chi.URLParam(req, "hash")
+
+	labelType, ref := walletcore.LabelTypeTransaction, hash
+	if outputIndex := req.FormValue("output"); outputIndex != "" {
+		if _, err := strconv.ParseUint(outputIndex, 10, 32); err != nil {
+			routes.renderError(fmt.Sprintf("Invalid output index: %s", outputIndex), res)
+			return
+		}
+		labelType, ref = walletcore.LabelTypeOutput, fmt.Sprintf("%s:%s", hash, outputIndex)
+	}
+
+	if err := routes.walletMiddleware.SetLabel(labelType, ref, req.FormValue("label")); err != nil {
+		routes.renderError(fmt.Sprintf("Error saving label: %s", err.Error()), res)
+		return
+	}
+
+	http.Redirect(res, req, "/transaction_details/"+hash, 303)
+}
+
+// exportLabels sends all labels as a BIP-329 json lines file download
+func (routes *Routes) exportLabels(res http.ResponseWriter, req *http.Request) {
+	labels, err := routes.walletMiddleware.Labels()
+	if err != nil {
+		routes.renderError(fmt.Sprintf("Error fetching labels: %s", err.Error()), res)
+		return
+	}
+
+	var exportData bytes.Buffer
+	if err = walletcore.WriteLabels(labels, &exportData); err != nil {
+		routes.renderError(fmt.Sprintf("Error exporting labels: %s", err.Error()), res)
+		return
+	}
+
+	fileName := fmt.Sprintf("godcr-%s-labels.jsonl", routes.walletMiddleware.NetType())
+
+	res.Header().Set("Content-Type", "application/jsonl")
+	res.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	res.Write(exportData.Bytes())
+}
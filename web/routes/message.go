@@ -0,0 +1,59 @@
+package routes
+
+import (
+	"net/http"
+)
+
+func (routes *Routes) messagePage(res http.ResponseWriter, req *http.Request) {
+	data := map[string]interface{}{
+		"watchingOnly": routes.walletMiddleware.IsWatchingOnlyWallet(),
+	}
+	routes.render("message.html", data, res)
+}
+
+// signMessage signs the submitted message and shows the message page again with the signature or the error
+func (routes *Routes) signMessage(res http.ResponseWriter, req *http.Request) {
+	req.ParseForm()
+	address := req.FormValue("address")
+	message := req.FormValue("message")
+
+	data := map[string]interface{}{
+		"watchingOnly": routes.walletMiddleware.IsWatchingOnlyWallet(),
+		"signAddress":  address,
+		"signMessage":  message,
+	}
+
+	signature, err := routes.walletMiddleware.SignMessage(address, message, req.FormValue("passphrase"))
+	if err != nil {
+		data["signError"] = err.Error()
+	} else {
+		data["signature"] = signature
+	}
+
+	routes.render("message.html", data, res)
+}
+
+// verifyMessage checks the submitted signature and shows the message page again with the result
+func (routes *Routes) verifyMessage(res http.ResponseWriter, req *http.Request) {
+	req.ParseForm()
+	address := req.FormValue("address")
+	message := req.FormValue("message")
+	signature := req.FormValue("signature")
+
+	data := map[string]interface{}{
+		"watchingOnly":    routes.walletMiddleware.IsWatchingOnlyWallet(),
+		"verifyAddress":   address,
+		"verifyMessage":   message,
+		"verifySignature": signature,
+	}
+
+	valid, err := routes.walletMiddleware.VerifyMessage(address, message, signature)
+	if err != nil {
+		data["verifyError"] = err.Error()
+	} else {
+		data["verified"] = true
+		data["valid"] = valid
+	}
+
+	routes.render("message.html", data, res)
+}
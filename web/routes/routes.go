@@ -3,30 +3,42 @@ package routes
 import (
 	"html/template"
 	"log"
+	"sync"
 
 	"github.com/go-chi/chi"
 	"github.com/raedahgroup/godcr/app"
+	"github.com/raedahgroup/godcr/app/walletregistry"
 )
 
 // Routes holds data required to process web server routes and display appropriate content on a page
 type Routes struct {
 	walletMiddleware app.WalletMiddleware
+	walletRegistry   *walletregistry.Registry
 	templates        map[string]*template.Template
-	blockchain       *Blockchain
+
+	// blockchain sync status of each wallet profile, see routes.blockchain()
+	blockchainsMu sync.Mutex
+	blockchains   map[string]*Blockchain
+
+	// seed and seed word positions of the seed backup being verified on the create wallet or seed backup page
+	seedVerification seedVerification
 }
 
 // Setup prepares page templates and creates route handlers, returns syncBlockchain function
-func Setup(walletMiddleware app.WalletMiddleware, router chi.Router) func() {
+// and a function that reports if the blockchain has been synced, for use by the json api
+// Wallet operations are performed on the wallet selected in `walletRegistry`
+func Setup(walletRegistry *walletregistry.Registry, router chi.Router) (syncBlockchain func(), checkBlockchainSynced func() error) {
 	routes := &Routes{
-		walletMiddleware: walletMiddleware,
+		walletMiddleware: walletRegistry,
+		walletRegistry:   walletRegistry,
 		templates:        map[string]*template.Template{},
-		blockchain:       &Blockchain{},
+		blockchains:      map[string]*Blockchain{},
 	}
 
 	routes.loadTemplates()
 	routes.loadRoutes(router)
 
-	return routes.syncBlockchain
+	return routes.syncBlockchain, routes.checkBlockchainSynced
 }
 
 func (routes *Routes) loadTemplates() {
@@ -46,6 +58,24 @@ func (routes *Routes) loadTemplates() {
 func (routes *Routes) loadRoutes(router chi.Router) {
 	router.Get("/createwallet", routes.createWalletPage)
 	router.Post("/createwallet", routes.createWallet)
+	router.Get("/restorewallet", routes.restoreWalletPage)
+	router.Post("/restorewallet", routes.restoreWallet)
+
+	// wallets with a custom public passphrase are opened with the passphrase entered on the open wallet form
+	router.Post("/openwallet", routes.openWallet)
+
+	// wallets can be switched even when the selected wallet does not exist or is not synced
+	router.Get("/wallets", routes.walletsPage)
+	router.Post("/wallets/switch", routes.switchWallet)
+
+	// the address book is kept by godcr for each network, it does not require the wallet to be loaded
+	router.Get("/addressbook", routes.addressBookPage)
+	router.Post("/addressbook/add", routes.addContact)
+	router.Post("/addressbook/edit", routes.editContact)
+	router.Post("/addressbook/remove", routes.removeContact)
+
+	// wallet events are streamed even while the blockchain is syncing, so that pages can display sync progress
+	router.Get("/ws", routes.walletEventsWebsocket)
 
 	// use router group for routes that require wallet to be loaded before being accessed
 	router.Group(routes.registerRoutesRequiringWallet)
@@ -59,9 +89,26 @@ func (routes *Routes) registerRoutesRequiringWallet(router chi.Router) {
 	router.Get("/", routes.balancePage)
 	router.Get("/send", routes.sendPage)
 	router.Post("/send", routes.submitSendTxForm)
+	router.Post("/send/estimate", routes.estimateSendTxForm)
 	router.Get("/receive", routes.receivePage)
 	router.Get("/generate-address/{accountNumber}", routes.generateReceiveAddress)
 	router.Get("/unspent-outputs/{accountNumber}", routes.getUnspentOutputs)
 	router.Get("/history", routes.historyPage)
+	router.Get("/history/export", routes.exportHistory)
 	router.Get("/transaction_details/{hash}", routes.transactionDetailsPage)
+	router.Post("/transaction_details/{hash}/label", routes.setTransactionLabel)
+	router.Get("/labels/export", routes.exportLabels)
+	router.Get("/staking", routes.stakingPage)
+	router.Post("/staking/revoke", routes.revokeTickets)
+	router.Get("/message", routes.messagePage)
+	router.Post("/message/sign", routes.signMessage)
+	router.Post("/message/verify", routes.verifyMessage)
+	router.Get("/accounts", routes.accountsPage)
+	router.Get("/settings", routes.settingsPage)
+	router.Post("/settings/passphrase", routes.changePassphrase)
+	router.Get("/seedbackup", routes.seedBackupPage)
+	router.Post("/seedbackup", routes.seedBackup)
+	router.Post("/accounts/create", routes.createAccount)
+	router.Post("/accounts/rename", routes.renameAccount)
+	router.Post("/accounts/hide", routes.hideAccount)
 }
@@ -0,0 +1,175 @@
+package routes
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/raedahgroup/godcr/app/config"
+	"github.com/raedahgroup/godcr/app/walletregistry"
+)
+
+func TestMain(m *testing.M) {
+	// templates are loaded from paths relative to the repository root, where godcr is run from
+	if err := os.Chdir("../.."); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
+// newTestRoutes sets up the routes for a registry with a single mock wallet profile, as Setup does
+func newTestRoutes(t *testing.T, emptyWallet bool) (*Routes, chi.Router) {
+	profiles := []*config.WalletProfile{{
+		Name: config.DefaultWalletProfileName,
+		WalletOptions: config.WalletOptions{
+			UseTestNet:      true,
+			UseMockWallet:   true,
+			EmptyMockWallet: emptyWallet,
+		},
+	}}
+	registry, err := walletregistry.New(context.Background(), profiles, "")
+	if err != nil {
+		t.Fatalf("error creating wallet registry: %s", err.Error())
+	}
+
+	routes := &Routes{
+		walletMiddleware: registry,
+		walletRegistry:   registry,
+		templates:        map[string]*template.Template{},
+		blockchains:      map[string]*Blockchain{},
+	}
+	routes.loadTemplates()
+
+	router := chi.NewRouter()
+	routes.loadRoutes(router)
+	return routes, router
+}
+
+// waitForSync waits for the scripted sync of the mock wallet to complete
+func waitForSync(t *testing.T, routes *Routes) {
+	for start := time.Now(); time.Since(start) < 30*time.Second; time.Sleep(100 * time.Millisecond) {
+		switch routes.blockchain().status() {
+		case syncStatusSuccess:
+			return
+		case syncStatusError:
+			t.Fatalf("blockchain sync failed: %s", routes.blockchain().report())
+		}
+	}
+	t.Fatal("blockchain sync did not complete")
+}
+
+// get sends a GET request for `path` to `router` and returns the response
+func get(router chi.Router, path string) *httptest.ResponseRecorder {
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, httptest.NewRequest(http.MethodGet, path, nil))
+	return res
+}
+
+// post submits `form` to `path` and returns the response
+func post(router chi.Router, path string, form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+	return res
+}
+
+// checkPage fails the test if the page at `path` is not displayed or shows an error
+func checkPage(t *testing.T, router chi.Router, path string) {
+	res := get(router, path)
+	if res.Code != http.StatusOK {
+		t.Errorf("%s: status %d", path, res.Code)
+		return
+	}
+	if strings.Contains(res.Body.String(), `id="wallet-not-ready"`) {
+		t.Errorf("%s: error page displayed: %s", path, res.Body.String())
+	}
+}
+
+func TestPagesWithMockWallet(t *testing.T) {
+	routes, router := newTestRoutes(t, false)
+	if err := routes.walletMiddleware.OpenWallet(""); err != nil {
+		t.Fatalf("error opening mock wallet: %s", err.Error())
+	}
+
+	if res := get(router, "/"); !strings.Contains(res.Body.String(), "Blockchain hasn't been synced") {
+		t.Errorf("pages were displayed before the blockchain was synced")
+	}
+
+	routes.syncBlockchain()
+	waitForSync(t, routes)
+
+	pages := []string{
+		"/", "/send", "/receive", "/history", "/history?sort=oldest&limit=2", "/staking", "/message",
+		"/accounts", "/settings", "/seedbackup", "/wallets",
+	}
+	for _, path := range pages {
+		checkPage(t, router, path)
+	}
+}
+
+func TestCreateWalletWithEmptyMockWallet(t *testing.T) {
+	routes, router := newTestRoutes(t, true)
+
+	if res := get(router, "/"); !strings.Contains(res.Body.String(), `href="/createwallet"`) {
+		t.Fatalf("create wallet link not displayed for missing wallet: %s", res.Body.String())
+	}
+
+	checkPage(t, router, "/createwallet")
+	seed := routes.seedVerification.getNewWalletSeed()
+	if seed == "" {
+		t.Fatal("seed for the new wallet was not kept on the server")
+	}
+
+	if res := post(router, "/createwallet", url.Values{"step": {"verify"}}); res.Code != http.StatusOK {
+		t.Fatalf("verify step: status %d", res.Code)
+	}
+	positions := routes.seedVerification.getPositions()
+	if len(positions) == 0 {
+		t.Fatal("no seed word positions were chosen")
+	}
+
+	seedWords := strings.Fields(seed)
+	wrongWords := make([]string, len(positions))
+	words := make([]string, len(positions))
+	for i, position := range positions {
+		wrongWords[i] = "wrong" + strconv.Itoa(i)
+		words[i] = seedWords[position]
+	}
+
+	form := url.Values{"step": {"create"}, "password": {"passphrase"}, "confirmPassword": {"passphrase"}, "word": wrongWords}
+	post(router, "/createwallet", form)
+	if exists, _ := routes.walletMiddleware.WalletExists(); exists {
+		t.Fatal("wallet was created with the wrong seed words")
+	}
+
+	form.Set("confirmPassword", "other passphrase")
+	form["word"] = words
+	post(router, "/createwallet", form)
+	if exists, _ := routes.walletMiddleware.WalletExists(); exists {
+		t.Fatal("wallet was created with mismatched passwords")
+	}
+
+	form.Set("confirmPassword", "passphrase")
+	if res := post(router, "/createwallet", form); res.Code != http.StatusSeeOther {
+		t.Fatalf("create step: status %d, expected a redirect: %s", res.Code, res.Body.String())
+	}
+	if exists, _ := routes.walletMiddleware.WalletExists(); !exists {
+		t.Fatal("wallet was not created")
+	}
+	if routes.walletMiddleware.HasUnconfirmedSeed() {
+		t.Error("verified seed was kept for a later backup")
+	}
+
+	waitForSync(t, routes)
+	checkPage(t, router, "/")
+	checkPage(t, router, "/accounts")
+}
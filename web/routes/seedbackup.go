@@ -0,0 +1,168 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/raedahgroup/godcr/app/walletcore"
+)
+
+// seedVerification keeps the state of seed backup verification on the server, so that the seed and the positions of the
+// words the user must enter are never taken from form values posted by the browser
+type seedVerification struct {
+	mu sync.Mutex
+
+	// newWalletSeed is the seed generated for the wallet being created on the create wallet page
+	newWalletSeed string
+
+	// positions are the 0-based positions of the seed words the user was last asked to enter
+	positions []int
+}
+
+// setNewWalletSeed keeps `seed` as the seed of the wallet being created and clears any chosen word positions
+func (verification *seedVerification) setNewWalletSeed(seed string) {
+	verification.mu.Lock()
+	defer verification.mu.Unlock()
+	verification.newWalletSeed = seed
+	verification.positions = nil
+}
+
+func (verification *seedVerification) getNewWalletSeed() string {
+	verification.mu.Lock()
+	defer verification.mu.Unlock()
+	return verification.newWalletSeed
+}
+
+// choosePositions randomly chooses the positions of the seed words the user will be asked to enter
+func (verification *seedVerification) choosePositions() ([]int, error) {
+	positions, err := walletcore.SeedVerificationPositions(walletcore.SeedWordCount)
+	if err != nil {
+		return nil, err
+	}
+
+	verification.mu.Lock()
+	defer verification.mu.Unlock()
+	verification.positions = positions
+	return positions, nil
+}
+
+func (verification *seedVerification) getPositions() []int {
+	verification.mu.Lock()
+	defer verification.mu.Unlock()
+	return verification.positions
+}
+
+// verify checks the words entered on the seed verification form against `seed`, at the positions chosen by choosePositions
+func (verification *seedVerification) verify(req *http.Request, seed string) error {
+	positions := verification.getPositions()
+	if len(positions) == 0 {
+		return fmt.Errorf("no seed words were requested, start the seed verification again")
+	}
+	return walletcore.VerifySeedWords(seed, positions, req.Form["word"])
+}
+
+// reset clears the seed and word positions once the verification is complete
+func (verification *seedVerification) reset() {
+	verification.setNewWalletSeed("")
+}
+
+// seedWordInput is a seed word the user is asked to enter to verify their seed backup
+type seedWordInput struct {
+	Number int
+}
+
+func seedWordInputs(positions []int) []seedWordInput {
+	inputs := make([]seedWordInput, len(positions))
+	for i, position := range positions {
+		inputs[i] = seedWordInput{Number: position + 1}
+	}
+	return inputs
+}
+
+// renderSeedStep shows `seed` on the page `templateName`, with a button to go on to verifying the seed backup
+func (routes *Routes) renderSeedStep(templateName, seed string, res http.ResponseWriter) {
+	data := map[string]interface{}{
+		"step": "seed",
+		"seed": seed,
+	}
+	routes.render(templateName, data, res)
+}
+
+// renderVerifyStep asks for the seed words at the positions last chosen on the page `templateName`,
+// choosing new positions if none were chosen
+func (routes *Routes) renderVerifyStep(templateName, errorMessage string, res http.ResponseWriter) {
+	positions := routes.seedVerification.getPositions()
+	if len(positions) == 0 {
+		var err error
+		if positions, err = routes.seedVerification.choosePositions(); err != nil {
+			routes.renderError(err.Error(), res)
+			return
+		}
+	}
+
+	data := map[string]interface{}{
+		"step":       "verify",
+		"wordInputs": seedWordInputs(positions),
+		"error":      errorMessage,
+	}
+	routes.render(templateName, data, res)
+}
+
+// seedBackupPage asks for the spending passphrase to display the seed of a wallet whose seed backup was not verified
+func (routes *Routes) seedBackupPage(res http.ResponseWriter, req *http.Request) {
+	routes.renderSeedBackupPage("", res)
+}
+
+func (routes *Routes) renderSeedBackupPage(errorMessage string, res http.ResponseWriter) {
+	data := map[string]interface{}{
+		"step":       "passphrase",
+		"unverified": routes.walletMiddleware.HasUnconfirmedSeed(),
+		"error":      errorMessage,
+	}
+	routes.render("seedbackup.html", data, res)
+}
+
+// seedBackup moves through the steps of verifying the backup of an unconfirmed seed:
+// decrypting and displaying the seed, asking for some of its words and deleting the seed once the words are correct.
+// The seed is decrypted with the passphrase whenever it is needed, it is never sent back by the browser
+func (routes *Routes) seedBackup(res http.ResponseWriter, req *http.Request) {
+	req.ParseForm()
+
+	switch req.FormValue("step") {
+	case "seed":
+		seed, err := routes.walletMiddleware.UnconfirmedSeed(req.FormValue("passphrase"))
+		if err != nil {
+			routes.renderSeedBackupPage(err.Error(), res)
+			return
+		}
+		routes.renderSeedStep("seedbackup.html", seed, res)
+
+	case "verify":
+		if _, err := routes.seedVerification.choosePositions(); err != nil {
+			routes.renderError(err.Error(), res)
+			return
+		}
+		routes.renderVerifyStep("seedbackup.html", "", res)
+
+	case "confirm":
+		seed, err := routes.walletMiddleware.UnconfirmedSeed(req.FormValue("passphrase"))
+		if err != nil {
+			routes.renderVerifyStep("seedbackup.html", err.Error(), res)
+			return
+		}
+		if err := routes.seedVerification.verify(req, seed); err != nil {
+			routes.renderVerifyStep("seedbackup.html", err.Error(), res)
+			return
+		}
+		if err := routes.walletMiddleware.ConfirmSeedBackup(); err != nil {
+			routes.renderError(fmt.Sprintf("Error confirming seed backup: %s", err.Error()), res)
+			return
+		}
+		routes.seedVerification.reset()
+		http.Redirect(res, req, "/", 303)
+
+	default:
+		routes.renderSeedBackupPage("", res)
+	}
+}
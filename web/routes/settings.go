@@ -0,0 +1,87 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/raedahgroup/godcr/app"
+)
+
+func (routes *Routes) settingsPage(res http.ResponseWriter, req *http.Request) {
+	routes.renderSettings(res, nil, "")
+}
+
+func (routes *Routes) changePassphrase(res http.ResponseWriter, req *http.Request) {
+	req.ParseForm()
+	passphraseType := req.FormValue("passphrase-type")
+	oldPassphrase := req.FormValue("old-passphrase")
+	newPassphrase := req.FormValue("new-passphrase")
+
+	if newPassphrase == "" {
+		routes.renderSettings(res, fmt.Errorf("the new passphrase cannot be empty"), "")
+		return
+	}
+	if newPassphrase != req.FormValue("confirm-passphrase") {
+		routes.renderSettings(res, fmt.Errorf("the new passphrases do not match"), "")
+		return
+	}
+
+	var err error
+	var success string
+	switch passphraseType {
+	case "private":
+		err = routes.walletMiddleware.ChangePrivatePassphrase(oldPassphrase, newPassphrase)
+		success = "Spending passphrase changed"
+	case "public":
+		err = routes.walletMiddleware.ChangePublicPassphrase(oldPassphrase, newPassphrase)
+		success = "Public passphrase changed"
+	default:
+		err = fmt.Errorf("unknown passphrase type: %s", passphraseType)
+	}
+
+	if err != nil {
+		routes.renderSettings(res, err, "")
+		return
+	}
+	routes.renderSettings(res, nil, success)
+}
+
+func (routes *Routes) renderSettings(res http.ResponseWriter, err error, success string) {
+	data := map[string]interface{}{
+		"watchingOnly":            routes.walletMiddleware.IsWatchingOnlyWallet(),
+		"defaultPublicPassphrase": app.DefaultPublicPassphrase,
+		"success":                 success,
+	}
+	if err != nil {
+		data["error"] = err.Error()
+	}
+	routes.render("settings.html", data, res)
+}
+
+// openWallet opens a wallet that uses a custom public passphrase with the public passphrase entered by the user,
+// then syncs the blockchain if the wallet has not been synced
+func (routes *Routes) openWallet(res http.ResponseWriter, req *http.Request) {
+	req.ParseForm()
+
+	if !routes.walletMiddleware.IsWalletOpen() {
+		err := routes.walletMiddleware.OpenWallet(req.FormValue("public-passphrase"))
+		if err != nil {
+			routes.renderOpenWallet(res, err)
+			return
+		}
+	}
+
+	if status := routes.blockchain().status(); status == syncStatusNotStarted || status == syncStatusError {
+		routes.syncBlockchain()
+	}
+
+	http.Redirect(res, req, "/", 303)
+}
+
+func (routes *Routes) renderOpenWallet(res http.ResponseWriter, err error) {
+	data := map[string]interface{}{}
+	if err != nil {
+		data["error"] = err.Error()
+	}
+	routes.render("openwallet.html", data, res)
+}
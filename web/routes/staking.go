@@ -0,0 +1,118 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/raedahgroup/godcr/app/walletcore"
+)
+
+// TicketFilterFromParams creates a ticket filter from url query parameters.
+// Supported parameters are status and account (each can be repeated) and limit
+func TicketFilterFromParams(params url.Values) (*walletcore.TicketFilter, error) {
+	filter := &walletcore.TicketFilter{}
+
+	for _, statusName := range params["status"] {
+		if statusName == "" {
+			continue
+		}
+		status, err := walletcore.ParseTicketStatus(statusName)
+		if err != nil {
+			return nil, err
+		}
+		filter.Statuses = append(filter.Statuses, status)
+	}
+
+	for _, accountStr := range params["account"] {
+		if accountStr == "" {
+			continue
+		}
+		account, err := strconv.ParseUint(accountStr, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid account number: %s", accountStr)
+		}
+		filter.Accounts = append(filter.Accounts, uint32(account))
+	}
+
+	if limit := params.Get("limit"); limit != "" {
+		var err error
+		if filter.Limit, err = strconv.Atoi(limit); err != nil || filter.Limit < 0 {
+			return nil, fmt.Errorf("invalid limit: %s", limit)
+		}
+	}
+
+	return filter, nil
+}
+
+// stakingPage shows the wallet's stake info and lists its tickets, filtered by the status and account url parameters
+func (routes *Routes) stakingPage(res http.ResponseWriter, req *http.Request) {
+	routes.renderStakingPage(map[string]interface{}{}, res, req)
+}
+
+// revokeTickets revokes the ticket whose hash is in the ticket form value, or all missed and expired tickets if no hash is set,
+// then shows the staking page with the tickets that were revoked
+func (routes *Routes) revokeTickets(res http.ResponseWriter, req *http.Request) {
+	req.ParseForm()
+	ticketHash := req.FormValue("ticket")
+	passphrase := req.FormValue("passphrase")
+
+	var revoked []*walletcore.RevokedTicket
+	var err error
+	if ticketHash == "" {
+		revoked, err = routes.walletMiddleware.RevokeTickets(req.Context(), passphrase)
+	} else {
+		var revocationHash string
+		revocationHash, err = routes.walletMiddleware.RevokeTicket(req.Context(), ticketHash, passphrase)
+		if err == nil {
+			revoked = append(revoked, &walletcore.RevokedTicket{TicketHash: ticketHash, RevocationHash: revocationHash})
+		}
+	}
+
+	data := map[string]interface{}{
+		"revoked": revoked,
+	}
+	if err != nil {
+		data["revokeError"] = err.Error()
+	} else if len(revoked) == 0 {
+		data["revokeError"] = "No missed or expired tickets to revoke"
+	}
+	routes.renderStakingPage(data, res, req)
+}
+
+// renderStakingPage adds the stake info, tickets and filter options to `data` and renders the staking page
+func (routes *Routes) renderStakingPage(data map[string]interface{}, res http.ResponseWriter, req *http.Request) {
+	params := req.URL.Query()
+	filter, err := TicketFilterFromParams(params)
+	if err != nil {
+		routes.renderError(fmt.Sprintf("Invalid ticket filter: %s", err.Error()), res)
+		return
+	}
+
+	stakeInfo, err := routes.walletMiddleware.StakeInfo(req.Context())
+	if err != nil {
+		routes.renderError(fmt.Sprintf("Error fetching stake info: %s", err.Error()), res)
+		return
+	}
+
+	tickets, err := routes.walletMiddleware.Tickets(req.Context(), filter)
+	if err != nil {
+		routes.renderError(fmt.Sprintf("Error fetching tickets: %s", err.Error()), res)
+		return
+	}
+
+	accounts, err := routes.walletMiddleware.AccountsOverview(walletcore.DefaultRequiredConfirmations)
+	if err != nil {
+		routes.renderError(fmt.Sprintf("Error fetching accounts: %s", err.Error()), res)
+		return
+	}
+
+	data["stakeInfo"] = stakeInfo
+	data["tickets"] = tickets
+	data["accounts"] = accounts
+	data["statuses"] = walletcore.TicketStatuses
+	data["params"] = params
+	data["watchingOnly"] = routes.walletMiddleware.IsWatchingOnlyWallet()
+	routes.render("staking.html", data, res)
+}
@@ -3,6 +3,8 @@ package routes
 import (
 	"fmt"
 	"html/template"
+	"net/url"
+	"strings"
 
 	"github.com/decred/dcrd/dcrutil"
 	"github.com/raedahgroup/godcr/app/walletcore"
@@ -17,11 +19,20 @@ func templates() []templateData {
 	return []templateData{
 		{"error.html", "web/views/error.html"},
 		{"createwallet.html", "web/views/createwallet.html"},
+		{"restorewallet.html", "web/views/restorewallet.html"},
 		{"balance.html", "web/views/balance.html"},
 		{"send.html", "web/views/send.html"},
 		{"receive.html", "web/views/receive.html"},
 		{"history.html", "web/views/history.html"},
 		{"transaction_details.html", "web/views/transaction_details.html"},
+		{"wallets.html", "web/views/wallets.html"},
+		{"addressbook.html", "web/views/addressbook.html"},
+		{"accounts.html", "web/views/accounts.html"},
+		{"message.html", "web/views/message.html"},
+		{"openwallet.html", "web/views/openwallet.html"},
+		{"settings.html", "web/views/settings.html"},
+		{"seedbackup.html", "web/views/seedbackup.html"},
+		{"staking.html", "web/views/staking.html"},
 	}
 }
 
@@ -37,5 +48,17 @@ func templateFuncMap() template.FuncMap {
 		"amountDcr": func(amount int64) string {
 			return dcrutil.Amount(amount).String()
 		},
+		"join": strings.Join,
+		"paramValue": func(params url.Values, key string) string {
+			return params.Get(key)
+		},
+		"paramHasValue": func(params url.Values, key string, value interface{}) bool {
+			for _, paramValue := range params[key] {
+				if paramValue == fmt.Sprint(value) {
+					return true
+				}
+			}
+			return false
+		},
 	}
 }
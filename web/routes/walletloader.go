@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"sync"
@@ -32,7 +33,7 @@ func (routes *Routes) walletLoaderMiddleware() func(http.Handler) http.Handler {
 // walletLoaderFn checks if wallet is not open, attempts to open it and also perform sync the blockchain
 // an error page is displayed and the actual route handler is not called, if ...
 // - wallet doesn't exist (hasn't been created)
-// - wallet exists but is not open
+// - wallet exists but is not open, a form to enter the wallet's public passphrase is displayed instead
 // - wallet is open but blockchain isn't synced
 func (routes *Routes) walletLoaderFn(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
@@ -55,22 +56,23 @@ func (routes *Routes) walletLoaderFn(next http.Handler) http.Handler {
 			return
 		}
 
+		// the wallet is only left unopened if it uses a custom public passphrase, ask the user for it
 		if !routes.walletMiddleware.IsWalletOpen() {
-			errMsg = "Wallet is not open. Restart the server"
+			routes.renderOpenWallet(res, nil)
 			return
 		}
 
 		// wallet is open, check if blockchain is synced
-		blockchainSyncStatus := routes.blockchain.status()
+		blockchainSyncStatus := routes.blockchain().status()
 		switch blockchainSyncStatus {
 		case syncStatusSuccess:
 			next.ServeHTTP(res, req)
 		case syncStatusNotStarted:
 			errMsg = "Cannot display page. Blockchain hasn't been synced"
 		case syncStatusInProgress:
-			errMsg = fmt.Sprintf("%s. Refresh after a while to access this page", routes.blockchain.report())
+			errMsg = fmt.Sprintf("%s. Refresh after a while to access this page", routes.blockchain().report())
 		case syncStatusError:
-			errMsg = fmt.Sprintf("Cannot display page. %s", routes.blockchain.report())
+			errMsg = fmt.Sprintf("Cannot display page. %s", routes.blockchain().report())
 		default:
 			errMsg = "Cannot display page. Blockchain sync status cannot be determined"
 		}
@@ -78,7 +80,8 @@ func (routes *Routes) walletLoaderFn(next http.Handler) http.Handler {
 }
 
 func (routes *Routes) syncBlockchain() {
-	updateStatus := routes.blockchain.updateStatus
+	// status updates go to the blockchain of the wallet selected when the sync started, even if the user switches wallets
+	updateStatus := routes.blockchain().updateStatus
 
 	err := routes.walletMiddleware.SyncBlockChain(&app.BlockChainSyncListener{
 		SyncStarted: func() {
@@ -107,6 +110,36 @@ func (routes *Routes) syncBlockchain() {
 	}
 }
 
+// checkBlockchainSynced returns nil if the blockchain has been synced successfully
+// otherwise it returns an error describing the current sync status
+func (routes *Routes) checkBlockchainSynced() error {
+	switch routes.blockchain().status() {
+	case syncStatusSuccess:
+		return nil
+	case syncStatusNotStarted:
+		return errors.New("Blockchain hasn't been synced")
+	case syncStatusInProgress, syncStatusError:
+		return errors.New(routes.blockchain().report())
+	default:
+		return errors.New("Blockchain sync status cannot be determined")
+	}
+}
+
+// blockchain returns the blockchain sync status of the selected wallet
+func (routes *Routes) blockchain() *Blockchain {
+	profileName := routes.walletRegistry.CurrentProfile().Name
+
+	routes.blockchainsMu.Lock()
+	defer routes.blockchainsMu.Unlock()
+
+	blockchain, ok := routes.blockchains[profileName]
+	if !ok {
+		blockchain = &Blockchain{}
+		routes.blockchains[profileName] = blockchain
+	}
+	return blockchain
+}
+
 func (b *Blockchain) updateStatus(report string, status syncStatus) {
 	b.Lock()
 	b._status = status
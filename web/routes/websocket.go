@@ -0,0 +1,56 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/raedahgroup/godcr/app"
+)
+
+// websocketWriteTimeout is the maximum time allowed to write an event to a websocket client
+const websocketWriteTimeout = 10 * time.Second
+
+var websocketUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// walletEventsWebsocket streams wallet events such as sync progress, new blocks, transactions and ticket status changes
+// to the connected websocket client until the client disconnects
+func (routes *Routes) walletEventsWebsocket(res http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := routes.walletMiddleware.Subscribe(ctx)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	conn, err := websocketUpgrader.Upgrade(res, req, nil)
+	if err != nil {
+		// Upgrade already responded to the client with an error
+		return
+	}
+	defer conn.Close()
+
+	// the client does not send messages, reading is only necessary to detect when the client disconnects
+	go func() {
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				cancel()
+				return
+			}
+		}
+	}()
+
+	for event := range events {
+		conn.SetWriteDeadline(time.Now().Add(websocketWriteTimeout))
+		err = conn.WriteJSON(app.NewEventMessage(event))
+		if err != nil {
+			return
+		}
+	}
+}
@@ -12,14 +12,16 @@ import (
 
 	"github.com/go-chi/chi"
 	"github.com/raedahgroup/godcr/app"
+	"github.com/raedahgroup/godcr/app/walletregistry"
+	"github.com/raedahgroup/godcr/web/api"
 	"github.com/raedahgroup/godcr/web/routes"
 )
 
-func StartServer(ctx context.Context, walletMiddleware app.WalletMiddleware, host, port string) error {
+func StartServer(ctx context.Context, walletRegistry *walletregistry.Registry, host, port string) error {
 	router := chi.NewRouter()
 
 	// first try to load wallet if it exists
-	err := openWalletIfExist(ctx, walletMiddleware)
+	err := openWalletIfExist(ctx, walletRegistry)
 	if err != nil {
 		return err
 	}
@@ -30,7 +32,10 @@ func StartServer(ctx context.Context, walletMiddleware app.WalletMiddleware, hos
 	makeStaticFileServer(router, "/static", http.Dir(filesDir))
 
 	// setup routes for templated pages, returns wallet loader function
-	syncBlockchain := routes.Setup(walletMiddleware, router)
+	syncBlockchain, checkBlockchainSynced := routes.Setup(walletRegistry, router)
+
+	// setup json api routes, these share the blockchain sync status of the templated pages
+	router.Mount("/api/"+api.Version, api.Router(walletRegistry, checkBlockchainSynced))
 
 	fmt.Println("Starting web server")
 	serverAddress := net.JoinHostPort(host, port)
@@ -45,7 +50,10 @@ func StartServer(ctx context.Context, walletMiddleware app.WalletMiddleware, hos
 		fmt.Println("Web server stopped")
 		return err
 	}
-	syncBlockchain()
+	// wallets that are not yet open are synced after they are opened from the browser
+	if exists, _ := walletRegistry.WalletExists(); !exists || walletRegistry.IsWalletOpen() {
+		syncBlockchain()
+	}
 
 	// keep alive till ctx is canceled
 	<-ctx.Done()
@@ -74,8 +82,12 @@ func openWalletIfExist(ctx context.Context, walletMiddleware app.WalletMiddlewar
 			return
 		}
 
-		err = walletMiddleware.OpenWallet()
-		if err != nil {
+		err = walletMiddleware.OpenWallet("")
+		if err == app.ErrInvalidPublicPassphrase {
+			// the public passphrase is entered on the open wallet form shown by the web wallet loader
+			fmt.Println("The wallet uses a custom public passphrase, open it from the browser")
+			err = nil
+		} else if err != nil {
 			errMsg = fmt.Sprintf("Failed to open %s wallet", walletMiddleware.NetType())
 		}
 	}()